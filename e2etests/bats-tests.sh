@@ -45,6 +45,21 @@ get_value_from() {
   [[ "${count}" == "6" ]]
 }
 
+@test "template-rego" {
+  tmp="tests/checks/rego.yml"
+  cmd="${KUBE_LINTER_BIN} lint --config e2etests/testdata/rego-config.yaml --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Deployment: deployment fire has no team label" ]]
+  [[ "${count}" == "1" ]]
+}
+
 @test "template-kubeconform" {
   tmp="tests/checks/kubeconform.yml"
   cmd="${KUBE_LINTER_BIN} lint --config e2etests/testdata/kubeconform-config.yaml --do-not-auto-add-defaults --format json ${tmp}"
@@ -1248,3 +1263,95 @@ get_value_from() {
   rm -f ${json_out} ${sarif_out}
 }
 
+@test "flag-ci-report-formats" {
+  tmp="tests/checks/run-as-non-root.yml"
+  junit_out=$(mktemp)
+  checkstyle_out=$(mktemp)
+  gitlab_out=$(mktemp)
+
+  cmd="${KUBE_LINTER_BIN} lint --format junit --output ${junit_out} --format checkstyle --output ${checkstyle_out} --format gitlab --output ${gitlab_out} ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+
+  grep -q '<testsuite name="run-as-non-root"' ${junit_out}
+  grep -q 'source="run-as-non-root"' ${checkstyle_out}
+
+  check_name=$(jq -r '.[0].check_name' ${gitlab_out})
+  fingerprints=$(jq -r '[.[].fingerprint] | unique | length' ${gitlab_out})
+  issues=$(jq -r 'length' ${gitlab_out})
+  [[ "${check_name}" == "run-as-non-root" ]]
+  [[ "${fingerprints}" == "${issues}" ]]
+  grep -q "fingerprint=\"$(jq -r '.[0].fingerprint' ${gitlab_out})\"" ${checkstyle_out}
+
+  # Cleanup
+  rm -f ${junit_out} ${checkstyle_out} ${gitlab_out}
+}
+
+@test "flag-summary-formats" {
+  tmp="tests/checks/run-as-non-root.yml"
+  html_out=$(mktemp)
+  cmd="${KUBE_LINTER_BIN} lint --format markdown --output /dev/stdout --format html --output ${html_out} ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  [[ "${output}" == *"<summary><b>run-as-non-root</b> (error)"* ]]
+  grep -q 'data-check="run-as-non-root"' ${html_out}
+
+  rm -f ${html_out}
+}
+
+@test "flag-template-format" {
+  tmp="tests/checks/run-as-non-root.yml"
+  cmd="${KUBE_LINTER_BIN} lint --format template --template-file example:csv ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  [[ "${lines[0]}" == "file,line,column,object,check,severity,message,field,remediation" ]]
+  [[ "${lines[1]}" == *",run-as-non-root,error,"* ]]
+}
+
+
+@test "structured-diagnostics" {
+  tmp="tests/checks/latest-tag.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include latest-tag --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  diagnostic=$(get_value_from "${lines[0]}" '.Reports[0].Diagnostic | [.Code, .Container, .Value] | join(" ")')
+  [[ "${diagnostic}" == "latest-tag/blocked-image app app:latest" ]]
+
+  sarif_out=$(mktemp)
+  cmd="${KUBE_LINTER_BIN} lint --include latest-tag --do-not-auto-add-defaults --format sarif --output ${sarif_out} ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  value=$(jq -r '.runs[0].results[0].properties.value' ${sarif_out})
+  [[ "${value}" == "app:latest" ]]
+
+  rm -f ${sarif_out}
+}
+
+@test "flag-stats" {
+  tmp="tests/checks/latest-tag.yml"
+  json_out=$(mktemp)
+  cmd="${KUBE_LINTER_BIN} lint --include latest-tag --do-not-auto-add-defaults --stats --format json --output ${json_out} ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  [[ "${output}" == *"Findings by check:"* ]]
+  findings=$(jq -r '.Summary.Stats.FindingsByCheck["latest-tag"]' ${json_out})
+  [[ "${findings}" == "2" ]]
+
+  rm -f ${json_out}
+}
@@ -0,0 +1,156 @@
+package admission
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/linter"
+	"golang.stackrox.io/kube-linter/pkg/run"
+	admissionV1 "k8s.io/api/admission/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ObjectFilePathPrefix is the prefix of the FilePath of objects received in admission requests.
+	// The full path is <admission>/<namespace>/<resource>/<name>.
+	ObjectFilePathPrefix = "<admission>"
+
+	// maxRequestBytes bounds the size of admission reviews. The API server itself limits objects to 3 MiB.
+	maxRequestBytes = 6 << 20
+)
+
+// Options represent values that can be provided to modify how admission requests are reviewed.
+type Options struct {
+	// FailOn is the minimum severity of findings that cause a request to be denied.
+	// Less severe findings are returned as warnings. If empty, config.DefaultSeverity is used.
+	FailOn config.Severity
+	// WarnOnly returns all findings as warnings, and never denies a request.
+	WarnOnly bool
+	// Timeout bounds the time spent linting the object of a request, which is then allowed with a warning.
+	// If it is zero, the object is linted until the request is canceled.
+	Timeout time.Duration
+}
+
+// A Handler serves a ValidatingAdmissionWebhook that lints the objects in admission requests.
+type Handler struct {
+	linter  *linter.Linter
+	options Options
+}
+
+// NewHandler creates a Handler that lints objects with the given Linter. Each object is linted on its own, so the
+// Linter should be created with linter.WithoutCrossObjectChecks.
+func NewHandler(l *linter.Linter, options Options) *Handler {
+	return &Handler{linter: l, options: options}
+}
+
+// ServeHTTP decodes an AdmissionReview request, and responds with the AdmissionReview returned by Review.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	if contentType := r.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "application/json") {
+		http.Error(w, fmt.Sprintf("unsupported content type %q", contentType), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	var review admissionV1.AdmissionReview
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&review); err != nil {
+		http.Error(w, fmt.Sprintf("decoding admission review: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "admission review has no request", http.StatusBadRequest)
+		return
+	}
+
+	response := h.Review(r.Context(), review.Request)
+	review.Response = response
+	review.Request = nil
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&review); err != nil {
+		http.Error(w, fmt.Sprintf("encoding admission review: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// Review lints the object of the given admission request.
+// Objects that cannot be decoded are allowed with a warning, so that the webhook does not block
+// resources that it does not understand, and so are objects that cannot be linted before ctx is done or the
+// Timeout of the Handler expires.
+func (h *Handler) Review(ctx context.Context, req *admissionV1.AdmissionRequest) *admissionV1.AdmissionResponse {
+	response := &admissionV1.AdmissionResponse{UID: req.UID, Allowed: true}
+	if req.Operation != admissionV1.Create && req.Operation != admissionV1.Update {
+		return response
+	}
+
+	name := req.Name
+	if name == "" {
+		// Objects created with generateName do not have a name yet.
+		name = "<generated>"
+	}
+	filePath := path.Join(ObjectFilePathPrefix, req.Namespace, req.Resource.Resource, name)
+	lintCtx, err := lintcontext.CreateContextFromReader(lintcontext.Options{}, filePath, bytes.NewReader(req.Object.Raw))
+	if err != nil {
+		response.Warnings = []string{fmt.Sprintf("kube-linter could not read the object: %v", err)}
+		return response
+	}
+	for _, invalidObj := range lintCtx.InvalidObjects() {
+		response.Warnings = append(response.Warnings, fmt.Sprintf("kube-linter could not decode the object: %v", invalidObj.LoadErr))
+	}
+	for _, obj := range lintCtx.Objects() {
+		// The namespace is often only set on the request, but checks expect it on the object.
+		if obj.K8sObject.GetNamespace() == "" {
+			obj.K8sObject.SetNamespace(req.Namespace)
+		}
+	}
+
+	if h.options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.options.Timeout)
+		defer cancel()
+	}
+	result, err := h.linter.Lint(ctx, linter.Contexts(lintCtx))
+	if err != nil {
+		response.Warnings = append(response.Warnings, fmt.Sprintf("kube-linter failed to run: %v", err))
+		return response
+	}
+
+	var denials []string
+	for _, report := range result.Reports {
+		if !h.options.WarnOnly && run.Fails(report, h.options.FailOn.OrDefault()) {
+			denials = append(denials, formatReport(report))
+			continue
+		}
+		response.Warnings = append(response.Warnings, formatReport(report))
+	}
+	if len(denials) > 0 {
+		response.Allowed = false
+		response.Result = &metaV1.Status{
+			Status:  metaV1.StatusFailure,
+			Code:    http.StatusForbidden,
+			Reason:  metaV1.StatusReasonForbidden,
+			Message: fmt.Sprintf("kube-linter found %d problem(s):\n- %s", len(denials), strings.Join(denials, "\n- ")),
+		}
+	}
+	return response
+}
+
+func formatReport(report diagnostic.WithContext) string {
+	msg := fmt.Sprintf("[%s] %s", report.Check, report.Diagnostic.Message)
+	if report.Diagnostic.Path != "" {
+		msg += fmt.Sprintf(" (field: %s)", report.Diagnostic.Path)
+	}
+	if report.Remediation != "" {
+		msg += fmt.Sprintf(" (remediation: %s)", report.Remediation)
+	}
+	return msg
+}
@@ -0,0 +1,177 @@
+package admission
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/linter"
+	admissionV1 "k8s.io/api/admission/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	// Register templates
+	_ "golang.stackrox.io/kube-linter/pkg/templates/all"
+)
+
+const (
+	privilegedPod = `{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "pod"%s},
+"spec": {"containers": [{"name": "app", "image": "nginx:1.25", "securityContext": {"privileged": true}}]}}`
+	ignoreAnnotation = `, "annotations": {"ignore-check.kube-linter.io/privileged-container": "trusted"}`
+)
+
+func newTestHandler(t *testing.T, options Options, checks ...string) *Handler {
+	if len(checks) == 0 {
+		// use-namespace is a warning-level check, and privileged-container is an error-level check.
+		checks = []string{"privileged-container", "use-namespace"}
+	}
+	l, err := linter.New(
+		linter.WithConfig(config.Config{Checks: config.ChecksConfig{DoNotAutoAddDefaults: true, Include: checks}}),
+		linter.WithoutCrossObjectChecks(),
+	)
+	require.NoError(t, err)
+	return NewHandler(l, options)
+}
+
+func request(operation admissionV1.Operation, object string) *admissionV1.AdmissionRequest {
+	return &admissionV1.AdmissionRequest{
+		UID:       types.UID("uid"),
+		Name:      "pod",
+		Namespace: "team-a",
+		Operation: operation,
+		Resource:  metaV1.GroupVersionResource{Version: "v1", Resource: "pods"},
+		Object:    runtime.RawExtension{Raw: []byte(object)},
+	}
+}
+
+func withoutNamespace(req *admissionV1.AdmissionRequest) *admissionV1.AdmissionRequest {
+	req.Namespace = ""
+	return req
+}
+
+func TestReview(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		options      Options
+		req          *admissionV1.AdmissionRequest
+		allowed      bool
+		denialChecks []string
+		numWarnings  int
+	}{
+		{
+			name:         "privileged pod is denied",
+			req:          request(admissionV1.Create, fmt.Sprintf(privilegedPod, "")),
+			denialChecks: []string{"privileged-container"},
+		},
+		{
+			name:    "namespace is taken from the request",
+			req:     request(admissionV1.Update, fmt.Sprintf(privilegedPod, ignoreAnnotation)),
+			allowed: true,
+		},
+		{
+			name:        "lower severities are warnings",
+			options:     Options{FailOn: "error"},
+			req:         withoutNamespace(request(admissionV1.Create, fmt.Sprintf(privilegedPod, ignoreAnnotation))),
+			allowed:     true,
+			numWarnings: 1,
+		},
+		{
+			name:         "fail on warnings",
+			options:      Options{FailOn: "warning"},
+			req:          withoutNamespace(request(admissionV1.Create, fmt.Sprintf(privilegedPod, ""))),
+			denialChecks: []string{"privileged-container", "use-namespace"},
+		},
+		{
+			name:        "warn only",
+			options:     Options{WarnOnly: true},
+			req:         request(admissionV1.Create, fmt.Sprintf(privilegedPod, "")),
+			allowed:     true,
+			numWarnings: 1,
+		},
+		{
+			name:    "deletes are allowed",
+			req:     request(admissionV1.Delete, ""),
+			allowed: true,
+		},
+		{
+			name:        "objects that cannot be decoded are allowed with a warning",
+			req:         request(admissionV1.Create, `{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "pod"}, "spec": 3}`),
+			allowed:     true,
+			numWarnings: 1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			response := newTestHandler(t, tc.options).Review(context.Background(), tc.req)
+			assert.Equal(t, tc.req.UID, response.UID)
+			assert.Equal(t, tc.allowed, response.Allowed)
+			assert.Len(t, response.Warnings, tc.numWarnings, "warnings: %v", response.Warnings)
+			if tc.allowed {
+				assert.Nil(t, response.Result)
+				return
+			}
+			require.NotNil(t, response.Result)
+			assert.Equal(t, int32(http.StatusForbidden), response.Result.Code)
+			for _, check := range tc.denialChecks {
+				assert.Contains(t, response.Result.Message, "["+check+"]")
+			}
+		})
+	}
+}
+
+func TestReviewSkipsCrossObjectChecks(t *testing.T) {
+	// The service account and the Service that would select the pod are not part of the request.
+	pod := `{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "pod", "labels": {"app": "pod"}},
+"spec": {"serviceAccountName": "app", "containers": [{"name": "app", "image": "nginx:1.25"}]}}`
+	h := newTestHandler(t, Options{}, "privileged-container", "non-existent-service-account", "dangling-service")
+	assert.Equal(t, []string{"privileged-container"}, h.linter.Checks())
+
+	response := h.Review(context.Background(), request(admissionV1.Create, pod))
+	assert.True(t, response.Allowed)
+	assert.Empty(t, response.Warnings)
+}
+
+func TestReviewTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	response := newTestHandler(t, Options{Timeout: time.Second}).Review(ctx, request(admissionV1.Create, fmt.Sprintf(privilegedPod, "")))
+	assert.True(t, response.Allowed)
+	require.Len(t, response.Warnings, 1)
+	assert.Contains(t, response.Warnings[0], "kube-linter failed to run")
+}
+
+func TestServeHTTP(t *testing.T) {
+	server := httptest.NewServer(newTestHandler(t, Options{}))
+	defer server.Close()
+
+	body, err := json.Marshal(admissionV1.AdmissionReview{
+		TypeMeta: metaV1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request:  request(admissionV1.Create, fmt.Sprintf(privilegedPod, "")),
+	})
+	require.NoError(t, err)
+
+	resp, err := http.Post(server.URL, "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var review admissionV1.AdmissionReview
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&review))
+	assert.Equal(t, "AdmissionReview", review.Kind)
+	assert.Nil(t, review.Request)
+	require.NotNil(t, review.Response)
+	assert.False(t, review.Response.Allowed)
+
+	resp, err = http.Post(server.URL, "text/plain", bytes.NewReader(body))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+}
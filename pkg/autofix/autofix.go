@@ -0,0 +1,204 @@
+package autofix
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/pmezard/go-difflib/difflib"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+)
+
+// A FileChange is a file whose contents are changed by fixes.
+type FileChange struct {
+	Path     string
+	Original []byte
+	Fixed    []byte
+}
+
+// Diff returns the changes to the file as a unified diff, in which the file is named after the given name.
+func (c FileChange) Diff(name string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(c.Original),
+		B:        splitLines(c.Fixed),
+		FromFile: "a/" + filepath.ToSlash(name),
+		ToFile:   "b/" + filepath.ToSlash(name),
+		Context:  3,
+	})
+}
+
+// splitLines splits the contents into lines, each with its newline.
+func splitLines(contents []byte) []string {
+	lines := strings.SplitAfter(string(contents), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Write writes the fixed contents to the file, keeping its permissions.
+func (c FileChange) Write() error {
+	info, err := os.Stat(c.Path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.Path, c.Fixed, info.Mode().Perm())
+}
+
+// Result is the outcome of fixing reports.
+type Result struct {
+	// Changes are the changed files, sorted by path.
+	Changes []FileChange
+	// Fixed are the reports that the changes fix.
+	Fixed []diagnostic.WithContext
+	// Remaining are the reports that have no fix, or whose fix could not be applied, in their original order.
+	Remaining []diagnostic.WithContext
+	// Errors describe why fixes could not be applied.
+	Errors []error
+}
+
+// Fix computes the changes to the files that the objects of the given reports were loaded from, that apply the fixes
+// suggested by the reports. Files are not modified: use FileChange.Write to apply the changes.
+//
+// Fixes are only applied to objects whose source appears verbatim in their file. In particular, objects that are
+// rendered from Helm charts or Kustomize, or that are read from a cluster or from stdin, are not fixed.
+func Fix(reports []diagnostic.WithContext) Result {
+	var result Result
+	fixed := make([]bool, len(reports))
+
+	byFile := make(map[string][]int)
+	for i, report := range reports {
+		if report.Diagnostic.Fix != nil && report.Object.Metadata.FilePath != "" && report.Object.Metadata.SourceMap == nil {
+			byFile[report.Object.Metadata.FilePath] = append(byFile[report.Object.Metadata.FilePath], i)
+		}
+	}
+	for path, indices := range byFile {
+		change, fixedIndices, errs := fixFile(path, reports, indices)
+		result.Errors = append(result.Errors, errs...)
+		for _, i := range fixedIndices {
+			fixed[i] = true
+		}
+		if change != nil {
+			result.Changes = append(result.Changes, *change)
+		}
+	}
+	sort.Slice(result.Changes, func(i, j int) bool {
+		return result.Changes[i].Path < result.Changes[j].Path
+	})
+	sort.Slice(result.Errors, func(i, j int) bool {
+		return result.Errors[i].Error() < result.Errors[j].Error()
+	})
+
+	for i, report := range reports {
+		if fixed[i] {
+			result.Fixed = append(result.Fixed, report)
+		} else {
+			result.Remaining = append(result.Remaining, report)
+		}
+	}
+	return result
+}
+
+// fixFile applies the fixes of the reports with the given indices, which all refer to objects in the file at the given path.
+// It returns the change to the file, or nil if there is none, and the indices of the reports that were fixed.
+func fixFile(path string, reports []diagnostic.WithContext, indices []int) (*FileChange, []int, []error) {
+	original, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		// Objects that were not loaded from a regular file, such as objects read from a cluster, cannot be fixed.
+		return nil, nil, nil
+	}
+	// Files with Windows line endings are fixed with Unix line endings, which are restored afterwards. Files that mix
+	// both are not fixed, as the line endings of the lines that fixes add would be ambiguous.
+	contents := string(original)
+	crlf := strings.Contains(contents, "\r\n")
+	if crlf {
+		contents = strings.ReplaceAll(contents, "\r\n", "\n")
+		if strings.Contains(contents, "\r") || strings.Count(contents, "\n") != strings.Count(string(original), "\r\n") {
+			return nil, nil, []error{fmt.Errorf("%s: cannot apply fixes: the file mixes Windows and Unix line endings", path)}
+		}
+	}
+	lines := strings.Split(contents, "\n")
+
+	// Fix the documents from the bottom of the file up, so that the line offsets of the documents that remain
+	// to be fixed are not changed by the fixes.
+	byOffset := make(map[int][]int)
+	var offsets []int
+	for _, i := range indices {
+		offset := reports[i].Object.Metadata.LineOffset
+		if _, ok := byOffset[offset]; !ok {
+			offsets = append(offsets, offset)
+		}
+		byOffset[offset] = append(byOffset[offset], i)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(offsets)))
+
+	var fixedIndices []int
+	var errs []error
+	for _, offset := range offsets {
+		docIndices := byOffset[offset]
+		metadata := reports[docIndices[0]].Object.Metadata
+		source := strings.ReplaceAll(string(metadata.Raw), "\r\n", "\n")
+		numLines := strings.Count(source, "\n") + 1
+		if !matchesSource(lines, offset, source) {
+			errs = append(errs, fmt.Errorf("%s:%d: cannot apply fixes: the file changed since it was linted", path, offset+1))
+			continue
+		}
+		if isList(source) {
+			errs = append(errs, fmt.Errorf("%s:%d: cannot apply fixes to a list of objects", path, offset+1))
+			continue
+		}
+		for _, i := range docIndices {
+			fixedSource, err := applyEdits(source, reports[i].Diagnostic.Fix.Edits)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s:%d: cannot apply fix for %s: %w", path, offset+1, reports[i].Check, err))
+				continue
+			}
+			source = fixedSource
+			fixedIndices = append(fixedIndices, i)
+		}
+		lines = append(lines[:offset], append(strings.Split(source, "\n"), lines[offset+numLines:]...)...)
+	}
+
+	fixedContents := []byte(strings.Join(lines, "\n"))
+	if crlf {
+		fixedContents = []byte(strings.ReplaceAll(string(fixedContents), "\n", "\r\n"))
+	}
+	if string(fixedContents) == string(original) {
+		return nil, fixedIndices, errs
+	}
+	return &FileChange{Path: path, Original: original, Fixed: fixedContents}, fixedIndices, errs
+}
+
+// matchesSource returns whether the document at the given line offset is the given source. It does not match if the
+// file was changed since the source was loaded.
+func matchesSource(lines []string, offset int, source string) bool {
+	end := offset + strings.Count(source, "\n") + 1
+	if end > len(lines) || strings.Join(lines[offset:end], "\n") != source {
+		return false
+	}
+	// The document must not continue past the source.
+	for _, line := range lines[end:] {
+		if strings.HasPrefix(line, "---") {
+			return true
+		}
+		if strings.TrimSpace(line) != "" && !isComment(line) {
+			return false
+		}
+	}
+	return true
+}
+
+// isList returns whether the document is a list of objects. Lists are not fixed, because the paths of reports
+// are relative to the objects in the list rather than to the document.
+func isList(source string) bool {
+	var header struct {
+		Kind string `yaml:"kind"`
+	}
+	if err := yaml.Unmarshal([]byte(source), &header); err != nil {
+		return true
+	}
+	return strings.HasSuffix(header.Kind, "List")
+}
@@ -0,0 +1,177 @@
+package autofix
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+)
+
+const manifests = `apiVersion: v1
+kind: Pod
+metadata:
+  name: first
+spec:
+  containers:
+  - name: app
+    image: nginx:1.25
+---
+# The second pod.
+apiVersion: v1
+kind: Pod
+metadata:
+  name: second
+spec:
+  containers:
+  - name: app
+    image: nginx:1.25
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: third
+  spec:
+    containers:
+    - name: app
+      image: nginx:1.25
+`
+
+const fixedManifests = `apiVersion: v1
+kind: Pod
+metadata:
+  name: first
+spec:
+  containers:
+  - name: app
+    image: nginx:1.25
+    imagePullPolicy: Always
+---
+# The second pod.
+apiVersion: v1
+kind: Pod
+metadata:
+  name: second
+spec:
+  containers:
+  - name: app
+    image: nginx:1.25
+    securityContext:
+      readOnlyRootFilesystem: true
+    imagePullPolicy: Always
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: third
+  spec:
+    containers:
+    - name: app
+      image: nginx:1.25
+`
+
+func TestFix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pods.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(manifests), 0o600))
+	lintCtxs, err := lintcontext.CreateContexts(nil, path)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 1)
+	objects := lintCtxs[0].Objects()
+	require.Len(t, objects, 3)
+
+	report := func(object lintcontext.Object, fix *diagnostic.Fix) diagnostic.WithContext {
+		return diagnostic.WithContext{Diagnostic: diagnostic.Diagnostic{Message: "problem", Fix: fix}, Object: object}
+	}
+	pullPolicyFix := func() *diagnostic.Fix {
+		return diagnostic.SetFix("Set imagePullPolicy", "spec.containers[0].imagePullPolicy", "Always")
+	}
+	reports := []diagnostic.WithContext{
+		report(objects[0], pullPolicyFix()),
+		report(objects[1], diagnostic.SetFix("Set readOnlyRootFilesystem", "spec.containers[0].securityContext.readOnlyRootFilesystem", true)),
+		report(objects[1], pullPolicyFix()),
+		report(objects[1], nil),
+		report(objects[1], diagnostic.SetFix("Invalid", "spec.containers[3].image", "nginx")),
+		report(objects[2], pullPolicyFix()),
+	}
+
+	result := Fix(reports)
+	assert.Len(t, result.Fixed, 3)
+	assert.Equal(t, []diagnostic.WithContext{reports[3], reports[4], reports[5]}, result.Remaining)
+	require.Len(t, result.Errors, 2)
+	assert.Contains(t, result.Errors[0].Error(), "list element [3] does not exist")
+	assert.Contains(t, result.Errors[1].Error(), "pods.yaml:20: cannot apply fixes to a list of objects")
+
+	require.Len(t, result.Changes, 1)
+	change := result.Changes[0]
+	assert.Equal(t, path, change.Path)
+	assert.Equal(t, fixedManifests, string(change.Fixed))
+
+	diff, err := change.Diff("pods.yaml")
+	require.NoError(t, err)
+	assert.Contains(t, diff, "--- a/pods.yaml\n+++ b/pods.yaml\n")
+	assert.Contains(t, diff, "+      readOnlyRootFilesystem: true\n")
+
+	require.NoError(t, change.Write())
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, fixedManifests, string(contents))
+
+	// The file no longer matches the objects, which are not fixed again.
+	result = Fix(reports[:1])
+	assert.Empty(t, result.Changes)
+	assert.Empty(t, result.Fixed)
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Error(), "pods.yaml:1: cannot apply fixes: the file changed since it was linted")
+}
+
+func TestFixWindowsLineEndings(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		contents string
+		fixed    string
+		err      string
+	}{
+		{
+			name:     "windows line endings",
+			contents: strings.ReplaceAll(manifests, "\n", "\r\n"),
+			fixed:    strings.ReplaceAll(strings.Replace(manifests, "nginx:1.25\n", "nginx:1.25\n    imagePullPolicy: Always\n", 1), "\n", "\r\n"),
+		},
+		{
+			name:     "mixed line endings",
+			contents: strings.Replace(manifests, "\n", "\r\n", 1),
+			err:      "the file mixes Windows and Unix line endings",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "pods.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.contents), 0o600))
+			lintCtxs, err := lintcontext.CreateContexts(nil, path)
+			require.NoError(t, err)
+			require.Len(t, lintCtxs, 1)
+
+			result := Fix([]diagnostic.WithContext{{
+				Diagnostic: diagnostic.Diagnostic{Message: "problem", Fix: diagnostic.SetFix("Set imagePullPolicy", "spec.containers[0].imagePullPolicy", "Always")},
+				Object:     lintCtxs[0].Objects()[0],
+			}})
+			if tc.err != "" {
+				assert.Empty(t, result.Changes)
+				require.Len(t, result.Errors, 1)
+				assert.Contains(t, result.Errors[0].Error(), tc.err)
+				return
+			}
+			assert.Empty(t, result.Errors)
+			require.Len(t, result.Changes, 1)
+			assert.Equal(t, tc.fixed, string(result.Changes[0].Fixed))
+		})
+	}
+}
@@ -0,0 +1,470 @@
+package autofix
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/goccy/go-yaml/token"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+)
+
+// childIndent is the indentation of the fields and list items that are added under an existing field.
+const childIndent = 2
+
+// applyEdits applies the given edits, in order, to the YAML source of a single object.
+// Edits are applied to the text rather than by re-encoding the document, so that comments and formatting are kept.
+func applyEdits(source string, edits []diagnostic.Edit) (string, error) {
+	for _, edit := range edits {
+		var err error
+		source, err = applyEdit(source, edit)
+		if err != nil {
+			return "", fmt.Errorf("%s %q: %w", edit.Kind, edit.Path, err)
+		}
+	}
+	return source, nil
+}
+
+func applyEdit(source string, edit diagnostic.Edit) (string, error) {
+	file, err := parser.ParseBytes([]byte(source), 0)
+	if err != nil {
+		return "", err
+	}
+	if len(file.Docs) == 0 || file.Docs[0] == nil || file.Docs[0].Body == nil {
+		return "", errors.New("empty document")
+	}
+	segments, err := lintcontext.ParseFieldPath(edit.Path)
+	if err != nil {
+		return "", err
+	}
+	d := &document{lines: strings.Split(source, "\n")}
+	r := resolve(file.Docs[0].Body, segments)
+
+	switch edit.Kind {
+	case diagnostic.EditSet:
+		err = d.set(r, segments, edit.Value)
+	case diagnostic.EditAppend:
+		err = d.append(r, segments, edit.Value)
+	case diagnostic.EditSortKeys:
+		err = d.sortKeys(r, segments)
+	default:
+		err = fmt.Errorf("unknown edit kind %q", edit.Kind)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(d.lines, "\n"), nil
+}
+
+// resolved is the deepest node that a field path resolves to.
+type resolved struct {
+	node ast.Node
+	// key is the key of the mapping entry whose value is node, if any.
+	key ast.Node
+	// consumed is the number of segments of the path that were resolved.
+	consumed int
+}
+
+func resolve(body ast.Node, segments []lintcontext.FieldPathSegment) resolved {
+	r := resolved{node: body}
+	for _, segment := range segments {
+		node := lintcontext.UnwrapNode(r.node)
+		if segment.IsIndex {
+			seq, ok := node.(*ast.SequenceNode)
+			if !ok || segment.Index < 0 || segment.Index >= len(seq.Values) {
+				return r
+			}
+			r = resolved{node: seq.Values[segment.Index], consumed: r.consumed + 1}
+			continue
+		}
+		mappingValue := lintcontext.FindMappingValue(node, segment.Key)
+		if mappingValue == nil {
+			return r
+		}
+		r = resolved{node: mappingValue.Value, key: mappingValue.Key, consumed: r.consumed + 1}
+	}
+	return r
+}
+
+// document is the source of an object, split into lines.
+type document struct {
+	lines []string
+}
+
+func (d *document) set(r resolved, segments []lintcontext.FieldPathSegment, value interface{}) error {
+	if r.consumed == len(segments) {
+		if r.key == nil {
+			return errors.New("only fields of mappings can be set")
+		}
+		if !isScalar(r.node) {
+			return errors.New("the existing value is not a scalar")
+		}
+		rendered, err := renderScalar(value)
+		if err != nil {
+			return err
+		}
+		return d.replaceValue(r.key, r.node, " "+rendered)
+	}
+	keys, err := remainingKeys(segments[r.consumed:])
+	if err != nil {
+		return err
+	}
+	return d.insertFields(r, keys, value)
+}
+
+func (d *document) append(r resolved, segments []lintcontext.FieldPathSegment, value interface{}) error {
+	rendered, err := renderScalar(value)
+	if err != nil {
+		return err
+	}
+	if r.consumed < len(segments) {
+		keys, err := remainingKeys(segments[r.consumed:])
+		if err != nil {
+			return err
+		}
+		return d.insertFields(r, keys, []interface{}{value})
+	}
+	if isNull(r.node) {
+		return d.insertFields(r, nil, []interface{}{value})
+	}
+	seq, ok := lintcontext.UnwrapNode(r.node).(*ast.SequenceNode)
+	if !ok {
+		return errors.New("the existing value is not a list")
+	}
+	for _, item := range seq.Values {
+		if tok := item.GetToken(); tok != nil && isScalar(item) && tok.Value == fmt.Sprint(value) {
+			return nil
+		}
+	}
+
+	if seq.IsFlowStyle {
+		if seq.End == nil || seq.Start == nil || seq.End.Position.Line != seq.Start.Position.Line {
+			return errors.New("multi-line flow lists are not supported")
+		}
+		lineIdx, col := seq.End.Position.Line-1, seq.End.Position.Column-1
+		line := d.lines[lineIdx]
+		if len(seq.Values) > 0 {
+			rendered = ", " + rendered
+		}
+		d.lines[lineIdx] = strings.TrimRight(line[:col], " ") + rendered + line[col:]
+		return nil
+	}
+
+	if seq.Start == nil {
+		return errors.New("cannot locate the list")
+	}
+	startIdx, dashIndent := seq.Start.Position.Line-1, seq.Start.Position.Column-1
+	last := d.blockEnd(startIdx, func(line string) bool {
+		indent := indentOf(line)
+		return indent > dashIndent || (indent == dashIndent && strings.HasPrefix(strings.TrimSpace(line), "-"))
+	})
+	d.insertLines(last+1, []string{strings.Repeat(" ", dashIndent) + "- " + rendered})
+	return nil
+}
+
+func (d *document) sortKeys(r resolved, segments []lintcontext.FieldPathSegment) error {
+	if r.consumed < len(segments) {
+		return errors.New("the field does not exist")
+	}
+	var values []*ast.MappingValueNode
+	switch n := lintcontext.UnwrapNode(r.node).(type) {
+	case *ast.MappingValueNode:
+		// A single entry is sorted already.
+		return nil
+	case *ast.MappingNode:
+		if n.IsFlowStyle {
+			return errors.New("flow mappings are not supported")
+		}
+		values = n.Values
+	default:
+		return errors.New("the field is not a mapping")
+	}
+	if len(values) < 2 {
+		return nil
+	}
+
+	type entry struct {
+		key   string
+		lines []string
+		// keyLine is the index in lines of the line of the key, after the comments above it.
+		keyLine int
+	}
+	indent := values[0].Key.GetToken().Position.Column - 1
+	firstKeyIdx := values[0].Key.GetToken().Position.Line - 1
+	// The first key of a mapping in a list starts on the line of the list item, e.g. "- name: app".
+	// The prefix stays at the start of the mapping, on the line of whichever key ends up first.
+	itemPrefix := d.lines[firstKeyIdx][:indent]
+	d.lines[firstKeyIdx] = strings.Repeat(" ", indent) + d.lines[firstKeyIdx][indent:]
+	starts := make([]int, len(values))
+	for i, value := range values {
+		keyIdx := value.Key.GetToken().Position.Line - 1
+		if strings.TrimSpace(d.lines[keyIdx][:indent]) != "" {
+			return errors.New("keys must start on their own line")
+		}
+		// Comments right above a key move with it.
+		start := keyIdx
+		for i > 0 && start-1 > starts[i-1] && isComment(d.lines[start-1]) && indentOf(d.lines[start-1]) == indent {
+			start--
+		}
+		starts[i] = start
+	}
+	end := d.blockEnd(firstKeyIdx, func(line string) bool { return indentOf(line) >= indent })
+
+	entries := make([]entry, len(values))
+	for i, value := range values {
+		entryEnd := end + 1
+		if i+1 < len(values) {
+			entryEnd = starts[i+1]
+		}
+		keyIdx := value.Key.GetToken().Position.Line - 1
+		entries[i] = entry{key: lintcontext.KeyString(value.Key), lines: d.lines[starts[i]:entryEnd], keyLine: keyIdx - starts[i]}
+	}
+	if sort.SliceIsSorted(entries, func(i, j int) bool { return entries[i].key < entries[j].key }) {
+		d.lines[firstKeyIdx] = itemPrefix + d.lines[firstKeyIdx][indent:]
+		return nil
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	sorted := make([]string, 0, end+1-starts[0])
+	for _, e := range entries {
+		sorted = append(sorted, e.lines...)
+	}
+	sorted[entries[0].keyLine] = itemPrefix + sorted[entries[0].keyLine][indent:]
+	d.lines = append(d.lines[:starts[0]], append(sorted, d.lines[end+1:]...)...)
+	return nil
+}
+
+// insertFields adds the given keys, nested in each other, under the resolved node, with the given value.
+// If keys is empty, the value replaces the resolved node, which must be null.
+func (d *document) insertFields(r resolved, keys []string, value interface{}) error {
+	node := lintcontext.UnwrapNode(r.node)
+	if mapping, ok := node.(*ast.MappingNode); ok && mapping.IsFlowStyle && len(mapping.Values) == 0 {
+		// Treat an empty flow mapping ({}) like a null value.
+		node = nil
+	}
+	switch n := node.(type) {
+	case *ast.MappingNode, *ast.MappingValueNode:
+		if len(keys) == 0 {
+			return errors.New("the existing value is not null")
+		}
+		if m, ok := n.(*ast.MappingNode); ok && m.IsFlowStyle {
+			return errors.New("flow mappings are not supported")
+		}
+		firstKey := firstKeyPosition(n)
+		if firstKey == nil {
+			return errors.New("cannot locate the mapping")
+		}
+		indent := firstKey.Column - 1
+		last := d.blockEnd(firstKey.Line-1, func(line string) bool { return indentOf(line) >= indent })
+		rendered, err := renderBlock(keys, value, indent)
+		if err != nil {
+			return err
+		}
+		d.insertLines(last+1, rendered)
+		return nil
+	}
+	if !isNull(node) || r.key == nil {
+		return errors.New("cannot add fields to a scalar")
+	}
+	keyTok := r.key.GetToken()
+	if err := d.replaceValue(r.key, r.node, ""); err != nil {
+		return err
+	}
+	indent := keyTok.Position.Column - 1 + childIndent
+	var rendered []string
+	var err error
+	if len(keys) == 0 {
+		rendered, err = renderValue(value, indent)
+	} else {
+		rendered, err = renderBlock(keys, value, indent)
+	}
+	if err != nil {
+		return err
+	}
+	d.insertLines(keyTok.Position.Line, rendered)
+	return nil
+}
+
+// replaceValue replaces the value of the mapping entry with the given key, which must be on the same line as the key,
+// with the given text. Comments after the value are kept.
+func (d *document) replaceValue(key, value ast.Node, text string) error {
+	keyTok := key.GetToken()
+	lineIdx := keyTok.Position.Line - 1
+	if valueTok := value.GetToken(); valueTok != nil && !isNull(value) && valueTok.Position.Line != keyTok.Position.Line {
+		return errors.New("values on a separate line are not supported")
+	}
+	line := d.lines[lineIdx]
+	colon := findUnquoted(line, keyTok.Position.Column-1, func(i int) bool {
+		return line[i] == ':' && (i+1 == len(line) || line[i+1] == ' ' || line[i+1] == '\t')
+	})
+	if colon < 0 {
+		return errors.New("cannot locate the value")
+	}
+	valueEnd := findUnquoted(line, colon+1, func(i int) bool {
+		return line[i] == '#' && (line[i-1] == ' ' || line[i-1] == '\t')
+	})
+	if valueEnd < 0 {
+		valueEnd = len(line)
+	} else {
+		// Keep the spacing before the comment.
+		for valueEnd > colon+1 && (line[valueEnd-1] == ' ' || line[valueEnd-1] == '\t') {
+			valueEnd--
+		}
+	}
+	d.lines[lineIdx] = line[:colon+1] + text + line[valueEnd:]
+	return nil
+}
+
+// blockEnd returns the index of the last line of the block that starts at the given line, and continues with the lines
+// for which inBlock returns true. Blank lines and comments do not end the block, but are not included at its end.
+func (d *document) blockEnd(start int, inBlock func(line string) bool) int {
+	last := start
+	for i := start + 1; i < len(d.lines); i++ {
+		line := d.lines[i]
+		if strings.TrimSpace(line) == "" || isComment(line) {
+			continue
+		}
+		if !inBlock(line) {
+			break
+		}
+		last = i
+	}
+	return last
+}
+
+func (d *document) insertLines(at int, lines []string) {
+	d.lines = append(d.lines[:at], append(lines, d.lines[at:]...)...)
+}
+
+// renderBlock renders the given keys, each nested in the previous one, with the given value.
+func renderBlock(keys []string, value interface{}, indent int) ([]string, error) {
+	var lines []string
+	for i, key := range keys {
+		renderedKey, err := renderScalar(key)
+		if err != nil {
+			return nil, err
+		}
+		prefix := strings.Repeat(" ", indent) + renderedKey + ":"
+		if i < len(keys)-1 {
+			lines = append(lines, prefix)
+			indent += childIndent
+			continue
+		}
+		if _, isList := value.([]interface{}); isList {
+			valueLines, err := renderValue(value, indent+childIndent)
+			if err != nil {
+				return nil, err
+			}
+			return append(append(lines, prefix), valueLines...), nil
+		}
+		renderedValue, err := renderScalar(value)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, prefix+" "+renderedValue)
+	}
+	return lines, nil
+}
+
+// renderValue renders a value on lines of its own: either a list of scalars, or a scalar.
+func renderValue(value interface{}, indent int) ([]string, error) {
+	items, isList := value.([]interface{})
+	if !isList {
+		return nil, errors.New("only lists can be rendered on separate lines")
+	}
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		rendered, err := renderScalar(item)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, strings.Repeat(" ", indent)+"- "+rendered)
+	}
+	return lines, nil
+}
+
+func renderScalar(value interface{}) (string, error) {
+	out, err := yaml.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	rendered := strings.TrimSpace(string(out))
+	if strings.Contains(rendered, "\n") {
+		return "", fmt.Errorf("value %v is not a scalar", value)
+	}
+	return rendered, nil
+}
+
+func remainingKeys(segments []lintcontext.FieldPathSegment) ([]string, error) {
+	keys := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		if segment.IsIndex {
+			return nil, fmt.Errorf("list element [%d] does not exist", segment.Index)
+		}
+		keys = append(keys, segment.Key)
+	}
+	return keys, nil
+}
+
+// findUnquoted returns the index of the first position at or after start, outside of quotes, for which match
+// returns true, or -1.
+func findUnquoted(line string, start int, match func(i int) bool) int {
+	var quote byte
+	for i := start; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && (i == start || line[i-1] == ' ' || line[i-1] == ':'):
+			quote = c
+		case match(i):
+			return i
+		}
+	}
+	return -1
+}
+
+func firstKeyPosition(node ast.Node) *token.Position {
+	switch n := node.(type) {
+	case *ast.MappingNode:
+		if len(n.Values) > 0 {
+			return firstKeyPosition(n.Values[0])
+		}
+	case *ast.MappingValueNode:
+		if tok := n.Key.GetToken(); tok != nil {
+			return tok.Position
+		}
+	}
+	return nil
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func isComment(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), "#")
+}
+
+func isNull(node ast.Node) bool {
+	if node == nil {
+		return true
+	}
+	_, ok := lintcontext.UnwrapNode(node).(*ast.NullNode)
+	return ok
+}
+
+func isScalar(node ast.Node) bool {
+	switch lintcontext.UnwrapNode(node).(type) {
+	case *ast.MappingNode, *ast.MappingValueNode, *ast.SequenceNode:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,256 @@
+package autofix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+)
+
+const deployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app # the app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: nginx:1.25
+        imagePullPolicy: Never  # for tests
+        securityContext:
+          capabilities:
+            drop:
+            - ALL
+        # resources are set by the VPA
+      - name: sidecar
+        image: "envoy:1.0"
+        securityContext: {}
+`
+
+func set(path string, value interface{}) diagnostic.Edit {
+	return diagnostic.Edit{Kind: diagnostic.EditSet, Path: path, Value: value}
+}
+
+func appendTo(path string, value interface{}) diagnostic.Edit {
+	return diagnostic.Edit{Kind: diagnostic.EditAppend, Path: path, Value: value}
+}
+
+func TestApplyEdits(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		source   string
+		edits    []diagnostic.Edit
+		expected string
+		err      bool
+	}{
+		{
+			name:   "replace a scalar and keep its comment",
+			source: deployment,
+			edits:  []diagnostic.Edit{set("spec.template.spec.containers[0].imagePullPolicy", "Always")},
+			expected: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app # the app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: nginx:1.25
+        imagePullPolicy: Always  # for tests
+        securityContext:
+          capabilities:
+            drop:
+            - ALL
+        # resources are set by the VPA
+      - name: sidecar
+        image: "envoy:1.0"
+        securityContext: {}
+`,
+		},
+		{
+			name:   "add a field to an existing mapping",
+			source: deployment,
+			edits:  []diagnostic.Edit{set("spec.template.spec.containers[0].securityContext.readOnlyRootFilesystem", true)},
+			expected: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app # the app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: nginx:1.25
+        imagePullPolicy: Never  # for tests
+        securityContext:
+          capabilities:
+            drop:
+            - ALL
+          readOnlyRootFilesystem: true
+        # resources are set by the VPA
+      - name: sidecar
+        image: "envoy:1.0"
+        securityContext: {}
+`,
+		},
+		{
+			name:   "add nested fields to an empty mapping",
+			source: deployment,
+			edits:  []diagnostic.Edit{appendTo("spec.template.spec.containers[1].securityContext.capabilities.drop", "NET_RAW")},
+			expected: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app # the app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: nginx:1.25
+        imagePullPolicy: Never  # for tests
+        securityContext:
+          capabilities:
+            drop:
+            - ALL
+        # resources are set by the VPA
+      - name: sidecar
+        image: "envoy:1.0"
+        securityContext:
+          capabilities:
+            drop:
+              - NET_RAW
+`,
+		},
+		{
+			name:   "add a field to a list item",
+			source: deployment,
+			edits:  []diagnostic.Edit{set("spec.template.spec.containers[1].imagePullPolicy", "Always")},
+			expected: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app # the app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: nginx:1.25
+        imagePullPolicy: Never  # for tests
+        securityContext:
+          capabilities:
+            drop:
+            - ALL
+        # resources are set by the VPA
+      - name: sidecar
+        image: "envoy:1.0"
+        securityContext: {}
+        imagePullPolicy: Always
+`,
+		},
+		{
+			name:   "append to a block list",
+			source: deployment,
+			edits:  []diagnostic.Edit{appendTo("spec.template.spec.containers[0].securityContext.capabilities.drop", "NET_RAW")},
+			expected: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app # the app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: nginx:1.25
+        imagePullPolicy: Never  # for tests
+        securityContext:
+          capabilities:
+            drop:
+            - ALL
+            - NET_RAW
+        # resources are set by the VPA
+      - name: sidecar
+        image: "envoy:1.0"
+        securityContext: {}
+`,
+		},
+		{
+			name:     "append an existing value",
+			source:   deployment,
+			edits:    []diagnostic.Edit{appendTo("spec.template.spec.containers[0].securityContext.capabilities.drop", "ALL")},
+			expected: deployment,
+		},
+		{
+			name:     "append to a flow list",
+			source:   "securityContext:\n  capabilities:\n    drop: [ALL]  # all\n",
+			edits:    []diagnostic.Edit{appendTo("securityContext.capabilities.drop", "NET_RAW")},
+			expected: "securityContext:\n  capabilities:\n    drop: [ALL, NET_RAW]  # all\n",
+		},
+		{
+			name:     "replace a null value",
+			source:   "securityContext:\nname: app\n",
+			edits:    []diagnostic.Edit{set("securityContext.privileged", false)},
+			expected: "securityContext:\n  privileged: false\nname: app\n",
+		},
+		{
+			name: "sort keys with their comments and children",
+			source: `metadata:
+  name: app
+  # the labels
+  labels:
+    b: "2"
+    a: "1"
+  annotations:
+    x: y
+spec: {}
+`,
+			edits: []diagnostic.Edit{{Kind: diagnostic.EditSortKeys, Path: "metadata"}},
+			expected: `metadata:
+  annotations:
+    x: y
+  # the labels
+  labels:
+    b: "2"
+    a: "1"
+  name: app
+spec: {}
+`,
+		},
+		{
+			name:     "sort keys of a list item",
+			source:   "containers:\n- name: app\n  # pinned\n  image: nginx:1.25\n- name: sidecar\n",
+			edits:    []diagnostic.Edit{{Kind: diagnostic.EditSortKeys, Path: "containers[0]"}},
+			expected: "containers:\n  # pinned\n- image: nginx:1.25\n  name: app\n- name: sidecar\n",
+		},
+		{
+			name:     "sort keys of the root",
+			source:   "kind: Pod\napiVersion: v1\n",
+			edits:    []diagnostic.Edit{{Kind: diagnostic.EditSortKeys}},
+			expected: "apiVersion: v1\nkind: Pod\n",
+		},
+		{
+			name:   "list elements cannot be created",
+			source: deployment,
+			edits:  []diagnostic.Edit{set("spec.template.spec.containers[2].image", "nginx")},
+			err:    true,
+		},
+		{
+			name:   "non-scalar values cannot be replaced",
+			source: deployment,
+			edits:  []diagnostic.Edit{set("spec.template.spec.containers[0].securityContext", true)},
+			err:    true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fixed, err := applyEdits(tc.source, tc.edits)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, fixed)
+		})
+	}
+}
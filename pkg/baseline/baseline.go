@@ -0,0 +1,141 @@
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+)
+
+// CurrentVersion is the version of the baseline file format written by this package.
+const CurrentVersion = 1
+
+// A Baseline records findings that already exist, so that only new findings are reported.
+type Baseline struct {
+	Version  int       `json:"version"`
+	Findings []Finding `json:"findings"`
+}
+
+// A Finding is a single finding recorded in a baseline.
+// Only the Fingerprint is used for matching; the other fields make the file easier to review.
+type Finding struct {
+	Fingerprint string `json:"fingerprint"`
+	Check       string `json:"check"`
+	Object      string `json:"object"`
+	FilePath    string `json:"filePath"`
+}
+
+// Fingerprint computes a stable identifier for a report.
+// It only depends on the check name, the identity of the object and the file path (relative to baseDir),
+// so that it keeps matching when the object moves within the file or the message changes.
+func Fingerprint(report diagnostic.WithContext, baseDir string) string {
+	info := report.Object.GetK8sObjectName()
+	h := sha256.New()
+	for _, part := range []string{
+		report.Check,
+		info.Namespace,
+		info.Name,
+		info.GroupVersionKind.String(),
+		NormalizePath(report.Object.Metadata.FilePath, baseDir),
+	} {
+		// The separator cannot appear in any of the parts, so different inputs cannot collide.
+		_, _ = h.Write([]byte(part))
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// NormalizePath makes the given file path relative to baseDir, using forward slashes,
+// so that a baseline can be shared between machines and operating systems.
+// Paths that cannot be made relative (for example, objects read from stdin) are returned as is.
+func NormalizePath(filePath, baseDir string) string {
+	if filepath.IsAbs(filePath) && baseDir != "" {
+		if rel, err := filepath.Rel(baseDir, filePath); err == nil {
+			filePath = rel
+		}
+	}
+	return filepath.ToSlash(filePath)
+}
+
+// New creates a baseline recording all the given reports.
+func New(reports []diagnostic.WithContext, baseDir string) *Baseline {
+	b := &Baseline{Version: CurrentVersion, Findings: make([]Finding, 0, len(reports))}
+	for _, report := range reports {
+		b.Findings = append(b.Findings, Finding{
+			Fingerprint: Fingerprint(report, baseDir),
+			Check:       report.Check,
+			Object:      report.Object.GetK8sObjectName().String(),
+			FilePath:    NormalizePath(report.Object.Metadata.FilePath, baseDir),
+		})
+	}
+	// Keep the file stable across runs, so that updates produce minimal diffs.
+	sort.Slice(b.Findings, func(i, j int) bool {
+		fi, fj := b.Findings[i], b.Findings[j]
+		if fi.FilePath != fj.FilePath {
+			return fi.FilePath < fj.FilePath
+		}
+		if fi.Object != fj.Object {
+			return fi.Object < fj.Object
+		}
+		if fi.Check != fj.Check {
+			return fi.Check < fj.Check
+		}
+		return fi.Fingerprint < fj.Fingerprint
+	})
+	return b
+}
+
+// Load reads a baseline from the given file.
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline %s: %w", path, err)
+	}
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parsing baseline %s: %w", path, err)
+	}
+	if b.Version != CurrentVersion {
+		return nil, fmt.Errorf("baseline %s has unsupported version %d (expected %d)", path, b.Version, CurrentVersion)
+	}
+	return &b, nil
+}
+
+// Write writes the baseline to the given file.
+func (b *Baseline) Write(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding baseline: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("writing baseline %s: %w", path, err)
+	}
+	return nil
+}
+
+// Filter returns the reports that are not recorded in the baseline, along with the number of reports that were.
+// Findings are matched by count: if the baseline records a fingerprint n times, at most n matching reports
+// are suppressed, so that a new finding identical to an existing one is still reported.
+func (b *Baseline) Filter(reports []diagnostic.WithContext, baseDir string) ([]diagnostic.WithContext, int) {
+	remaining := make(map[string]int, len(b.Findings))
+	for _, finding := range b.Findings {
+		remaining[finding.Fingerprint]++
+	}
+	var kept []diagnostic.WithContext
+	var suppressed int
+	for _, report := range reports {
+		fingerprint := Fingerprint(report, baseDir)
+		if remaining[fingerprint] > 0 {
+			remaining[fingerprint]--
+			suppressed++
+			continue
+		}
+		kept = append(kept, report)
+	}
+	return kept, suppressed
+}
@@ -0,0 +1,79 @@
+package baseline
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func report(check, name, filePath string, line int) diagnostic.WithContext {
+	return diagnostic.WithContext{
+		Diagnostic: diagnostic.Diagnostic{Message: "message", Line: line},
+		Check:      check,
+		Object: lintcontext.Object{
+			Metadata: lintcontext.ObjectMetadata{FilePath: filePath},
+			K8sObject: &v1.Pod{
+				TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+			},
+		},
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	baseDir := filepath.FromSlash("/repo")
+	original := Fingerprint(report("check", "pod", filepath.FromSlash("/repo/manifests/pod.yaml"), 3), baseDir)
+
+	assert.Equal(t, original, Fingerprint(report("check", "pod", filepath.FromSlash("/repo/manifests/pod.yaml"), 30), baseDir),
+		"fingerprint must not depend on the position")
+	assert.Equal(t, original, Fingerprint(report("check", "pod", "manifests/pod.yaml", 3), ""),
+		"fingerprint must not depend on where the repository is checked out")
+	assert.NotEqual(t, original, Fingerprint(report("other-check", "pod", filepath.FromSlash("/repo/manifests/pod.yaml"), 3), baseDir))
+	assert.NotEqual(t, original, Fingerprint(report("check", "other-pod", filepath.FromSlash("/repo/manifests/pod.yaml"), 3), baseDir))
+	assert.NotEqual(t, original, Fingerprint(report("check", "pod", filepath.FromSlash("/repo/other/pod.yaml"), 3), baseDir))
+}
+
+func TestRoundTripAndFilter(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "baseline.json")
+	podFile := filepath.Join(dir, "pod.yaml")
+
+	existing := []diagnostic.WithContext{
+		report("check", "pod", podFile, 1),
+		report("check", "pod", podFile, 2),
+	}
+	require.NoError(t, New(existing, dir).Write(path))
+
+	b, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, b.Findings, 2)
+	assert.Equal(t, "pod.yaml", b.Findings[0].FilePath)
+	assert.Equal(t, "check", b.Findings[0].Check)
+
+	current := []diagnostic.WithContext{
+		report("check", "pod", podFile, 5),
+		report("check", "pod", podFile, 6),
+		// The same finding a third time is new: the baseline only recorded two.
+		report("check", "pod", podFile, 7),
+		report("new-check", "pod", podFile, 5),
+	}
+	kept, suppressed := b.Filter(current, dir)
+	assert.Equal(t, 2, suppressed)
+	assert.Equal(t, []diagnostic.WithContext{current[2], current[3]}, kept)
+}
+
+func TestLoadErrors(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, (&Baseline{Version: CurrentVersion + 1}).Write(path))
+	_, err = Load(path)
+	assert.ErrorContains(t, err, "unsupported version")
+}
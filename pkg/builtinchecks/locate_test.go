@@ -0,0 +1,112 @@
+package builtinchecks_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
+	"golang.stackrox.io/kube-linter/pkg/checkregistry"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/run"
+	_ "golang.stackrox.io/kube-linter/pkg/templates/all"
+)
+
+const locateYAML = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 3
+  selector:
+    matchLabels:
+      app: app
+  template:
+    metadata:
+      labels:
+        app: other
+    spec:
+      serviceAccountName: default
+      restartPolicy: Never
+      securityContext:
+        runAsNonRoot: false
+        sysctls:
+          - name: kernel.msgmax
+            value: "65536"
+      containers:
+        - name: app
+          image: app:1.0
+          ports:
+            - containerPort: 80
+          livenessProbe:
+            httpGet:
+              port: 8080
+          volumeMounts:
+            - name: etc
+              mountPath: /host/etc
+      volumes:
+        - name: etc
+          hostPath:
+            path: /etc
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: reader
+  namespace: default
+rules:
+  - apiGroups: [""]
+    resources: ["secrets"]
+    verbs:
+      - get
+      - "*"
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: reader
+  namespace: default
+subjects:
+  - kind: User
+    name: someone
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: reader
+`
+
+func TestDiagnosticsAreLocatedAtTheirField(t *testing.T) {
+	registry := checkregistry.New()
+	require.NoError(t, builtinchecks.LoadInto(registry))
+	lintCtx, err := lintcontext.CreateContextFromReader(lintcontext.Options{}, "objects.yaml", strings.NewReader(locateYAML))
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		check    string
+		expected []string
+	}{
+		{check: "access-to-secrets", expected: []string{"roleRef 58"}},
+		{check: "default-service-account", expected: []string{"spec.template.spec.serviceAccountName 15"}},
+		{check: "liveness-port", expected: []string{"spec.template.spec.containers[0].livenessProbe.httpGet.port 29"}},
+		{check: "mismatching-selector", expected: []string{"spec.template.metadata.labels 12"}},
+		{check: "no-readiness-probe", expected: []string{"spec.template.spec.containers[0].readinessProbe 23"}},
+		{check: "privileged-ports", expected: []string{"spec.template.spec.containers[0].ports[0].containerPort 26"}},
+		{check: "restart-policy", expected: []string{"spec.template.spec.restartPolicy 16"}},
+		{check: "run-as-non-root", expected: []string{"spec.template.spec.securityContext.runAsNonRoot 18"}},
+		{check: "sensitive-host-mounts", expected: []string{"spec.template.spec.containers[0].volumeMounts[0] 31"}},
+		{check: "unsafe-sysctls", expected: []string{"spec.template.spec.securityContext.sysctls[0] 20"}},
+		{check: "wildcard-in-rules", expected: []string{"rules[0].verbs[1] 48"}},
+	} {
+		t.Run(tc.check, func(t *testing.T) {
+			result, err := run.Run([]lintcontext.LintContext{lintCtx}, registry, []string{tc.check})
+			require.NoError(t, err)
+			var located []string
+			for _, report := range result.Reports {
+				located = append(located, fmt.Sprintf("%s %d", report.Diagnostic.Path, report.Diagnostic.Line))
+			}
+			assert.Equal(t, tc.expected, located)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package check
 
 import (
+	"context"
+
 	"golang.stackrox.io/kube-linter/pkg/config"
 	"golang.stackrox.io/kube-linter/pkg/diagnostic"
 	"golang.stackrox.io/kube-linter/pkg/lintcontext"
@@ -9,8 +11,24 @@ import (
 // A Func is a specific lint-check, which runs on a specific objects, and emits diagnostics if problems are found.
 // Checks have access to the entire LintContext, with all the objects in it, but must only report problems for the
 // object passed in the second argument.
+//
+// A Func may be called concurrently from multiple goroutines, with different objects and contexts.
+// It must treat the LintContext and the objects in it as read-only, and any state it shares across
+// calls (e.g. caches built at instantiation time) must be safe for concurrent use.
 type Func func(lintCtx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic
 
+// A ContextFunc is a Func that is also passed a context, which is done when the run is canceled or the check
+// times out. Checks that can be slow (for example, because they fetch data or evaluate user-provided expressions)
+// should stop as soon as it is done. The same concurrency requirements as for Func apply.
+type ContextFunc func(ctx context.Context, lintCtx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic
+
+// WithoutContext returns a Func that calls f with context.Background().
+func (f ContextFunc) WithoutContext() Func {
+	return func(lintCtx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+		return f(context.Background(), lintCtx, object)
+	}
+}
+
 // A Template is a template for a check.
 type Template struct {
 	// HumanName is a human-friendly name for the template.
@@ -20,10 +38,16 @@ type Template struct {
 	Key                  string
 	Description          string
 	SupportedObjectKinds config.ObjectKindsDesc
+	// NeedsOtherObjects is set if the checks of the template look up other objects of the lint context, for example
+	// the Services that select a Deployment. They report false positives when objects are linted on their own.
+	NeedsOtherObjects bool `json:"-"`
 
 	Parameters             []ParameterDesc                                          // TODO: use HumanReadableParamDesc for json output instead
 	ParseAndValidateParams func(params map[string]interface{}) (interface{}, error) `json:"-"`
 	Instantiate            func(parsedParams interface{}) (Func, error)             `json:"-"`
+	// InstantiateWithContext, if set, is preferred to Instantiate by the linter, so that the check can stop
+	// when the run is canceled or the check times out.
+	InstantiateWithContext func(parsedParams interface{}) (ContextFunc, error) `json:"-"`
 }
 
 // HumanReadableParameters helper transforms each of Template.Parameters to HumanReadableParamDesc.
@@ -8,34 +8,43 @@ import (
 )
 
 // A CheckRegistry is a registry of checks.
-// It is not thread-safe. It is anticipated that checks will all be registered ahead of time
-// before calls to Load.
+// Register is not thread-safe. It is anticipated that checks will all be registered ahead of time
+// before calls to Load. Once registration is complete, Load is safe for concurrent use, and so are
+// the instantiated checks it returns (see check.Func).
 type CheckRegistry interface {
 	Register(checks ...*config.Check) error
 	Load(name string) *instantiatedcheck.InstantiatedCheck
 }
 
-type checkRegistry map[string]*instantiatedcheck.InstantiatedCheck
+type checkRegistry struct {
+	checks  map[string]*instantiatedcheck.InstantiatedCheck
+	options instantiatedcheck.Options
+}
 
-func (cr checkRegistry) Register(checks ...*config.Check) error {
+func (cr *checkRegistry) Register(checks ...*config.Check) error {
 	for _, c := range checks {
-		instantiated, err := instantiatedcheck.ValidateAndInstantiate(c)
+		instantiated, err := instantiatedcheck.ValidateAndInstantiateWithOptions(cr.options, c)
 		if err != nil {
 			return fmt.Errorf("invalid check %s: %w", c.Name, err)
 		}
-		if _, ok := cr[instantiated.Spec.Name]; ok {
+		if _, ok := cr.checks[instantiated.Spec.Name]; ok {
 			return fmt.Errorf("duplicate check name: %s", instantiated.Spec.Name)
 		}
-		cr[instantiated.Spec.Name] = instantiated
+		cr.checks[instantiated.Spec.Name] = instantiated
 	}
 	return nil
 }
 
-func (cr checkRegistry) Load(name string) *instantiatedcheck.InstantiatedCheck {
-	return cr[name]
+func (cr *checkRegistry) Load(name string) *instantiatedcheck.InstantiatedCheck {
+	return cr.checks[name]
 }
 
 // New returns a ready-to-use, empty CheckRegistry.
 func New() CheckRegistry {
-	return make(checkRegistry)
+	return NewWithOptions(instantiatedcheck.Options{})
+}
+
+// NewWithOptions returns a ready-to-use, empty CheckRegistry, which instantiates checks with the given Options.
+func NewWithOptions(options instantiatedcheck.Options) CheckRegistry {
+	return &checkRegistry{checks: make(map[string]*instantiatedcheck.InstantiatedCheck), options: options}
 }
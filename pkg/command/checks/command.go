@@ -27,6 +27,7 @@ Description: {{.Description}}
 Remediation: {{.Remediation}}
 Template: {{.Template}}
 Parameters: {{.Params}}
+Severity: {{ severity . }}
 Enabled by default: {{ isDefault . }}
 {{end -}}
 `
@@ -40,6 +41,8 @@ KubeLinter includes the following built-in checks:
 
 **Enabled by default**: {{ if isDefault . }}Yes{{ else }}No{{ end }}
 
+**Severity**: {{ severity . }}
+
 **Description**: {{.Description}}
 
 **Remediation**: {{.Remediation}}
@@ -60,6 +63,9 @@ var (
 			return defaultchecks.List.Contains(check.Name)
 		},
 		"templateLink": GetTemplateLink,
+		"severity": func(check config.Check) config.Severity {
+			return check.Severity.OrDefault()
+		},
 	}
 	plainTemplate    = common.MustInstantiatePlainTemplate(plainTemplateStr, checksFuncMap)
 	markDownTemplate = common.MustInstantiateMarkdownTemplate(markDownTemplateStr, checksFuncMap)
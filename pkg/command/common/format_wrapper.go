@@ -14,11 +14,23 @@ const (
 	PlainFormat = "plain"
 	// MarkdownFormat is for markdown output suitable for `*.md` files.
 	MarkdownFormat = "markdown"
+	// HTMLFormat is for a self-contained HTML page.
+	HTMLFormat = "html"
 	// JSONFormat is for JSON output with kube-linter's own data structs.
 	JSONFormat = "json"
 	// SARIFFormat is JSON-based standard for reporting lint errors.
 	// See https://www.oasis-open.org/committees/tc_home.php?wg_abbrev=sarif
 	SARIFFormat = "sarif"
+	// JUnitFormat is the JUnit XML format of test reports, which CI systems display as test results.
+	JUnitFormat = "junit"
+	// CheckstyleFormat is the XML format of Checkstyle reports.
+	// See https://checkstyle.org/
+	CheckstyleFormat = "checkstyle"
+	// GitLabFormat is the JSON format of GitLab Code Quality reports.
+	// See https://docs.gitlab.com/ee/ci/testing/code_quality.html
+	GitLabFormat = "gitlab"
+	// TemplateFormat is for output rendered by a Go template provided by the user.
+	TemplateFormat = "template"
 )
 
 // FormatFunc sets contract formatter of each FormatType should follow.
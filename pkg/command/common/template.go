@@ -49,7 +49,7 @@ var (
 // MustInstantiateMarkdownTemplate instantiates the given go template with a common list of markdown functions.
 // It panics if there is an error.
 func MustInstantiateMarkdownTemplate(templateStr string, customFuncMap template.FuncMap) *template.Template {
-	tpl, err := instantiateTemplate(templateStr, markdownFuncs, customFuncMap)
+	tpl, err := instantiateTemplate("", templateStr, markdownFuncs, customFuncMap)
 	utils.Must(err)
 	return tpl
 }
@@ -57,12 +57,19 @@ func MustInstantiateMarkdownTemplate(templateStr string, customFuncMap template.
 // MustInstantiatePlainTemplate instantiates the given go template with a common list of functions for console output.
 // It panics if there is an error.
 func MustInstantiatePlainTemplate(templateStr string, customFuncMap template.FuncMap) *template.Template {
-	tpl, err := instantiateTemplate(templateStr, plainFuncs, customFuncMap)
+	tpl, err := instantiateTemplate("", templateStr, plainFuncs, customFuncMap)
 	utils.Must(err)
 	return tpl
 }
 
-func instantiateTemplate(templateStr string, commonFuncMap, customFuncMap template.FuncMap) (*template.Template, error) {
-	tpl, err := template.New("").Funcs(sprig.TxtFuncMap()).Funcs(commonFuncMap).Funcs(customFuncMap).Parse(templateStr)
+// InstantiateNamedPlainTemplate is like MustInstantiatePlainTemplate, but returns an error instead of panicking, for
+// templates provided by users. Errors in the template, when it is parsed or executed, refer to it by the given name
+// and give the line on which they occur.
+func InstantiateNamedPlainTemplate(name, templateStr string, customFuncMap template.FuncMap) (*template.Template, error) {
+	return instantiateTemplate(name, templateStr, plainFuncs, customFuncMap)
+}
+
+func instantiateTemplate(name, templateStr string, commonFuncMap, customFuncMap template.FuncMap) (*template.Template, error) {
+	tpl, err := template.New(name).Funcs(sprig.TxtFuncMap()).Funcs(commonFuncMap).Funcs(customFuncMap).Parse(templateStr)
 	return tpl, err
 }
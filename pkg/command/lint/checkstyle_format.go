@@ -0,0 +1,100 @@
+package lint
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+// checkstyleVersion is the version of the Checkstyle format that is written.
+const checkstyleVersion = "4.3"
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+	// Fingerprint is not part of the Checkstyle format, but consumers ignore unknown attributes.
+	Fingerprint string `xml:"fingerprint,attr"`
+}
+
+// formatLintCheckstyle implements common.CheckstyleFormat.
+// Must be used only with lint.Command because it only understands run.Result as data parameter.
+func formatLintCheckstyle(out io.Writer, data interface{}) error {
+	if res, ok := data.(run.Result); ok {
+		return formatCheckstyle(out, res)
+	}
+	return errors.New("provided data must be of run.Result type")
+}
+
+// formatCheckstyle writes the findings grouped by file, in the order in which the files are first reported.
+func formatCheckstyle(out io.Writer, result run.Result) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	fingerprints := reportFingerprints(result.Reports, cwd)
+	report := checkstyleReport{Version: checkstyleVersion, Files: []checkstyleFile{}}
+	indices := make(map[string]int)
+	for i := range result.Reports {
+		r := &result.Reports[i]
+		idx, ok := indices[r.Object.Metadata.FilePath]
+		if !ok {
+			idx = len(report.Files)
+			indices[r.Object.Metadata.FilePath] = idx
+			report.Files = append(report.Files, checkstyleFile{Name: r.Object.Metadata.FilePath})
+		}
+		line := r.Diagnostic.Line
+		if line == 0 {
+			// Checkstyle consumers expect a line, so findings without a position are reported on the first line.
+			line = 1
+		}
+		report.Files[idx].Errors = append(report.Files[idx].Errors, checkstyleError{
+			Line:        line,
+			Column:      r.Diagnostic.Column,
+			Severity:    getCheckstyleSeverity(r.Severity),
+			Message:     describeReport(r),
+			Source:      r.Check,
+			Fingerprint: fingerprints[i],
+		})
+	}
+
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(out)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, "\n")
+	return err
+}
+
+// getCheckstyleSeverity maps a severity to the corresponding Checkstyle severity.
+func getCheckstyleSeverity(severity config.Severity) string {
+	switch severity.OrDefault() {
+	case config.SeverityWarning:
+		return "warning"
+	case config.SeverityInfo:
+		return "info"
+	default:
+		return "error"
+	}
+}
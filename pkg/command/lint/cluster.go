@@ -0,0 +1,36 @@
+package lint
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// clusterFlags are the flags that select the cluster to lint in --cluster mode.
+type clusterFlags struct {
+	enabled             bool
+	kubeconfig          string
+	context             string
+	namespace           string
+	contextPerNamespace bool
+}
+
+// newClient creates a client for the cluster selected by the flags, using the same rules as kubectl
+// to find the kubeconfig ($KUBECONFIG, then ~/.kube/config, then the in-cluster config).
+func (f *clusterFlags) newClient() (kubernetes.Interface, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = f.kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{
+		CurrentContext: f.context,
+	})
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("loading kubeconfig: %w", err)
+	}
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("creating Kubernetes client: %w", err)
+	}
+	return client, nil
+}
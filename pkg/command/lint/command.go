@@ -3,21 +3,19 @@ package lint
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"strings"
+	"text/template"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"golang.stackrox.io/kube-linter/pkg/diagnostic"
-	"golang.stackrox.io/kube-linter/pkg/pathutil"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-
-	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
-	"golang.stackrox.io/kube-linter/pkg/checkregistry"
+	"golang.stackrox.io/kube-linter/internal/flagutil"
 	"golang.stackrox.io/kube-linter/pkg/command/common"
 	"golang.stackrox.io/kube-linter/pkg/config"
-	"golang.stackrox.io/kube-linter/pkg/configresolver"
-	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/linter"
 	"golang.stackrox.io/kube-linter/pkg/run"
 )
 
@@ -25,7 +23,7 @@ const (
 	plainTemplateStr = `KubeLinter {{.Summary.KubeLinterVersion}}
 
 {{range .Reports}}
-{{- .Object.Metadata.FilePath | bold}}: (object: {{.Object.GetK8sObjectName | bold}}) {{.Diagnostic.Message | red}} (check: {{.Check | yellow}}, remediation: {{.Remediation | yellow}})
+{{- .Object.Metadata.FilePath | bold}}{{if .Diagnostic.Line}}{{printf ":%d:%d" .Diagnostic.Line .Diagnostic.Column | bold}}{{end}}: (object: {{.Object.GetK8sObjectName | bold}}){{with .Object.Metadata.Variant}} (variant: {{. | bold}}){{end}} {{.Diagnostic.Message | colorBySeverity .Severity}}{{with .Diagnostic.Path}} (field: {{. | bold}}){{end}} (check: {{.Check | yellow}}, remediation: {{.Remediation | yellow}})
 
 {{else}}No lint errors found!
 {{end -}}
@@ -33,13 +31,27 @@ const (
 )
 
 var (
-	plainTemplate = common.MustInstantiatePlainTemplate(plainTemplateStr, nil)
+	plainTemplate = common.MustInstantiatePlainTemplate(plainTemplateStr, template.FuncMap{
+		"colorBySeverity": colorBySeverity,
+	})
+
+	severityColors = map[config.Severity]*color.Color{
+		config.SeverityError:   color.New(color.FgRed),
+		config.SeverityWarning: color.New(color.FgYellow),
+		config.SeverityInfo:    color.New(color.FgCyan),
+	}
 
 	formatters = common.Formatters{
 		Formatters: map[common.FormatType]common.FormatFunc{
-			common.JSONFormat:  common.FormatJSON,
-			common.SARIFFormat: formatLintSarif,
-			common.PlainFormat: plainTemplate.Execute,
+			common.JSONFormat:       common.FormatJSON,
+			common.SARIFFormat:      formatLintSarif,
+			common.PlainFormat:      plainTemplate.Execute,
+			common.JUnitFormat:      formatLintJUnit,
+			common.CheckstyleFormat: formatLintCheckstyle,
+			common.GitLabFormat:     formatLintGitLab,
+			common.MarkdownFormat:   formatLintMarkdown,
+			common.HTMLFormat:       formatLintHTML,
+			common.TemplateFormat:   formatRequiresTemplateFile,
 		},
 	}
 )
@@ -52,17 +64,56 @@ func Command() *cobra.Command {
 	var errorOnInvalidResource bool
 	var formats []string
 	var outputs []string
+	var templateFile string
+	var parallelism int
+	var baselinePath string
+	var updateBaseline bool
+	var fix, fixDryRun bool
+	var stats bool
+	var cluster clusterFlags
+	failOn := flagutil.NewEnumFlag("Minimum severity of findings that cause a non-zero exit code", config.AllSeverities(), string(config.SeverityInfo))
 
 	v := viper.New()
 
 	c := &cobra.Command{
-		Use:   "lint",
-		Args:  cobra.MinimumNArgs(1),
-		Short: "Lint Kubernetes YAML files and Helm charts",
+		Use: "lint",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if cluster.enabled {
+				if len(args) > 0 {
+					return errors.New("paths cannot be given with --cluster")
+				}
+				return nil
+			}
+			for _, flag := range []string{"kubeconfig", "context", "namespace", "context-per-namespace"} {
+				if cmd.Flags().Changed(flag) {
+					return fmt.Errorf("--%s requires --cluster", flag)
+				}
+			}
+			return cobra.MinimumNArgs(1)(cmd, args)
+		},
+		Short: "Lint Kubernetes YAML files and Helm charts, or the objects deployed in a cluster",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			checkRegistry := checkregistry.New()
-			if err := builtinchecks.LoadInto(checkRegistry); err != nil {
-				return err
+			if updateBaseline && baselinePath == "" {
+				return errors.New("--update-baseline requires --baseline")
+			}
+			if fix && fixDryRun {
+				return errors.New("--fix and --fix-dry-run cannot be used together")
+			}
+			if (fix || fixDryRun) && cluster.enabled {
+				return errors.New("--fix and --fix-dry-run cannot be used with --cluster")
+			}
+			lintFormatters := formatters
+			if slices.Contains(formats, common.TemplateFormat) != (templateFile != "") {
+				return errors.New("--format template and --template-file must be used together")
+			}
+			if templateFile != "" {
+				// Load the template before linting, so that errors in it are reported right away.
+				templateFormatter, err := loadTemplateFormatter(templateFile)
+				if err != nil {
+					return fmt.Errorf("invalid template: %w", err)
+				}
+				lintFormatters = common.Formatters{Formatters: maps.Clone(formatters.Formatters)}
+				lintFormatters.Formatters[common.TemplateFormat] = templateFormatter
 			}
 
 			// Load Configuration
@@ -71,55 +122,44 @@ func Command() *cobra.Command {
 				return fmt.Errorf("failed to load config: %w", err)
 			}
 
-			if err := configresolver.LoadCustomChecksInto(&cfg, checkRegistry); err != nil {
-				return err
+			failOnSeverity := config.Severity(failOn.String())
+			linterOptions := []linter.Option{linter.WithConfig(cfg), linter.WithParallelism(parallelism), linter.WithFailOn(failOnSeverity)}
+			if stats {
+				linterOptions = append(linterOptions, linter.WithStats())
+			}
+			if errorOnInvalidResource {
+				linterOptions = append(linterOptions, linter.WithInvalidObjectReports())
+			}
+			if baselinePath != "" {
+				linterOptions = append(linterOptions, linter.WithBaseline(baselinePath, updateBaseline))
 			}
-			enabledChecks, err := configresolver.GetEnabledChecksAndValidate(&cfg, checkRegistry)
+			if !cluster.enabled {
+				// Nested configs are looked up from the working directory, whose config file is the root config.
+				linterOptions = append(linterOptions, linter.WithNestedConfigs("."))
+			}
+			l, err := linter.New(linterOptions...)
 			if err != nil {
 				return err
 			}
-			if len(enabledChecks) == 0 {
+			if len(l.Checks()) == 0 {
 				fmt.Fprintln(os.Stderr, "Warning: no checks enabled.")
 				return nil
 			}
-			ignorePaths, err := configresolver.GetIgnorePaths(&cfg)
+
+			source, err := createSource(&cluster, args)
 			if err != nil {
 				return err
 			}
-
-			absArgs := make([]string, 0, len(args))
-			for _, arg := range args {
-				if arg == lintcontext.ReadFromStdin {
-					absArgs = append(absArgs, lintcontext.ReadFromStdin)
-					continue
-				}
-				absArg, err := pathutil.GetAbsolutPath(arg)
-				if err != nil {
-					return err
-				}
-				absArgs = append(absArgs, absArg)
-			}
-
-			lintCtxs, err := lintcontext.CreateContexts(ignorePaths, absArgs...)
+			lintResult, err := l.Lint(cmd.Context(), source)
 			if err != nil {
 				return err
 			}
-			invalidObjectsResult := generateReportFromInvalidObjects(lintCtxs)
 			if verbose {
-				for _, invalidObj := range invalidObjectsResult {
+				for _, invalidObj := range lintResult.InvalidObjects {
 					_, _ = fmt.Fprintf(os.Stderr, "Warning: failed to load object from %s: %v\n", invalidObj.Object.Metadata.FilePath, invalidObj.Diagnostic.Message)
 				}
 			}
-
-			var atLeastOneObjectFound = errorOnInvalidResource && len(invalidObjectsResult) > 0
-			for _, lintCtx := range lintCtxs {
-				if len(lintCtx.Objects()) > 0 {
-					atLeastOneObjectFound = true
-					break
-				}
-			}
-
-			if !atLeastOneObjectFound {
+			if lintResult.Objects == 0 && (!errorOnInvalidResource || len(lintResult.InvalidObjects) == 0) {
 				msg := "no valid objects found"
 				if failIfNoObjects {
 					return errors.New(msg)
@@ -127,17 +167,31 @@ func Command() *cobra.Command {
 				fmt.Fprintf(os.Stderr, "Warning: %s.\n", msg)
 				return nil
 			}
-			result, err := run.Run(lintCtxs, checkRegistry, enabledChecks)
-			if err != nil {
-				return err
+			if updateBaseline {
+				// The updated baseline records all the findings, so all of them were removed from the result.
+				fmt.Fprintf(os.Stderr, "Wrote %d finding(s) to baseline %s\n", lintResult.Baselined, baselinePath)
+			}
+			if verbose && baselinePath != "" {
+				fmt.Fprintf(os.Stderr, "Suppressed %d finding(s) recorded in baseline %s\n", lintResult.Baselined, baselinePath)
 			}
 
-			if errorOnInvalidResource {
-				result.Reports = append(result.Reports, invalidObjectsResult...)
+			result := lintResult.Result
+			if fix || fixDryRun {
+				if err := applyFixes(os.Stdout, &result, fixDryRun, verbose); err != nil {
+					return err
+				}
+				if fixDryRun {
+					return nil
+				}
+				// Fixes change the findings, and therefore the status of the run and the stats.
+				result.Summary.ChecksStatus = run.Status(result.Reports, failOnSeverity)
+				if stats {
+					result.Summary.Stats.CountFindings(result.Reports)
+				}
 			}
 
 			// Validate and pair formats with outputs
-			pairs, err := ValidateAndPairFormatsOutputs(formats, outputs, formatters.GetEnabledFormatters())
+			pairs, err := ValidateAndPairFormatsOutputs(formats, outputs, lintFormatters.GetEnabledFormatters())
 			if err != nil {
 				return err
 			}
@@ -146,7 +200,7 @@ func Command() *cobra.Command {
 			var writeErrors []error
 			var successCount int
 			for _, pair := range pairs {
-				formatter, err := formatters.FormatterByType(string(pair.Format))
+				formatter, err := lintFormatters.FormatterByType(string(pair.Format))
 				if err != nil {
 					return err
 				}
@@ -197,8 +251,14 @@ func Command() *cobra.Command {
 				return errors.New(errMsg.String())
 			}
 
-			if len(result.Reports) > 0 {
-				err = fmt.Errorf("found %d lint errors", len(result.Reports))
+			if stats {
+				if err := printStats(os.Stderr, result.Summary.Stats); err != nil {
+					return err
+				}
+			}
+
+			if failing := run.CountFailing(result.Reports, failOnSeverity); failing > 0 {
+				err = fmt.Errorf("found %d lint errors", failing)
 			}
 			return err
 		},
@@ -213,6 +273,21 @@ func Command() *cobra.Command {
 	c.Flags().StringSliceVar(&outputs, "output", []string{},
 		"Output file path (can be repeated). Must match the number of --format flags. "+
 			"If omitted, all outputs go to stdout")
+	c.Flags().StringVar(&templateFile, "template-file", "",
+		fmt.Sprintf("Path to the Go template that renders the output of --format template. Use %s<name> for an example template: %s",
+			exampleTemplatePrefix, strings.Join(exampleTemplateNames(), ", ")))
+	c.Flags().Var(failOn, "fail-on", failOn.Usage())
+	c.Flags().IntVar(&parallelism, "parallelism", 0, "Number of objects to check concurrently. If 0, the number of available CPUs is used")
+	c.Flags().StringVar(&baselinePath, "baseline", "", "Path to a baseline file. Findings recorded in the baseline are not reported")
+	c.Flags().BoolVar(&updateBaseline, "update-baseline", false, "Record all current findings in the file given by --baseline, replacing its contents")
+	c.Flags().BoolVar(&fix, "fix", false, "Apply the fixes suggested by checks to the files, and only report the findings that remain")
+	c.Flags().BoolVar(&fixDryRun, "fix-dry-run", false, "Print the changes that --fix would make as a diff, instead of reporting findings")
+	c.Flags().BoolVar(&stats, "stats", false, "Print statistics about the run to stderr, such as the number of findings by check and the time spent in each check, and include them in the JSON and SARIF output")
+	c.Flags().BoolVar(&cluster.enabled, "cluster", false, "Lint the objects deployed in a cluster instead of files")
+	c.Flags().StringVar(&cluster.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file to use with --cluster. Defaults to $KUBECONFIG or ~/.kube/config")
+	c.Flags().StringVar(&cluster.context, "context", "", "Kubeconfig context to use with --cluster. Defaults to the current context")
+	c.Flags().StringVar(&cluster.namespace, "namespace", "", "Only lint objects in this namespace with --cluster. Defaults to all namespaces, including cluster-scoped objects")
+	c.Flags().BoolVar(&cluster.contextPerNamespace, "context-per-namespace", false, "With --cluster, lint each namespace separately instead of the whole cluster as a single context")
 	c.Flags().BoolVarP(&errorOnInvalidResource, "fail-on-invalid-resource", "", false, "Error out when we have an invalid resource")
 	_ = c.Flags().MarkDeprecated("fail-on-invalid-resource", "Use 'schema-validation' builtin check or kubeconform template for better schema validation.")
 
@@ -220,22 +295,28 @@ func Command() *cobra.Command {
 	return c
 }
 
-func generateReportFromInvalidObjects(lintCtxs []lintcontext.LintContext) []diagnostic.WithContext {
-	var invalidObjectsResult []diagnostic.WithContext
-	for _, lintCtx := range lintCtxs {
-		for _, invalidObj := range lintCtx.InvalidObjects() {
-			invalidObjectsResult = append(invalidObjectsResult, diagnostic.WithContext{
-				Diagnostic: diagnostic.Diagnostic{
-					Message: invalidObj.LoadErr.Error(),
-				},
-				Check:       "failed-to-load-object",
-				Remediation: "Confirm that the file is accessible and is valid k8s yaml.",
-				Object: lintcontext.Object{
-					Metadata:  invalidObj.Metadata,
-					K8sObject: &unstructured.Unstructured{},
-				},
-			})
-		}
+// createSource returns the source of the objects to lint: the given paths or, in --cluster mode, the cluster.
+func createSource(cluster *clusterFlags, args []string) (linter.Source, error) {
+	if !cluster.enabled {
+		return linter.Paths(args...), nil
+	}
+	client, err := cluster.newClient()
+	if err != nil {
+		return nil, err
+	}
+	return linter.Cluster(client, linter.ClusterOptions{
+		Namespace:           cluster.namespace,
+		ContextPerNamespace: cluster.contextPerNamespace,
+		Warn: func(msg string) {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", msg)
+		},
+	}), nil
+}
+
+func colorBySeverity(severity config.Severity, text string) string {
+	c, ok := severityColors[severity.OrDefault()]
+	if !ok {
+		return text
 	}
-	return invalidObjectsResult
+	return c.Sprint(text)
 }
@@ -1,12 +1,15 @@
 package lint
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
+	"golang.stackrox.io/kube-linter/internal/stringutils"
+	"golang.stackrox.io/kube-linter/pkg/run"
 
 	// Register templates
 	_ "golang.stackrox.io/kube-linter/pkg/templates/all"
@@ -33,6 +36,172 @@ func TestCommand_InvalidResources(t *testing.T) {
 	}
 }
 
+func TestCommand_FailOn(t *testing.T) {
+	// use-namespace is a warning-level check, and the pod has no namespace.
+	args := []string{"./testdata/valid-pod.yaml", "--do-not-auto-add-defaults", "--include", "use-namespace"}
+	tests := []struct {
+		name    string
+		failOn  []string
+		failure bool
+	}{
+		{name: "Default", failure: true},
+		{name: "Warning", failOn: []string{"--fail-on", "warning"}, failure: true},
+		{name: "Error", failOn: []string{"--fail-on", "error"}, failure: false},
+		{name: "Invalid", failOn: []string{"--fail-on", "critical"}, failure: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := filepath.Join(t.TempDir(), "result.json")
+			err := createLintCommand(append(append(args, "--format", "json", "--output", output), tt.failOn...)...).Execute()
+			if tt.failure != (err != nil) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			contents, readErr := os.ReadFile(output)
+			if readErr != nil {
+				// The run did not start, e.g. because of an invalid flag.
+				return
+			}
+			// The status of the run agrees with the exit code.
+			var result run.Result
+			if err := json.Unmarshal(contents, &result); err != nil {
+				t.Fatal(err)
+			}
+			if expected := stringutils.Ternary(tt.failure, string(run.ChecksFailed), string(run.ChecksPassed)); string(result.Summary.ChecksStatus) != expected {
+				t.Errorf("expected status %s, got %s", expected, result.Summary.ChecksStatus)
+			}
+		})
+	}
+}
+
+func TestCommand_ClusterFlags(t *testing.T) {
+	for _, args := range [][]string{
+		{"./testdata/valid-pod.yaml", "--namespace", "default"},
+		{"./testdata/valid-pod.yaml", "--context-per-namespace"},
+		{"./testdata/valid-pod.yaml", "--cluster"},
+	} {
+		if err := createLintCommand(args...).Execute(); err == nil {
+			t.Errorf("expected an error for %v", args)
+		}
+	}
+}
+
+func TestCommand_Baseline(t *testing.T) {
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+	args := []string{"./testdata/valid-pod.yaml", "--do-not-auto-add-defaults", "--include", "use-namespace"}
+
+	if err := createLintCommand(args...).Execute(); err == nil {
+		t.Fatal("expected findings without a baseline")
+	}
+	if err := createLintCommand(append(args, "--update-baseline")...).Execute(); err == nil {
+		t.Fatal("expected --update-baseline without --baseline to fail")
+	}
+	if err := createLintCommand(append(args, "--baseline", baselinePath, "--update-baseline")...).Execute(); err != nil {
+		t.Fatalf("unexpected error updating the baseline: %v", err)
+	}
+	if _, err := os.Stat(baselinePath); err != nil {
+		t.Fatalf("baseline was not written: %v", err)
+	}
+	if err := createLintCommand(append(args, "--baseline", baselinePath)...).Execute(); err != nil {
+		t.Fatalf("unexpected error with the baseline: %v", err)
+	}
+	// Findings from checks that are not in the baseline are still reported.
+	if err := createLintCommand(append(args, "--include", "no-liveness-probe", "--baseline", baselinePath)...).Execute(); err == nil {
+		t.Fatal("expected new findings to be reported")
+	}
+}
+
+func TestCommand_Fix(t *testing.T) {
+	const pod = `apiVersion: v1
+kind: Pod
+metadata:
+  name: app
+  namespace: team-a
+spec:
+  containers:
+  - name: app
+    image: nginx:1.25
+    securityContext:
+      runAsNonRoot: true # required
+`
+	path := filepath.Join(t.TempDir(), "pod.yaml")
+	if err := os.WriteFile(path, []byte(pod), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	args := []string{path, "--do-not-auto-add-defaults", "--include", "no-read-only-root-fs"}
+
+	if err := createLintCommand(append(args, "--fix", "--fix-dry-run")...).Execute(); err == nil {
+		t.Fatal("expected --fix and --fix-dry-run to be exclusive")
+	}
+	if err := createLintCommand(append(args, "--fix-dry-run")...).Execute(); err != nil {
+		t.Fatalf("unexpected error with --fix-dry-run: %v", err)
+	}
+	if contents, _ := os.ReadFile(path); string(contents) != pod {
+		t.Fatalf("--fix-dry-run modified the file:\n%s", contents)
+	}
+	if err := createLintCommand(append(args, "--fix")...).Execute(); err != nil {
+		t.Fatalf("unexpected error with --fix: %v", err)
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := pod + "      readOnlyRootFilesystem: true\n"; string(contents) != expected {
+		t.Fatalf("unexpected fixed file:\n%s", contents)
+	}
+	if err := createLintCommand(args...).Execute(); err != nil {
+		t.Fatalf("unexpected findings after --fix: %v", err)
+	}
+}
+
+func TestCommand_SingleContext(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"service/service.yaml": `apiVersion: v1
+kind: Service
+metadata:
+  name: app
+spec:
+  selector:
+    app: app
+`,
+		"deployment/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  selector:
+    matchLabels:
+      app: app
+  template:
+    metadata:
+      labels:
+        app: app
+    spec:
+      containers:
+      - name: app
+        image: nginx:1.25
+`,
+	}
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	args := []string{dir, "--do-not-auto-add-defaults", "--include", "dangling-service"}
+
+	// The service and the deployment are in different directories, so they are linted separately by default.
+	if err := createLintCommand(args...).Execute(); err == nil {
+		t.Fatal("expected the service to be reported as dangling")
+	}
+	if err := createLintCommand(append(args, "--single-context")...).Execute(); err != nil {
+		t.Fatalf("unexpected findings with --single-context: %v", err)
+	}
+}
+
 func createLintCommand(args ...string) *cobra.Command {
 	c := Command()
 	c.SilenceUsage = true
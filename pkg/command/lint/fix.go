@@ -0,0 +1,58 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"golang.stackrox.io/kube-linter/pkg/autofix"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+// applyFixes applies the fixes suggested by the reports of the result to the files, and removes the fixed reports
+// from the result. If dryRun is set, the files are left untouched, and the changes are written to out as a diff.
+func applyFixes(out io.Writer, result *run.Result, dryRun, verbose bool) error {
+	fixResult := autofix.Fix(result.Reports)
+	if verbose {
+		for _, err := range fixResult.Errors {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
+	if dryRun {
+		for _, change := range fixResult.Changes {
+			diff, err := change.Diff(displayPath(change.Path))
+			if err != nil {
+				return err
+			}
+			if _, err := io.WriteString(out, diff); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(os.Stderr, "%d finding(s) can be fixed in %d file(s)\n", len(fixResult.Fixed), len(fixResult.Changes))
+		return nil
+	}
+
+	for _, change := range fixResult.Changes {
+		if err := change.Write(); err != nil {
+			return fmt.Errorf("writing fixes to %s: %w", change.Path, err)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Fixed %d finding(s) in %d file(s)\n", len(fixResult.Fixed), len(fixResult.Changes))
+	result.Reports = fixResult.Remaining
+	return nil
+}
+
+// displayPath returns the path relative to the working directory, if it is within it.
+func displayPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(wd, path)
+	if err == nil && filepath.IsLocal(rel) {
+		return rel
+	}
+	return path
+}
@@ -0,0 +1,82 @@
+package lint
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+// gitLabIssue is an issue of a GitLab Code Quality report.
+type gitLabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitLabLocation `json:"location"`
+}
+
+type gitLabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitLabLines `json:"lines"`
+}
+
+type gitLabLines struct {
+	Begin int `json:"begin"`
+}
+
+// formatLintGitLab implements common.GitLabFormat.
+// Must be used only with lint.Command because it only understands run.Result as data parameter.
+func formatLintGitLab(out io.Writer, data interface{}) error {
+	if res, ok := data.(run.Result); ok {
+		return formatGitLab(out, res)
+	}
+	return errors.New("provided data must be of run.Result type")
+}
+
+// formatGitLab writes an issue per finding. GitLab expects the paths of issues to be relative to the repository root,
+// so they are made relative to the working directory, assuming that this tool is invoked from the repository root.
+func formatGitLab(out io.Writer, result run.Result) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	fingerprints := reportFingerprints(result.Reports, cwd)
+	issues := make([]gitLabIssue, 0, len(result.Reports))
+	for i := range result.Reports {
+		r := &result.Reports[i]
+		line := r.Diagnostic.Line
+		if line == 0 {
+			line = 1
+		}
+		issues = append(issues, gitLabIssue{
+			Description: describeReport(r),
+			CheckName:   r.Check,
+			Fingerprint: fingerprints[i],
+			Severity:    getGitLabSeverity(r.Severity),
+			Location: gitLabLocation{
+				Path:  relativeReportPath(cwd, r.Object.Metadata.FilePath),
+				Lines: gitLabLines{Begin: line},
+			},
+		})
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(issues)
+}
+
+// getGitLabSeverity maps a severity to the corresponding GitLab Code Quality severity.
+func getGitLabSeverity(severity config.Severity) string {
+	switch severity.OrDefault() {
+	case config.SeverityWarning:
+		return "minor"
+	case config.SeverityInfo:
+		return "info"
+	default:
+		return "major"
+	}
+}
@@ -0,0 +1,37 @@
+package lint
+
+import (
+	_ "embed"
+	"errors"
+	"html/template"
+	"io"
+
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+var (
+	//go:embed html_report.tmpl
+	htmlTemplateStr string
+
+	// The html format uses html/template rather than the common templates, so that the findings are escaped.
+	htmlTemplate = template.Must(template.New("html").Parse(htmlTemplateStr))
+)
+
+// formatLintHTML implements common.HTMLFormat.
+// Must be used only with lint.Command because it only understands run.Result as data parameter.
+func formatLintHTML(out io.Writer, data interface{}) error {
+	if res, ok := data.(run.Result); ok {
+		return formatHTML(out, res)
+	}
+	return errors.New("provided data must be of run.Result type")
+}
+
+// formatHTML writes a self-contained page, which lists all the findings and lets readers filter them by severity, by
+// check and by text.
+func formatHTML(out io.Writer, result run.Result) error {
+	summary, err := newReportSummary(result, 0)
+	if err != nil {
+		return err
+	}
+	return htmlTemplate.Execute(out, summary)
+}
@@ -0,0 +1,150 @@
+package lint
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golang.stackrox.io/kube-linter/internal/consts"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string           `xml:"name,attr"`
+	ClassName  string           `xml:"classname,attr"`
+	Properties *junitProperties `xml:"properties"`
+	Failure    *junitFailure    `xml:"failure"`
+}
+
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+// formatLintJUnit implements common.JUnitFormat.
+// Must be used only with lint.Command because it only understands run.Result as data parameter.
+func formatLintJUnit(out io.Writer, data interface{}) error {
+	if res, ok := data.(run.Result); ok {
+		return formatJUnit(out, res)
+	}
+	return errors.New("provided data must be of run.Result type")
+}
+
+// formatJUnit writes a test suite per check, with a failed test case per finding of the check, or a single passed test
+// case if the check has no findings.
+func formatJUnit(out io.Writer, result run.Result) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	fingerprints := reportFingerprints(result.Reports, cwd)
+
+	var timestamp string
+	if !result.Summary.CheckEndTime.IsZero() {
+		timestamp = result.Summary.CheckEndTime.UTC().Format(time.RFC3339)
+	}
+	suites := junitTestSuites{Name: consts.ProgramName}
+	indices := make(map[string]int, len(result.Checks))
+	addSuite := func(name string) int {
+		idx, ok := indices[name]
+		if !ok {
+			idx = len(suites.Suites)
+			indices[name] = idx
+			suites.Suites = append(suites.Suites, junitTestSuite{Name: name, Timestamp: timestamp})
+		}
+		return idx
+	}
+	for _, check := range result.Checks {
+		addSuite(check.Name)
+	}
+	// Reports of checks that are not in result.Checks, such as timeouts, get suites of their own.
+	for i := range result.Reports {
+		report := &result.Reports[i]
+		testCase, err := newJUnitFailedCase(report, fingerprints[i])
+		if err != nil {
+			return err
+		}
+		suite := &suites.Suites[addSuite(report.Check)]
+		suite.Cases = append(suite.Cases, testCase)
+		suite.Failures++
+	}
+	for i := range suites.Suites {
+		suite := &suites.Suites[i]
+		if len(suite.Cases) == 0 {
+			suite.Cases = []junitTestCase{{Name: suite.Name, ClassName: suite.Name}}
+		}
+		suite.Tests = len(suite.Cases)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+	}
+
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(out)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, "\n")
+	return err
+}
+
+func newJUnitFailedCase(report *diagnostic.WithContext, fingerprint string) (junitTestCase, error) {
+	k8sObjectName := report.Object.GetK8sObjectName()
+	text, err := renderTemplate(resultMessageTemplate, struct {
+		Report     *diagnostic.WithContext
+		ObjectName lintcontext.K8sObjectInfo
+	}{Report: report, ObjectName: k8sObjectName})
+	if err != nil {
+		return junitTestCase{}, err
+	}
+	text += "\nfile: " + report.Object.Metadata.FilePath
+	if report.Diagnostic.Line > 0 {
+		text += fmt.Sprintf(":%d", report.Diagnostic.Line)
+	}
+	if report.Remediation != "" {
+		text += "\nremediation: " + report.Remediation
+	}
+	return junitTestCase{
+		Name:       k8sObjectName.String(),
+		ClassName:  report.Check,
+		Properties: &junitProperties{Properties: []junitProperty{{Name: "fingerprint", Value: fingerprint}}},
+		Failure: &junitFailure{
+			Message: report.Diagnostic.Message,
+			Type:    string(report.Severity.OrDefault()),
+			Text:    text,
+		},
+	}, nil
+}
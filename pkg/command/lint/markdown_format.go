@@ -0,0 +1,84 @@
+package lint
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"text/template"
+
+	"golang.stackrox.io/kube-linter/pkg/command/common"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+const (
+	// maxMarkdownFindingsPerCheck bounds the size of the markdown summary, so that it fits in a pull request comment.
+	maxMarkdownFindingsPerCheck = 25
+
+	markdownTemplateStr = `## KubeLinter {{ .Summary.KubeLinterVersion }}
+
+{{ if .Total -}}
+:x: **{{ .Total }} finding{{ if ne .Total 1 }}s{{ end }}** in {{ .Objects }} object{{ if ne .Objects 1 }}s{{ end }}: {{ range $i, $c := .SeverityCounts }}{{ if $i }}, {{ end }}{{ $c.Count }} {{ $c.Severity }}{{ end }}
+
+| Check | Severity | Findings |
+| --- | --- | --- |
+{{ range .Checks -}}
+| {{ if .URL }}[{{ .Name | codeSnippetInTable }}]({{ .URL }}){{ else }}{{ .Name | codeSnippetInTable }}{{ end }} | {{ .Severity }} | {{ .Count }} |
+{{ end }}
+{{- range .Checks }}
+<details>
+<summary><b>{{ .Name }}</b> ({{ .Severity }}): {{ .Count }} finding{{ if ne .Count 1 }}s{{ end }}</summary>
+{{ with .Description }}
+{{ . | escapeMarkdown }}
+{{ end }}{{ with .Remediation }}
+**Remediation:** {{ . | escapeMarkdown }}
+{{ end }}
+{{ range .Objects -}}
+{{ .Name | codeSnippet }} in {{ .FilePath | codeSnippet }}{{ with .Variant }} (variant: {{ . | codeSnippet }}){{ end }}
+{{ range .Findings -}}
+- {{ if .Line }}line {{ .Line }}: {{ end }}{{ .Message | escapeMarkdown }}{{ with .Field }} (field: {{ . | codeSnippet }}){{ end }}
+{{ end }}
+{{ end -}}
+{{ with .Omitted }}_... and {{ . }} more._
+
+{{ end -}}
+</details>
+{{ end -}}
+{{ else -}}
+:white_check_mark: No lint errors found!
+{{ end -}}
+`
+)
+
+var (
+	markdownTemplate = common.MustInstantiateMarkdownTemplate(markdownTemplateStr, template.FuncMap{
+		"escapeMarkdown": escapeMarkdown,
+	})
+
+	markdownEscaper = strings.NewReplacer(
+		`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "|", `\|`, "<", "&lt;", ">", "&gt;",
+	)
+)
+
+// formatLintMarkdown implements common.MarkdownFormat.
+// Must be used only with lint.Command because it only understands run.Result as data parameter.
+func formatLintMarkdown(out io.Writer, data interface{}) error {
+	if res, ok := data.(run.Result); ok {
+		return formatMarkdown(out, res)
+	}
+	return errors.New("provided data must be of run.Result type")
+}
+
+// formatMarkdown writes a summary of the findings that fits in a pull request comment: the number of findings by
+// severity and by check, and a collapsible section per check with its findings grouped by object.
+func formatMarkdown(out io.Writer, result run.Result) error {
+	summary, err := newReportSummary(result, maxMarkdownFindingsPerCheck)
+	if err != nil {
+		return err
+	}
+	return markdownTemplate.Execute(out, summary)
+}
+
+// escapeMarkdown escapes the characters of free text, such as messages, that markdown would interpret.
+func escapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
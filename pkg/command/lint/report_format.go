@@ -0,0 +1,58 @@
+package lint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.stackrox.io/kube-linter/pkg/baseline"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+)
+
+// describeReport describes a report on a single line, for the formats that do not have fields for the object, variant
+// and field of a finding.
+func describeReport(report *diagnostic.WithContext) string {
+	var b strings.Builder
+	b.WriteString(report.Diagnostic.Message)
+	fmt.Fprintf(&b, " (object: %s", report.Object.GetK8sObjectName())
+	if variant := report.Object.Metadata.Variant; variant != "" {
+		fmt.Fprintf(&b, ", variant: %s", variant)
+	}
+	if path := report.Diagnostic.Path; path != "" {
+		fmt.Fprintf(&b, ", field: %s", path)
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+// reportFingerprints returns a stable fingerprint of each report, which is unique among the reports. It extends the
+// fingerprint of the report in a baseline (see baseline.Fingerprint) with the field and message of the finding, so
+// that the findings of a check for different fields of an object are told apart, but it does not depend on where the
+// object is in its file.
+func reportFingerprints(reports []diagnostic.WithContext, baseDir string) []string {
+	fingerprints := make([]string, 0, len(reports))
+	seen := make(map[string]int, len(reports))
+	for i := range reports {
+		parts := []string{baseline.Fingerprint(reports[i], baseDir), reports[i].Diagnostic.Path, reports[i].Diagnostic.Message}
+		fingerprint := hashParts(parts)
+		// Identical findings, which can only be reported by the same check, are numbered in order.
+		if n := seen[fingerprint]; n > 0 {
+			seen[fingerprint]++
+			fingerprint = hashParts(append(parts, fmt.Sprint(n)))
+		} else {
+			seen[fingerprint] = 1
+		}
+		fingerprints = append(fingerprints, fingerprint)
+	}
+	return fingerprints
+}
+
+func hashParts(parts []string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		_, _ = h.Write([]byte(part))
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,236 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/command/common"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/run"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newFormatTestResult(t *testing.T) run.Result {
+	path, err := filepath.Abs(filepath.Join("testdata", "pod.yaml"))
+	require.NoError(t, err)
+	obj := lintcontext.Object{
+		Metadata: lintcontext.ObjectMetadata{FilePath: path},
+		K8sObject: &v1.Pod{
+			TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metaV1.ObjectMeta{Name: "app", Namespace: "default"},
+		},
+	}
+	return run.Result{
+		Checks: []config.Check{{Name: "latest-tag"}, {Name: "run-as-non-root", Severity: config.SeverityWarning}},
+		Reports: []diagnostic.WithContext{
+			{
+				Diagnostic: diagnostic.Diagnostic{
+					Message:   "image uses latest",
+					Path:      "spec.containers[0].image",
+					Line:      8,
+					Column:    5,
+					Code:      "latest-tag/blocked-image",
+					Container: "app",
+					Value:     "nginx",
+				},
+				Check:    "latest-tag",
+				Severity: config.SeverityError,
+				Object:   obj,
+			},
+			{
+				Diagnostic: diagnostic.Diagnostic{
+					Message:   "image uses latest",
+					Path:      "spec.containers[1].image",
+					Line:      10,
+					Column:    5,
+					Code:      "latest-tag/blocked-image",
+					Container: "cache",
+					Value:     "redis:latest",
+				},
+				Check:    "latest-tag",
+				Severity: config.SeverityError,
+				Object:   obj,
+			},
+			{
+				Diagnostic: diagnostic.Diagnostic{Message: "check timed out"},
+				Check:      run.CheckTimeoutName,
+				Severity:   config.SeverityInfo,
+				Object:     obj,
+			},
+		},
+		Summary: run.Summary{ChecksStatus: run.ChecksFailed},
+	}
+}
+
+func TestFormatJUnit(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, formatLintJUnit(&out, newFormatTestResult(t)))
+
+	var suites junitTestSuites
+	require.NoError(t, xml.Unmarshal(out.Bytes(), &suites))
+	assert.Equal(t, 4, suites.Tests)
+	assert.Equal(t, 3, suites.Failures)
+	require.Len(t, suites.Suites, 3)
+
+	latestTag := suites.Suites[0]
+	assert.Equal(t, "latest-tag", latestTag.Name)
+	assert.Equal(t, 2, latestTag.Failures)
+	require.Len(t, latestTag.Cases, 2)
+	assert.Equal(t, "default/app /v1, Kind=Pod", latestTag.Cases[0].Name)
+	require.NotNil(t, latestTag.Cases[0].Failure)
+	assert.Equal(t, "error", latestTag.Cases[0].Failure.Type)
+	assert.Contains(t, latestTag.Cases[0].Failure.Text, "field: spec.containers[0].image")
+	require.NotNil(t, latestTag.Cases[0].Properties)
+	require.NotNil(t, latestTag.Cases[1].Properties)
+	assert.NotEqual(t, latestTag.Cases[0].Properties.Properties[0].Value, latestTag.Cases[1].Properties.Properties[0].Value)
+
+	// Checks without findings have a single passed test case.
+	passed := suites.Suites[1]
+	assert.Equal(t, "run-as-non-root", passed.Name)
+	require.Len(t, passed.Cases, 1)
+	assert.Nil(t, passed.Cases[0].Failure)
+
+	assert.Equal(t, run.CheckTimeoutName, suites.Suites[2].Name)
+}
+
+func TestFormatCheckstyle(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, formatLintCheckstyle(&out, newFormatTestResult(t)))
+
+	var report checkstyleReport
+	require.NoError(t, xml.Unmarshal(out.Bytes(), &report))
+	require.Len(t, report.Files, 1)
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	for i, fingerprint := range reportFingerprints(newFormatTestResult(t).Reports, cwd) {
+		assert.Equal(t, fingerprint, report.Files[0].Errors[i].Fingerprint)
+		report.Files[0].Errors[i].Fingerprint = ""
+	}
+	assert.Equal(t, []checkstyleError{
+		{Line: 8, Column: 5, Severity: "error", Message: "image uses latest (object: default/app /v1, Kind=Pod, field: spec.containers[0].image)", Source: "latest-tag"},
+		{Line: 10, Column: 5, Severity: "error", Message: "image uses latest (object: default/app /v1, Kind=Pod, field: spec.containers[1].image)", Source: "latest-tag"},
+		{Line: 1, Severity: "info", Message: "check timed out (object: default/app /v1, Kind=Pod)", Source: run.CheckTimeoutName},
+	}, report.Files[0].Errors)
+}
+
+func TestFormatGitLab(t *testing.T) {
+	result := newFormatTestResult(t)
+	var out bytes.Buffer
+	require.NoError(t, formatLintGitLab(&out, result))
+
+	var issues []gitLabIssue
+	require.NoError(t, json.Unmarshal(out.Bytes(), &issues))
+	require.Len(t, issues, 3)
+	assert.Equal(t, "latest-tag", issues[0].CheckName)
+	assert.Equal(t, "major", issues[0].Severity)
+	assert.Equal(t, gitLabLocation{Path: "testdata/pod.yaml", Lines: gitLabLines{Begin: 8}}, issues[0].Location)
+	assert.Equal(t, "info", issues[2].Severity)
+	assert.Equal(t, 1, issues[2].Location.Lines.Begin)
+
+	// Fingerprints are unique, and do not depend on the position of the findings.
+	assert.NotEqual(t, issues[0].Fingerprint, issues[1].Fingerprint)
+	result.Reports[0].Diagnostic.Line = 20
+	out.Reset()
+	require.NoError(t, formatLintGitLab(&out, result))
+	var moved []gitLabIssue
+	require.NoError(t, json.Unmarshal(out.Bytes(), &moved))
+	assert.Equal(t, issues[0].Fingerprint, moved[0].Fingerprint)
+
+	// Identical findings get different fingerprints.
+	fingerprints := reportFingerprints(append(result.Reports, result.Reports[0]), "")
+	assert.NotEqual(t, fingerprints[0], fingerprints[3])
+}
+
+func TestFormatSarifProperties(t *testing.T) {
+	result := newFormatTestResult(t)
+	// Rules need the templates of the checks, which are not needed for the results.
+	result.Checks = nil
+	var out bytes.Buffer
+	require.NoError(t, formatLintSarif(&out, result))
+
+	var report struct {
+		Runs []struct {
+			Results []struct {
+				Properties map[string]string
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	require.Len(t, report.Runs, 1)
+	require.Len(t, report.Runs[0].Results, 3)
+	assert.Equal(t, map[string]string{
+		"code":      "latest-tag/blocked-image",
+		"container": "app",
+		"field":     "spec.containers[0].image",
+		"value":     "nginx",
+	}, report.Runs[0].Results[0].Properties)
+	assert.Nil(t, report.Runs[0].Results[2].Properties)
+}
+
+func TestFormatsRequireResult(t *testing.T) {
+	for _, format := range []common.FormatFunc{formatLintJUnit, formatLintCheckstyle, formatLintGitLab, formatLintMarkdown, formatLintHTML} {
+		assert.Error(t, format(&bytes.Buffer{}, "not a result"))
+	}
+}
+
+func TestReportSummary(t *testing.T) {
+	summary, err := newReportSummary(newFormatTestResult(t), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 3, summary.Total)
+	assert.Equal(t, 1, summary.Objects)
+	assert.Equal(t, []severityCount{{Severity: config.SeverityError, Count: 2}, {Severity: config.SeverityInfo, Count: 1}}, summary.SeverityCounts)
+	assert.Equal(t, []string{"latest-tag", "run-as-non-root"}, summary.CheckNames)
+
+	require.Len(t, summary.Checks, 2)
+	latestTag := summary.Checks[0]
+	assert.Equal(t, "latest-tag", latestTag.Name)
+	assert.Equal(t, 2, latestTag.Count)
+	require.Len(t, latestTag.Objects, 1)
+	assert.Equal(t, "testdata/pod.yaml", latestTag.Objects[0].FilePath)
+	assert.Equal(t, []findingSummary{
+		{Message: "image uses latest", Field: "spec.containers[0].image", Line: 8, Severity: config.SeverityError},
+		{Message: "image uses latest", Field: "spec.containers[1].image", Line: 10, Severity: config.SeverityError},
+	}, latestTag.Objects[0].Findings)
+	// Checks that are not in the result have no documentation.
+	assert.Equal(t, run.CheckTimeoutName, summary.Checks[1].Name)
+	assert.Empty(t, summary.Checks[1].URL)
+
+	summary, err = newReportSummary(newFormatTestResult(t), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.Checks[0].Count)
+	assert.Len(t, summary.Checks[0].Objects[0].Findings, 1)
+	assert.Equal(t, 1, summary.Checks[0].Omitted)
+}
+
+func TestFormatMarkdown(t *testing.T) {
+	result := newFormatTestResult(t)
+	result.Reports[0].Diagnostic.Message = "image <b>uses</b> *latest*"
+	var out bytes.Buffer
+	require.NoError(t, formatLintMarkdown(&out, result))
+	assert.Contains(t, out.String(), "**3 findings** in 1 object: 2 error, 1 info")
+	assert.Contains(t, out.String(), "<summary><b>latest-tag</b> (error): 2 findings</summary>")
+	assert.Contains(t, out.String(), `- line 8: image &lt;b&gt;uses&lt;/b&gt; \*latest\* (field: `+"`spec.containers[0].image`)")
+
+	out.Reset()
+	require.NoError(t, formatLintMarkdown(&out, run.Result{Summary: run.Summary{KubeLinterVersion: "v1"}}))
+	assert.Equal(t, "## KubeLinter v1\n\n:white_check_mark: No lint errors found!\n", out.String())
+}
+
+func TestFormatHTML(t *testing.T) {
+	result := newFormatTestResult(t)
+	result.Reports[0].Diagnostic.Message = "<script>alert(1)</script>"
+	var out bytes.Buffer
+	require.NoError(t, formatLintHTML(&out, result))
+	assert.Contains(t, out.String(), `<tr data-severity="error" data-check="latest-tag">`)
+	assert.Contains(t, out.String(), "&lt;script&gt;alert(1)&lt;/script&gt;")
+	assert.Contains(t, out.String(), `<option value="info">info</option>`)
+}
@@ -0,0 +1,166 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"golang.stackrox.io/kube-linter/pkg/baseline"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+// reportSummary is the data of the summary reports, the markdown and html formats, which group the findings by check
+// and by object.
+type reportSummary struct {
+	Summary run.Summary
+	// Total is the number of findings.
+	Total int
+	// Objects is the number of objects with findings.
+	Objects        int
+	SeverityCounts []severityCount
+	// Checks are the checks with findings, the most severe first.
+	Checks []checkSummary
+	// CheckNames are the names of all the checks that ran.
+	CheckNames []string
+}
+
+type severityCount struct {
+	Severity config.Severity
+	Count    int
+}
+
+type checkSummary struct {
+	Name        string
+	Severity    config.Severity
+	Description string
+	Remediation string
+	// URL is the documentation of the template of the check, if it is known.
+	URL   string
+	Count int
+	// Objects are the objects with findings, in the order of the reports.
+	Objects []objectSummary
+	// Omitted is the number of findings that are not listed in Objects, because of the limit of findings per check.
+	Omitted int
+}
+
+type objectSummary struct {
+	Name     string
+	FilePath string
+	Variant  string
+	Findings []findingSummary
+}
+
+type findingSummary struct {
+	Message  string
+	Field    string
+	Line     int
+	Severity config.Severity
+}
+
+// newReportSummary groups the findings of the result by check and by object. If maxFindingsPerCheck is positive, at
+// most that many findings are listed for each check, so that the summary keeps a bounded size.
+func newReportSummary(result run.Result, maxFindingsPerCheck int) (reportSummary, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return reportSummary{}, err
+	}
+	summary := reportSummary{Summary: result.Summary, Total: len(result.Reports)}
+	specs := make(map[string]*config.Check, len(result.Checks))
+	for i := range result.Checks {
+		specs[result.Checks[i].Name] = &result.Checks[i]
+		summary.CheckNames = append(summary.CheckNames, result.Checks[i].Name)
+	}
+
+	severityCounts := make(map[config.Severity]int)
+	checkIndices := make(map[string]int)
+	type objectKey struct{ check, object, filePath, variant string }
+	objectIndices := make(map[objectKey]int)
+	objects := make(map[objectKey]bool)
+	for i := range result.Reports {
+		report := &result.Reports[i]
+		severity := report.Severity.OrDefault()
+		severityCounts[severity]++
+
+		idx, ok := checkIndices[report.Check]
+		if !ok {
+			idx = len(summary.Checks)
+			checkIndices[report.Check] = idx
+			summary.Checks = append(summary.Checks, newCheckSummary(report, specs[report.Check]))
+		}
+		check := &summary.Checks[idx]
+		check.Count++
+		// A check can report findings of several severities (for example, the checks of suppressions), so the check
+		// has the highest one.
+		if severity.AtLeast(check.Severity) {
+			check.Severity = severity
+		}
+
+		key := objectKey{
+			check:    report.Check,
+			object:   report.Object.GetK8sObjectName().String(),
+			filePath: relativeReportPath(cwd, report.Object.Metadata.FilePath),
+			variant:  report.Object.Metadata.Variant,
+		}
+		objects[objectKey{object: key.object, filePath: key.filePath, variant: key.variant}] = true
+		if maxFindingsPerCheck > 0 && check.Count > maxFindingsPerCheck {
+			check.Omitted++
+			continue
+		}
+		objIdx, ok := objectIndices[key]
+		if !ok {
+			objIdx = len(check.Objects)
+			objectIndices[key] = objIdx
+			check.Objects = append(check.Objects, objectSummary{Name: key.object, FilePath: key.filePath, Variant: key.variant})
+		}
+		check.Objects[objIdx].Findings = append(check.Objects[objIdx].Findings, newFindingSummary(report))
+	}
+	summary.Objects = len(objects)
+
+	for _, severity := range config.AllSeverities() {
+		if count := severityCounts[config.Severity(severity)]; count > 0 {
+			summary.SeverityCounts = append(summary.SeverityCounts, severityCount{Severity: config.Severity(severity), Count: count})
+		}
+	}
+	sort.SliceStable(summary.Checks, func(i, j int) bool {
+		ci, cj := summary.Checks[i], summary.Checks[j]
+		if ci.Severity != cj.Severity {
+			return ci.Severity.AtLeast(cj.Severity)
+		}
+		return ci.Name < cj.Name
+	})
+	return summary, nil
+}
+
+func newCheckSummary(report *diagnostic.WithContext, spec *config.Check) checkSummary {
+	check := checkSummary{Name: report.Check, Severity: report.Severity.OrDefault(), Remediation: report.Remediation}
+	// Reports of checks that are not in the result, such as timeouts, have no documentation.
+	if spec == nil {
+		return check
+	}
+	check.Description = spec.Description
+	// Checks of external templates have no documentation page.
+	if url, err := getCheckTemplateURL(spec); err == nil {
+		check.URL = url
+	}
+	return check
+}
+
+func newFindingSummary(report *diagnostic.WithContext) findingSummary {
+	return findingSummary{
+		Message:  report.Diagnostic.Message,
+		Field:    report.Diagnostic.Path,
+		Line:     report.Diagnostic.Line,
+		Severity: report.Severity.OrDefault(),
+	}
+}
+
+// relativeReportPath makes the path of a report relative to the working directory, with forward slashes, so that it
+// matches the paths of the repository when KubeLinter is invoked from its root.
+func relativeReportPath(cwd, path string) string {
+	if absPath, err := filepath.Abs(path); err == nil {
+		path = absPath
+	}
+	return baseline.NormalizePath(path, cwd)
+}
@@ -26,7 +26,9 @@ Remediation: {{.Remediation}}
 Template: {{checkTemplateURL .}}`
 
 	resultMessageTemplateStr = `{{.Report.Diagnostic.Message}}
-object: {{.ObjectName}}`
+object: {{.ObjectName}}{{with .Report.Object.Metadata.Variant}}
+variant: {{.}}{{end}}{{with .Report.Diagnostic.Path}}
+field: {{.}}{{end}}`
 )
 
 var (
@@ -61,10 +63,15 @@ func formatSarif(out io.Writer, result run.Result) error {
 		return err
 	}
 
-	sarifRun.AddInvocation(result.Summary.ChecksStatus == run.ChecksPassed).
+	invocation := sarifRun.AddInvocation(result.Summary.ChecksStatus == run.ChecksPassed).
 		WithEndTimeUTC(result.Summary.CheckEndTime).
 		// WithWorkingDirectory helps GitHub resolve artifact locations from repo root when their paths are absolute.
 		WithWorkingDirectory(sarif.NewArtifactLocation().WithUri("file://" + cwd))
+	if result.Summary.Stats != nil {
+		properties := sarif.NewPropertyBag()
+		properties.Add("stats", result.Summary.Stats)
+		invocation.AttachPropertyBag(properties)
+	}
 
 	for i := range result.Checks {
 		err = addSarifRule(sarifRun, &result.Checks[i])
@@ -98,6 +105,7 @@ func addSarifRule(sarifRun *sarif.Run, check *config.Check) error {
 		WithDescription(check.Description).
 		WithFullDescription(sarif.NewMultiformatMessageString(check.Remediation)).
 		WithHelpURI(helpURL).
+		WithDefaultConfiguration(sarif.NewReportingConfiguration().WithLevel(getSarifLevel(check.Severity))).
 		// Notice that we give WithHelp the same information as added above for couple of reasons:
 		// 1) GitHub does not display HelpURI, although this attribute is required.
 		// 2) Rule ID, short and full descriptions are shown at different spots on the screen but it is helpful to see
@@ -130,10 +138,7 @@ func addSarifResult(sarifRun *sarif.Run, cwd string, report *diagnostic.WithCont
 
 	sarifLocation.PhysicalLocation = sarif.NewPhysicalLocation().
 		WithArtifactLocation(sarif.NewArtifactLocation().WithUri(getArtifactURI(cwd, report.Object.Metadata.FilePath))).
-		// We currently assign all errors to the first line in the file otherwise the absent region on the output
-		// does not pass GitHub validation rule GH1003.
-		// TODO: update region location with actual position in the file when it is available.
-		WithRegion(sarif.NewRegion().WithStartLine(1))
+		WithRegion(getSarifRegion(&report.Diagnostic))
 
 	k8sObjectName := report.Object.GetK8sObjectName()
 
@@ -155,14 +160,65 @@ func addSarifResult(sarifRun *sarif.Run, cwd string, report *diagnostic.WithCont
 	}
 
 	result := sarif.NewRuleResult(report.Check).
+		WithLevel(getSarifLevel(report.Severity)).
 		WithMessage(sarif.NewTextMessage(messageText))
 	result.AddLocation(sarifLocation)
+	if properties := getSarifProperties(&report.Diagnostic); properties != nil {
+		result.AttachPropertyBag(properties)
+	}
 
 	sarifRun.AddResult(result)
 
 	return nil
 }
 
+// getSarifProperties returns the structured fields of the diagnostic as a property bag, so that tools consuming the
+// SARIF output do not have to parse them out of the message, or nil if the diagnostic has none.
+func getSarifProperties(d *diagnostic.Diagnostic) *sarif.PropertyBag {
+	properties := sarif.NewPropertyBag()
+	for _, field := range []struct{ key, value string }{
+		{"code", d.Code},
+		{"container", d.Container},
+		{"field", d.Path},
+		{"value", d.Value},
+		{"expected", d.Expected},
+	} {
+		if field.value != "" {
+			properties.AddString(field.key, field.value)
+		}
+	}
+	if len(properties.Properties) == 0 {
+		return nil
+	}
+	return properties
+}
+
+// getSarifLevel maps a severity to the corresponding SARIF level.
+func getSarifLevel(severity config.Severity) string {
+	switch severity.OrDefault() {
+	case config.SeverityWarning:
+		return "warning"
+	case config.SeverityInfo:
+		return "note"
+	default:
+		return "error"
+	}
+}
+
+// getSarifRegion returns the region of the diagnostic in its file.
+// If the position is unknown, the error is assigned to the first line in the file, otherwise the absent region on the
+// output does not pass GitHub validation rule GH1003.
+func getSarifRegion(d *diagnostic.Diagnostic) *sarif.Region {
+	if d.Line == 0 {
+		return sarif.NewRegion().WithStartLine(1)
+	}
+	region := sarif.NewRegion().WithStartLine(d.Line)
+	if d.Column > 0 {
+		region.WithStartColumn(d.Column)
+	}
+	return region
+}
+
 // getArtifactURI tries to resolve path relative to cwd; if that fails, tries to get the absolute path with appended
 // `file://` protocol; if that fails, returns the path as-is.
 // GitHub prefers file URIs to be provided relative to the repo root. Assuming that this tool is invoked from the repo
@@ -0,0 +1,90 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+// statsEntry is a row of a table of the stats, with the value already formatted.
+type statsEntry struct {
+	name  string
+	value string
+}
+
+// printStats writes the given stats as text, with the slowest checks and loaders, and the most frequent findings,
+// first.
+func printStats(out io.Writer, stats *run.Stats) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Statistics:\n")
+	fmt.Fprintf(w, "  Contexts:\t%d\n", stats.Contexts)
+	fmt.Fprintf(w, "  Objects:\t%d\n", stats.Objects)
+	fmt.Fprintf(w, "  Invalid objects:\t%d\n", stats.InvalidObjects)
+	fmt.Fprintf(w, "  Run time:\t%s\n", roundDuration(stats.Duration))
+	for _, table := range []struct {
+		title   string
+		entries []statsEntry
+	}{
+		{"Load time by loader", durationEntries(stats.LoadDurations)},
+		{"Check time by check", durationEntries(stats.CheckDurations)},
+		{"Findings by check", countEntries(stats.FindingsByCheck)},
+		{"Findings by kind", countEntries(stats.FindingsByKind)},
+		{"Findings by namespace", countEntries(stats.FindingsByNamespace)},
+	} {
+		if len(table.entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "  %s:\n", table.title)
+		for _, entry := range table.entries {
+			fmt.Fprintf(w, "    %s\t%s\n", entry.name, entry.value)
+		}
+	}
+	return w.Flush()
+}
+
+// durationEntries returns the entries of the given durations, from the longest to the shortest.
+func durationEntries(durations map[string]time.Duration) []statsEntry {
+	names := make([]string, 0, len(durations))
+	for name := range durations {
+		names = append(names, name)
+	}
+	sortNames(names, func(a, b string) bool { return durations[a] > durations[b] })
+	entries := make([]statsEntry, 0, len(names))
+	for _, name := range names {
+		entries = append(entries, statsEntry{name: name, value: roundDuration(durations[name]).String()})
+	}
+	return entries
+}
+
+// countEntries returns the entries of the given counts, from the largest to the smallest.
+func countEntries(counts map[string]int) []statsEntry {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sortNames(names, func(a, b string) bool { return counts[a] > counts[b] })
+	entries := make([]statsEntry, 0, len(names))
+	for _, name := range names {
+		displayName := name
+		if displayName == "" {
+			displayName = "<none>"
+		}
+		entries = append(entries, statsEntry{name: displayName, value: fmt.Sprint(counts[name])})
+	}
+	return entries
+}
+
+// sortNames sorts the given names with the given function, and then by name.
+func sortNames(names []string, less func(a, b string) bool) {
+	sort.Strings(names)
+	sort.SliceStable(names, func(i, j int) bool { return less(names[i], names[j]) })
+}
+
+// roundDuration rounds the given duration to a precision that is readable, but still shows fast checks.
+func roundDuration(d time.Duration) time.Duration {
+	return d.Round(time.Microsecond)
+}
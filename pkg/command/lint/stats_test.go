@@ -0,0 +1,72 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+func newTestStats() *run.Stats {
+	return &run.Stats{
+		Contexts:            1,
+		Objects:             3,
+		Duration:            12345678 * time.Nanosecond,
+		FindingsByCheck:     map[string]int{"latest-tag": 2, "run-as-non-root": 3, "no-read-only-root-fs": 2},
+		FindingsByKind:      map[string]int{"Pod": 7},
+		FindingsByNamespace: map[string]int{"": 7},
+		CheckDurations:      map[string]time.Duration{"latest-tag": time.Millisecond, "run-as-non-root": 2 * time.Millisecond},
+		LoadDurations:       map[string]time.Duration{lintcontext.LoaderHelm: time.Second},
+	}
+}
+
+func TestPrintStats(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, printStats(&out, newTestStats()))
+	assert.Equal(t, `Statistics:
+  Contexts:         1
+  Objects:          3
+  Invalid objects:  0
+  Run time:         12.346ms
+  Load time by loader:
+    helm  1s
+  Check time by check:
+    run-as-non-root  2ms
+    latest-tag       1ms
+  Findings by check:
+    run-as-non-root       3
+    latest-tag            2
+    no-read-only-root-fs  2
+  Findings by kind:
+    Pod  7
+  Findings by namespace:
+    <none>  7
+`, out.String())
+}
+
+func TestFormatSarifStats(t *testing.T) {
+	result := newFormatTestResult(t)
+	result.Checks = nil
+	result.Summary.Stats = newTestStats()
+	var out bytes.Buffer
+	require.NoError(t, formatLintSarif(&out, result))
+
+	var report struct {
+		Runs []struct {
+			Invocations []struct {
+				Properties struct {
+					Stats run.Stats
+				}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	require.Len(t, report.Runs, 1)
+	require.Len(t, report.Runs[0].Invocations, 1)
+	assert.Equal(t, *result.Summary.Stats, report.Runs[0].Invocations[0].Properties.Stats)
+}
@@ -0,0 +1,102 @@
+package lint
+
+import (
+	"bytes"
+	"embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"golang.stackrox.io/kube-linter/pkg/command/common"
+)
+
+// exampleTemplatePrefix is the prefix of the values of --template-file that refer to the example templates that ship
+// with KubeLinter, rather than to files.
+const exampleTemplatePrefix = "example:"
+
+var (
+	//go:embed templates
+	exampleTemplates embed.FS
+
+	templateFormatFuncs = template.FuncMap{
+		"colorBySeverity": colorBySeverity,
+		"csvRow":          csvRow,
+	}
+)
+
+// formatRequiresTemplateFile stands for common.TemplateFormat in the formatters, until it is replaced by the
+// formatter of the template given with --template-file.
+func formatRequiresTemplateFile(io.Writer, interface{}) error {
+	return errors.New("--format template requires --template-file")
+}
+
+// exampleTemplateNames returns the names of the example templates, which are their file names without the extension.
+func exampleTemplateNames() []string {
+	entries, err := exampleTemplates.ReadDir("templates")
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".tmpl"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loadTemplateFormatter returns a formatter that renders run.Result with the Go template in the given file, or with
+// the example template with the given name if it has the exampleTemplatePrefix.
+func loadTemplateFormatter(templateFile string) (common.FormatFunc, error) {
+	var content []byte
+	var err error
+	name := templateFile
+	if exampleName, ok := strings.CutPrefix(templateFile, exampleTemplatePrefix); ok {
+		// Do NOT use filepath.Join here, because embed always uses `/` as the separator.
+		content, err = exampleTemplates.ReadFile(path.Join("templates", exampleName+".tmpl"))
+		if err != nil {
+			return nil, fmt.Errorf("unknown example template %q: available templates are: %s", exampleName, strings.Join(exampleTemplateNames(), ", "))
+		}
+	} else {
+		content, err = os.ReadFile(filepath.Clean(templateFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template file: %w", err)
+		}
+		name = filepath.Base(templateFile)
+	}
+	// Errors of text/template start with "template: <name>:<line>:", so that they point to the faulty line.
+	tpl, err := common.InstantiateNamedPlainTemplate(name, string(content), templateFormatFuncs)
+	if err != nil {
+		return nil, err
+	}
+	return func(out io.Writer, data interface{}) error {
+		// Render to a buffer first, so that a failing template does not produce partial output.
+		var buf bytes.Buffer
+		if err := tpl.Execute(&buf, data); err != nil {
+			return err
+		}
+		_, err := buf.WriteTo(out)
+		return err
+	}, nil
+}
+
+// csvRow formats the given values as a row of a CSV file, without the final newline.
+func csvRow(values []interface{}) (string, error) {
+	record := make([]string, 0, len(values))
+	for _, value := range values {
+		record = append(record, fmt.Sprint(value))
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(record); err != nil {
+		return "", err
+	}
+	w.Flush()
+	return strings.TrimSuffix(buf.String(), "\n"), w.Error()
+}
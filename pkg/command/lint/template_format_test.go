@@ -0,0 +1,61 @@
+package lint
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExampleTemplates(t *testing.T) {
+	names := exampleTemplateNames()
+	assert.Equal(t, []string{"csv", "slack.md", "summary.html"}, names)
+
+	result := newFormatTestResult(t)
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			format, err := loadTemplateFormatter(exampleTemplatePrefix + name)
+			require.NoError(t, err)
+			var out bytes.Buffer
+			require.NoError(t, format(&out, result))
+			assert.Contains(t, out.String(), "image uses latest")
+		})
+	}
+
+	format, err := loadTemplateFormatter(exampleTemplatePrefix + "csv")
+	require.NoError(t, err)
+	var out bytes.Buffer
+	require.NoError(t, format(&out, result))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, "file,line,column,object,check,severity,message,field,remediation", lines[0])
+	assert.True(t, strings.HasSuffix(lines[1], `,8,5,"default/app /v1, Kind=Pod",latest-tag,error,image uses latest,spec.containers[0].image,`), lines[1])
+
+	_, err = loadTemplateFormatter(exampleTemplatePrefix + "nope")
+	assert.ErrorContains(t, err, "available templates are: csv, slack.md, summary.html")
+}
+
+func TestTemplateFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	_, err := loadTemplateFormatter(writeTemplate("parse.tmpl", "ok\n\n{{ .Reports | nosuchfunc }}\n"))
+	assert.ErrorContains(t, err, `parse.tmpl:3: function "nosuchfunc" not defined`)
+
+	format, err := loadTemplateFormatter(writeTemplate("exec.tmpl", "ok\n{{ range .Reports }}\n{{ .Nope }}{{ end }}\n"))
+	require.NoError(t, err)
+	var out bytes.Buffer
+	assert.ErrorContains(t, format(&out, newFormatTestResult(t)), "exec.tmpl:3:3")
+	assert.Empty(t, out.String(), "a failing template must not produce partial output")
+
+	_, err = loadTemplateFormatter(filepath.Join(dir, "missing.tmpl"))
+	assert.ErrorContains(t, err, "failed to read template file")
+}
@@ -8,8 +8,10 @@ import (
 	"github.com/spf13/cobra"
 	"golang.stackrox.io/kube-linter/pkg/command/checks"
 	"golang.stackrox.io/kube-linter/pkg/command/lint"
+	"golang.stackrox.io/kube-linter/pkg/command/suppressions"
 	"golang.stackrox.io/kube-linter/pkg/command/templates"
 	"golang.stackrox.io/kube-linter/pkg/command/version"
+	"golang.stackrox.io/kube-linter/pkg/command/webhook"
 )
 
 const (
@@ -32,8 +34,10 @@ func Command() *cobra.Command {
 	c.AddCommand(
 		checks.Command(),
 		lint.Command(),
+		suppressions.Command(),
 		templates.Command(),
 		version.Command(),
+		webhook.Command(),
 	)
 	c.PersistentFlags().Bool(colorFlag, true, "Force color output")
 	return c
@@ -0,0 +1,307 @@
+package suppressions
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.stackrox.io/kube-linter/internal/flagutil"
+	"golang.stackrox.io/kube-linter/internal/stringutils"
+	"golang.stackrox.io/kube-linter/pkg/command/common"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/configresolver"
+	"golang.stackrox.io/kube-linter/pkg/ignore"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/pathutil"
+)
+
+const (
+	// Sources of suppressions.
+	sourceAnnotation = "annotation"
+	sourceComment    = "comment"
+	sourceConfig     = "config"
+
+	plainTemplateStr = `{{ range $i, $_ := . }}
+{{- if $i}}
+------------------------------
+
+{{end -}}
+Checks: {{ join ", " .Checks }}
+Source: {{.Source}}{{with .Dir}}
+Directory: {{.}}{{end}}{{with .Object}}
+Object: {{.}}{{end}}{{if .File}}
+File: {{.File}}{{if .Line}}:{{.Line}}{{end}}{{end}}{{with .Variant}}
+Variant: {{.}}{{end}}
+Owner: {{ .Owner | default "none" }}
+Expires: {{.Until}}
+Ticket: {{ .Ticket | default "none" }}
+Reason: {{ .Reason | default "none" }}
+{{else}}No active suppressions found.
+{{end -}}
+`
+)
+
+var (
+	plainTemplate = common.MustInstantiatePlainTemplate(plainTemplateStr, nil)
+
+	formatters = common.Formatters{
+		Formatters: map[common.FormatType]common.FormatFunc{
+			common.PlainFormat: plainTemplate.Execute,
+			common.JSONFormat:  common.FormatJSON,
+		},
+	}
+)
+
+// A Suppression is a suppression that applies, along with where it is declared.
+type Suppression struct {
+	Checks []string
+	// Source is where the suppression is declared: in an annotation, a comment, or the config.
+	Source string
+	// Dir is the directory of the nested config that declares the suppression, if it is not the root config.
+	Dir string `json:",omitempty"`
+	// Object describes the objects that the suppression applies to.
+	Object  string
+	File    string
+	Line    int
+	Variant string
+	Owner   string
+	Ticket  string
+	Reason  string
+	// Until is the last day on which the suppression applies, or "never".
+	Until string
+}
+
+func listCommand() *cobra.Command {
+	var configPath string
+	format := flagutil.NewEnumFlag("Output format", formatters.GetEnabledFormatters(), common.PlainFormat)
+	c := &cobra.Command{
+		Use:   "list [paths...]",
+		Short: "List the suppressions that apply, from the config and the nested configs, and from the annotations and comments of the given files",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(viper.New(), configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			configSuppressions, err := configresolver.GetSuppressions(&cfg)
+			if err != nil {
+				return err
+			}
+			var objects []lintcontext.Object
+			var nested []NestedSuppressions
+			if len(args) > 0 {
+				if objects, err = loadObjects(cmd, &cfg, args); err != nil {
+					return err
+				}
+				if nested, err = nestedSuppressions(cfg, args); err != nil {
+					return err
+				}
+			}
+			formatFunc, err := formatters.FormatterByType(format.String())
+			if err != nil {
+				return err
+			}
+			return formatFunc(os.Stdout, List(objects, configSuppressions, nested, time.Now()))
+		},
+	}
+	c.Flags().StringVar(&configPath, "config", "", "Path to config file")
+	c.Flags().Var(format, "format", format.Usage())
+	return c
+}
+
+// Command defines the root of the suppressions command.
+func Command() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "suppressions",
+		Short: "Audit the suppressions of findings",
+	}
+	c.AddCommand(listCommand())
+	return c
+}
+
+// NestedSuppressions are the suppressions declared by the config file of a subdirectory (see config.LoadNested).
+// They apply to the objects of the files beneath the directory.
+type NestedSuppressions struct {
+	Dir          string
+	Suppressions []ignore.Suppression
+}
+
+// loadObjects loads the objects of the given paths, the way that the lint command does.
+func loadObjects(cmd *cobra.Command, cfg *config.Config, args []string) ([]lintcontext.Object, error) {
+	ignorePaths, err := configresolver.GetIgnorePaths(cfg)
+	if err != nil {
+		return nil, err
+	}
+	options, err := configresolver.GetContextOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	absArgs := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == lintcontext.ReadFromStdin {
+			absArgs = append(absArgs, lintcontext.ReadFromStdin)
+			continue
+		}
+		absArg, err := pathutil.GetAbsolutPath(arg)
+		if err != nil {
+			return nil, err
+		}
+		absArgs = append(absArgs, absArg)
+	}
+	lintCtxs, err := lintcontext.CreateContextsWithContext(cmd.Context(), options, ignorePaths, absArgs...)
+	if err != nil {
+		return nil, err
+	}
+	var objects []lintcontext.Object
+	for _, lintCtx := range lintCtxs {
+		objects = append(objects, lintCtx.Objects()...)
+	}
+	return objects, nil
+}
+
+// nestedSuppressions loads the config files of the subdirectories that apply to the given paths, the way that the
+// lint command does, and returns the suppressions that each of them declares, in the order of their directories.
+func nestedSuppressions(cfg config.Config, args []string) ([]NestedSuppressions, error) {
+	paths := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == lintcontext.ReadFromStdin {
+			continue
+		}
+		path, err := pathutil.GetAbsolutPath(arg)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	nested, err := config.LoadNested(cfg, ".", paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load nested config: %w", err)
+	}
+	result := make([]NestedSuppressions, 0, len(nested))
+	for _, n := range nested {
+		// The config of a nested config file is merged with the configs of its parent directories, whose
+		// suppressions come first. Only list the ones that the file itself declares.
+		parentCount := len(cfg.Suppressions)
+		parentDir := ""
+		for _, other := range nested {
+			if !other.Contains(n.Dir) || len(other.Dir) <= len(parentDir) {
+				continue
+			}
+			parentCount, parentDir = len(other.Config.Suppressions), other.Dir
+		}
+		own := n.Config
+		own.Suppressions = own.Suppressions[parentCount:]
+		suppressions, err := configresolver.GetSuppressions(&own)
+		if err != nil {
+			return nil, fmt.Errorf("config of %s: %w", n.Dir, err)
+		}
+		if len(suppressions) > 0 {
+			result = append(result, NestedSuppressions{Dir: n.Dir, Suppressions: suppressions})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Dir < result[j].Dir })
+	return result, nil
+}
+
+// List returns the suppressions that apply at the given time, declared in the config, in the nested configs, or by
+// the annotations and comments of the given objects. Suppressions that have expired, or whose justification is
+// invalid, are omitted.
+func List(objects []lintcontext.Object, configSuppressions []ignore.Suppression, nested []NestedSuppressions, now time.Time) []Suppression {
+	var suppressions []Suppression
+	addConfigSuppressions := func(configSuppressions []ignore.Suppression, dir string) {
+		for _, s := range configSuppressions {
+			if s.Expired(now) {
+				continue
+			}
+			suppression := newSuppression(s.Checks, sourceConfig, s.Justification)
+			suppression.Dir, suppression.Object = dir, describeSelector(s)
+			suppressions = append(suppressions, suppression)
+		}
+	}
+	addConfigSuppressions(configSuppressions, "")
+	for _, n := range nested {
+		addConfigSuppressions(n.Suppressions, n.Dir)
+	}
+
+	type documentKey struct {
+		filePath, variant string
+		lineOffset        int
+	}
+	parsedDocuments := make(map[documentKey]bool)
+	for _, obj := range objects {
+		objectName := obj.GetK8sObjectName().String()
+		annotations := obj.K8sObject.GetAnnotations()
+		keys := make([]string, 0, len(annotations))
+		for key := range annotations {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			checkName := key
+			if key == ignore.AllAnnotationKey {
+				checkName = "all checks"
+			} else if !stringutils.ConsumePrefix(&checkName, ignore.AnnotationKeyPrefix) {
+				continue
+			}
+			j, err := ignore.ParseJustification(annotations[key])
+			if err != nil || j.Expired(now) {
+				continue
+			}
+			s := newSuppression([]string{checkName}, sourceAnnotation, j)
+			s.Object, s.File, s.Variant = objectName, obj.Metadata.FilePath, obj.Metadata.Variant
+			suppressions = append(suppressions, s)
+		}
+
+		key := documentKey{filePath: obj.Metadata.FilePath, variant: obj.Metadata.Variant, lineOffset: obj.Metadata.LineOffset}
+		if parsedDocuments[key] {
+			continue
+		}
+		parsedDocuments[key] = true
+		for _, comment := range ignore.ParseComments(obj.Metadata.Raw) {
+			j, err := ignore.ParseJustification(comment.Reason)
+			if err != nil || j.Expired(now) {
+				continue
+			}
+			s := newSuppression(comment.Checks, sourceComment+" ("+string(comment.Scope)+")", j)
+			s.File, s.Variant = obj.Metadata.FilePath, obj.Metadata.Variant
+			s.Line, _ = obj.Metadata.Position(comment.Line, comment.Column)
+			if comment.Scope != ignore.ScopeFile {
+				s.Object = objectName
+			}
+			suppressions = append(suppressions, s)
+		}
+	}
+	return suppressions
+}
+
+func newSuppression(checks []string, source string, j ignore.Justification) Suppression {
+	return Suppression{
+		Checks: checks,
+		Source: source,
+		Owner:  j.Owner,
+		Ticket: j.Ticket,
+		Reason: j.Reason,
+		Until:  j.UntilString(),
+	}
+}
+
+// describeSelector describes the objects that a suppression of the config applies to.
+func describeSelector(s ignore.Suppression) string {
+	var parts []string
+	for _, part := range []struct{ name, value string }{
+		{name: "kind", value: s.Kind},
+		{name: "namespace", value: s.Namespace},
+		{name: "name", value: s.Name},
+	} {
+		if part.value != "" {
+			parts = append(parts, part.name+"="+part.value)
+		}
+	}
+	if len(parts) == 0 {
+		return "all objects"
+	}
+	return strings.Join(parts, ", ")
+}
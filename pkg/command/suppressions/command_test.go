@@ -0,0 +1,118 @@
+package suppressions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/ignore"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestList(t *testing.T) {
+	pod := func(lineOffset int, annotations map[string]string) lintcontext.Object {
+		return lintcontext.Object{
+			Metadata: lintcontext.ObjectMetadata{
+				FilePath:   "pods.yaml",
+				LineOffset: lineOffset,
+				Raw:        []byte("# kube-linter:ignore-file latest-tag -- until=2026-12-31; owner=sre\n# kube-linter:ignore-document sorted-keys -- until=2026-01-01\nkind: Pod\n"),
+			},
+			K8sObject: &v1.Pod{
+				TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				ObjectMeta: metaV1.ObjectMeta{Name: "agent", Namespace: "ops", Annotations: annotations},
+			},
+		}
+	}
+	objects := []lintcontext.Object{
+		pod(0, map[string]string{
+			"ignore-check.kube-linter.io/run-as-non-root":      "until=2026-09-30; reason=Legacy image; ticket=SEC-123; owner=platform",
+			"ignore-check.kube-linter.io/privileged-container": "until=2026-05-31; reason=Expired",
+			"kube-linter.io/ignore-all":                        "until=not-a-date",
+			"app.kubernetes.io/name":                           "agent",
+		}),
+		// A list item, which shares the comments of the first object.
+		pod(0, nil),
+	}
+	configSuppressions := []ignore.Suppression{
+		{Checks: []string{"unset-memory-requirements", "unset-cpu-requirements"}, Kind: "Deployment", Namespace: "ops", Justification: ignore.Justification{Reason: "Autoscaled"}},
+		{Checks: []string{"latest-tag"}, Justification: ignore.Justification{Until: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)}},
+	}
+	nested := []NestedSuppressions{
+		{Dir: "/repo/teams/payments", Suppressions: []ignore.Suppression{
+			{Checks: []string{"no-read-only-root-fs"}, Name: "ledger", Justification: ignore.Justification{Owner: "payments"}},
+			{Checks: []string{"latest-tag"}, Justification: ignore.Justification{Until: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)}},
+		}},
+	}
+
+	assert.Equal(t, []Suppression{
+		{
+			Checks: []string{"unset-memory-requirements", "unset-cpu-requirements"},
+			Source: "config",
+			Object: "kind=Deployment, namespace=ops",
+			Reason: "Autoscaled",
+			Until:  "never",
+		},
+		{
+			Checks: []string{"no-read-only-root-fs"},
+			Source: "config",
+			Dir:    "/repo/teams/payments",
+			Object: "name=ledger",
+			Owner:  "payments",
+			Until:  "never",
+		},
+		{
+			Checks: []string{"run-as-non-root"},
+			Source: "annotation",
+			Object: "ops/agent /v1, Kind=Pod",
+			File:   "pods.yaml",
+			Owner:  "platform",
+			Ticket: "SEC-123",
+			Reason: "Legacy image",
+			Until:  "2026-09-30",
+		},
+		{
+			Checks: []string{"latest-tag"},
+			Source: "comment (file)",
+			File:   "pods.yaml",
+			Line:   1,
+			Owner:  "sre",
+			Until:  "2026-12-31",
+		},
+	}, List(objects, configSuppressions, nested, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestNestedSuppressions(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"teams/.kube-linter.yaml":          "suppressions:\n  - checks: [latest-tag]\n    reason: Pinned by digest\n",
+		"teams/payments/.kube-linter.yaml": "suppressions:\n  - checks: [run-as-non-root]\n    name: ledger\n    owner: payments\n",
+		"teams/payments/deploy.yaml":       "kind: Deployment\n",
+		"teams/search/.kube-linter.yaml":   "checks:\n  include: [sorted-keys]\n",
+		"teams/search/deploy.yaml":         "kind: Deployment\n",
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	t.Chdir(dir)
+
+	root := config.Config{Suppressions: []config.SuppressionConfig{{Checks: []string{"sorted-keys"}}}}
+	nested, err := nestedSuppressions(root, []string{"teams", lintcontext.ReadFromStdin})
+	require.NoError(t, err)
+	// The suppressions of the parent directories and of the root config are only listed once, for the directory
+	// that declares them. Directories without suppressions are left out.
+	assert.Equal(t, []NestedSuppressions{
+		{Dir: filepath.Join(dir, "teams"), Suppressions: []ignore.Suppression{
+			{Checks: []string{"latest-tag"}, Justification: ignore.Justification{Reason: "Pinned by digest"}},
+		}},
+		{Dir: filepath.Join(dir, "teams", "payments"), Suppressions: []ignore.Suppression{
+			{Checks: []string{"run-as-non-root"}, Name: "ledger", Justification: ignore.Justification{Owner: "payments"}},
+		}},
+	}, nested)
+}
@@ -1,11 +1,16 @@
 package templates
 
 import (
+	"fmt"
 	"os"
+	"sort"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"golang.stackrox.io/kube-linter/internal/flagutil"
 	"golang.stackrox.io/kube-linter/pkg/command/common"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/configresolver"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 )
 
@@ -67,13 +72,25 @@ var (
 )
 
 func listCommand() *cobra.Command {
+	var configPath string
 	format := flagutil.NewEnumFlag("Output format", formatters.GetEnabledFormatters(), common.PlainFormat)
 	c := &cobra.Command{
 		Use:   "list",
-		Short: "List check templates",
+		Short: "List check templates, including the ones declared in the config",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			knownTemplates := templates.List()
+			cfg, err := config.Load(viper.New(), configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			externalTemplates, err := configresolver.GetTemplates(&cfg)
+			if err != nil {
+				return err
+			}
+			knownTemplates := append(templates.List(), externalTemplates...)
+			sort.Slice(knownTemplates, func(i, j int) bool {
+				return knownTemplates[i].Key < knownTemplates[j].Key
+			})
 			formatFunc, err := formatters.FormatterByType(format.String())
 			if err != nil {
 				return err
@@ -81,6 +98,7 @@ func listCommand() *cobra.Command {
 			return formatFunc(os.Stdout, knownTemplates)
 		},
 	}
+	c.Flags().StringVar(&configPath, "config", "", "Path to config file")
 	c.Flags().Var(format, "format", format.Usage())
 	return c
 }
@@ -0,0 +1,107 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.stackrox.io/kube-linter/internal/flagutil"
+	"golang.stackrox.io/kube-linter/pkg/admission"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/linter"
+)
+
+const (
+	validatePath = "/validate"
+	healthzPath  = "/healthz"
+
+	shutdownTimeout = 10 * time.Second
+)
+
+// Command is the command for serving a validating admission webhook.
+func Command() *cobra.Command {
+	var configPath string
+	var address string
+	var certFile string
+	var keyFile string
+	var warnOnly bool
+	var reviewTimeout time.Duration
+	failOn := flagutil.NewEnumFlag("Minimum severity of findings that cause a request to be denied. Less severe findings are returned as warnings", config.AllSeverities(), string(config.DefaultSeverity))
+
+	v := viper.New()
+
+	c := &cobra.Command{
+		Use:   "serve-webhook",
+		Args:  cobra.NoArgs,
+		Short: "Serve a validating admission webhook that lints objects as they are admitted to a cluster",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if certFile == "" || keyFile == "" {
+				return errors.New("--tls-cert-file and --tls-key-file are required")
+			}
+
+			cfg, err := config.Load(v, configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			// Objects are admitted one at a time, so checks that look up other objects would always report them
+			// as missing.
+			l, err := linter.New(linter.WithConfig(cfg), linter.WithoutCrossObjectChecks())
+			if err != nil {
+				return err
+			}
+			if len(l.Checks()) == 0 {
+				fmt.Fprintln(os.Stderr, "Warning: no checks enabled.")
+			}
+
+			mux := http.NewServeMux()
+			mux.Handle(validatePath, admission.NewHandler(l, admission.Options{
+				FailOn:   config.Severity(failOn.String()),
+				WarnOnly: warnOnly,
+				Timeout:  reviewTimeout,
+			}))
+			mux.HandleFunc(healthzPath, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			server := &http.Server{
+				Addr:              address,
+				Handler:           mux,
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			serveErr := make(chan error, 1)
+			go func() {
+				serveErr <- server.ListenAndServeTLS(certFile, keyFile)
+			}()
+			fmt.Fprintf(os.Stderr, "Serving admission webhook on %s%s with %d check(s)\n", address, validatePath, len(l.Checks()))
+
+			select {
+			case err := <-serveErr:
+				return err
+			case <-ctx.Done():
+			}
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+			return server.Shutdown(shutdownCtx)
+		},
+	}
+
+	c.Flags().StringVar(&configPath, "config", "", "Path to config file")
+	c.Flags().StringVar(&address, "address", ":8443", "Address to listen on")
+	c.Flags().StringVar(&certFile, "tls-cert-file", "", "Path to the TLS certificate to serve")
+	c.Flags().StringVar(&keyFile, "tls-key-file", "", "Path to the private key of the TLS certificate")
+	c.Flags().Var(failOn, "fail-on", failOn.Usage())
+	c.Flags().BoolVar(&warnOnly, "warn-only", false, "Never deny requests, and return all findings as warnings")
+	c.Flags().DurationVar(&reviewTimeout, "review-timeout", 8*time.Second, "Maximum time spent linting the object of a request, after which it is allowed with a warning. It should be less than the timeoutSeconds of the webhook configuration")
+
+	config.AddFlags(c, v)
+	return c
+}
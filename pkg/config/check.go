@@ -8,6 +8,8 @@ type Check struct {
 	Scope       *ObjectKindsDesc       `json:"scope"`
 	Template    string                 `json:"template"`
 	Params      map[string]interface{} `json:"params,omitempty"`
+	// Severity is the severity of the findings reported by the check. If unset, DefaultSeverity is used.
+	Severity Severity `json:"severity,omitempty"`
 }
 
 // ObjectKindsDesc describes a list of supported object kinds for a check template.
@@ -22,6 +22,10 @@ type ChecksConfig struct {
 	// Exclude is a list of check names to exclude.
 	// +flagName=exclude
 	Exclude []string `json:"exclude"`
+	// Exclusions exclude checks for the objects that match their scope only, unlike Exclude, which excludes checks
+	// for all objects.
+	// +flagName=-
+	Exclusions []ExclusionConfig `json:"exclusions"`
 	// Include is a list of check names to include. If a check is in both Include and Exclude,
 	// Exclude wins.
 	// +flagName=include
@@ -29,13 +33,97 @@ type ChecksConfig struct {
 	// IgnorePaths is a list of path to ignore from applying checks
 	// +flagName=ignore-paths
 	IgnorePaths []string `json:"ignorePaths"`
+	// SeverityOverrides maps check names to the severity that their findings should be reported with,
+	// overriding the severity defined by the check.
+	// +flagName=-
+	SeverityOverrides map[string]Severity `json:"severityOverrides"`
+	// Timeout is the longest that a check can take on a single object (e.g. 30s), after which it is reported
+	// with a check-timeout finding and the run continues. Checks do not time out if it is empty.
+	// +flagName=check-timeout
+	Timeout string `json:"timeout"`
+}
+
+// ExclusionConfig excludes checks for the objects that match all of its scope. The namespace and labels are
+// matched with the regex semantics of matcher.ForString.
+type ExclusionConfig struct {
+	// Checks are the names of the checks to exclude.
+	Checks []string `json:"checks"`
+	// Namespace matches the namespace of objects. It matches all objects if it is empty.
+	Namespace string `json:"namespace"`
+	// Labels maps matchers of label keys to matchers of their values. Objects must have a matching label for
+	// each entry.
+	Labels map[string]string `json:"labels"`
+	// Paths are glob patterns (which support **) of the files and directories that objects must be loaded from.
+	// A pattern that matches a directory matches all the files in it. They match all objects if they are empty.
+	Paths []string `json:"paths"`
+}
+
+// HelmConfig is the config that determines how Helm charts are rendered.
+type HelmConfig struct {
+	// ValuesFiles is a list of values files to merge, in order, over the values.yaml of each chart.
+	// Relative paths are resolved against the chart directory.
+	// +flagName=helm-values
+	ValuesFiles []string `json:"valuesFiles"`
+	// Set is a list of values to set on the command line (e.g. key1=val1), applied after the values files.
+	// +flagName=helm-set
+	Set []string `json:"set"`
+	// ReleaseName is the name of the release that charts are rendered as.
+	// +flagName=helm-release-name
+	ReleaseName string `json:"releaseName"`
+	// Namespace is the namespace that charts are rendered in.
+	// +flagName=helm-namespace
+	Namespace string `json:"namespace"`
+	// KubeVersion is the Kubernetes version used for Capabilities.KubeVersion when rendering charts.
+	// +flagName=helm-kube-version
+	KubeVersion string `json:"kubeVersion"`
+	// APIVersions is a list of additional Kubernetes API versions used for Capabilities.APIVersions when rendering charts.
+	// +flagName=helm-api-versions
+	APIVersions []string `json:"apiVersions"`
+	// Variants renders each chart once per entry, with the settings of the entry applied over the ones above.
+	// Findings are tagged with the name of the variant that they were found in.
+	// +flagName=-
+	Variants map[string]HelmConfig `json:"variants"`
+	// Charts overrides the settings above for the charts at the given paths.
+	// Values files and set values are applied after the global ones.
+	// +flagName=-
+	Charts map[string]HelmConfig `json:"charts"`
+}
+
+// ContextGroupConfig is a named set of paths whose objects are linted together.
+type ContextGroupConfig struct {
+	// Name is the name of the group.
+	Name string `json:"name"`
+	// Paths is a list of glob patterns of the files and directories in the group.
+	Paths []string `json:"paths"`
+}
+
+// ContextsConfig is the config that determines which objects are linted together, which matters for checks that
+// relate objects to each other. By default, the objects of each directory are linted together.
+type ContextsConfig struct {
+	// Single, if set, lints all the objects of an invocation together, regardless of their directories.
+	// +flagName=single-context
+	Single bool `json:"single"`
+	// Groups lints the objects of the files that match the paths of each group together.
+	// Files are added to the first group that they match.
+	// +flagName=-
+	Groups []ContextGroupConfig `json:"groups"`
 }
 
 // Config represents the config file format.
 type Config struct {
+	// Extends is a list of paths of configs that this config is applied on top of (see Merge), in order. Relative
+	// paths are resolved against the directory of this config.
+	// +flagName=-
+	Extends []string `json:"extends,omitempty"`
 	// +flagName=-
-	CustomChecks []Check      `json:"customChecks,omitempty"`
-	Checks       ChecksConfig `json:"checks,omitempty"`
+	CustomChecks []Check `json:"customChecks,omitempty"`
+	// +flagName=-
+	Templates []TemplateConfig `json:"templates,omitempty"`
+	// +flagName=-
+	Suppressions []SuppressionConfig `json:"suppressions,omitempty"`
+	Checks       ChecksConfig        `json:"checks,omitempty"`
+	Helm         HelmConfig          `json:"helm,omitempty"`
+	Contexts     ContextsConfig      `json:"contexts,omitempty"`
 }
 
 // Defines the list of default config filenames to check if parameter isn't passed in
@@ -50,15 +138,11 @@ func fileExists(filename string) bool {
 	return !info.IsDir()
 }
 
-// Load loads the config from the given path.
+// Load loads the config from the given path. If the config extends other configs, it is merged on top of them
+// (see Merge).
 func Load(v *viper.Viper, configPath string) (Config, error) {
 	if configPath == "" {
-		for _, p := range defaultConfigFilenames {
-			if fileExists(p) {
-				configPath = p
-				break
-			}
-		}
+		configPath = findConfigFile(".")
 	}
 
 	if configPath != "" {
@@ -73,6 +157,74 @@ func Load(v *viper.Viper, configPath string) (Config, error) {
 		}
 	}
 
+	conf, err := unmarshal(v)
+	if err != nil {
+		return Config{}, err
+	}
+	if configPath == "" || len(conf.Extends) == 0 {
+		return conf, nil
+	}
+	return resolveExtends(conf, configPath, nil)
+}
+
+// findConfigFile returns the path of the default config file in the given directory, or "" if there is none.
+func findConfigFile(dir string) string {
+	for _, name := range defaultConfigFilenames {
+		if p := filepath.Join(dir, name); fileExists(p) {
+			return p
+		}
+	}
+	return ""
+}
+
+// loadFile loads the config file at the given path, without the flags that Load takes into account.
+func loadFile(path string, extendedBy []string) (Config, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return Config{}, fmt.Errorf("reading file %s: %w", path, err)
+	}
+	conf, err := unmarshal(v)
+	if err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return resolveExtends(conf, path, extendedBy)
+}
+
+// resolveExtends merges the config at the given path on top of the configs that it extends. extendedBy holds the
+// absolute paths of the configs that extend it, directly or not, to detect cycles.
+func resolveExtends(conf Config, path string, extendedBy []string) (Config, error) {
+	if len(conf.Extends) == 0 {
+		return conf, nil
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return Config{}, err
+	}
+	extendedBy = append(extendedBy, absPath)
+	var base Config
+	for _, extendsPath := range conf.Extends {
+		if extendsPath == "" {
+			return Config{}, fmt.Errorf("%s: extends paths cannot be empty", path)
+		}
+		if !filepath.IsAbs(extendsPath) {
+			extendsPath = filepath.Join(filepath.Dir(absPath), extendsPath)
+		}
+		for _, p := range extendedBy {
+			if p == extendsPath {
+				return Config{}, fmt.Errorf("%s: cycle in extends: %s extends itself", path, extendsPath)
+			}
+		}
+		extended, err := loadFile(extendsPath, extendedBy)
+		if err != nil {
+			return Config{}, err
+		}
+		base = Merge(base, extended)
+	}
+	return Merge(base, conf), nil
+}
+
+func unmarshal(v *viper.Viper) (Config, error) {
 	var conf Config
 	err := v.Unmarshal(&conf, func(config *mapstructure.DecoderConfig) {
 		config.TagName = "json"
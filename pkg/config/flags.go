@@ -31,4 +31,36 @@ func AddFlags(c *cobra.Command, v *viper.Viper) {
 	if err := v.BindPFlag("checks.ignorePaths", c.Flags().Lookup("ignore-paths")); err != nil {
 		panic(err)
 	}
+	c.Flags().String("check-timeout", "", "Timeout is the longest that a check can take on a single object (e.g. 30s), after which it is reported with a check-timeout finding and the run continues. Checks do not time out if it is empty.")
+	if err := v.BindPFlag("checks.timeout", c.Flags().Lookup("check-timeout")); err != nil {
+		panic(err)
+	}
+	c.Flags().StringSlice("helm-values", nil, "ValuesFiles is a list of values files to merge, in order, over the values.yaml of each chart. Relative paths are resolved against the chart directory.")
+	if err := v.BindPFlag("helm.valuesFiles", c.Flags().Lookup("helm-values")); err != nil {
+		panic(err)
+	}
+	c.Flags().StringSlice("helm-set", nil, "Set is a list of values to set on the command line (e.g. key1=val1), applied after the values files.")
+	if err := v.BindPFlag("helm.set", c.Flags().Lookup("helm-set")); err != nil {
+		panic(err)
+	}
+	c.Flags().String("helm-release-name", "", "ReleaseName is the name of the release that charts are rendered as.")
+	if err := v.BindPFlag("helm.releaseName", c.Flags().Lookup("helm-release-name")); err != nil {
+		panic(err)
+	}
+	c.Flags().String("helm-namespace", "", "Namespace is the namespace that charts are rendered in.")
+	if err := v.BindPFlag("helm.namespace", c.Flags().Lookup("helm-namespace")); err != nil {
+		panic(err)
+	}
+	c.Flags().String("helm-kube-version", "", "KubeVersion is the Kubernetes version used for Capabilities.KubeVersion when rendering charts.")
+	if err := v.BindPFlag("helm.kubeVersion", c.Flags().Lookup("helm-kube-version")); err != nil {
+		panic(err)
+	}
+	c.Flags().StringSlice("helm-api-versions", nil, "APIVersions is a list of additional Kubernetes API versions used for Capabilities.APIVersions when rendering charts.")
+	if err := v.BindPFlag("helm.apiVersions", c.Flags().Lookup("helm-api-versions")); err != nil {
+		panic(err)
+	}
+	c.Flags().Bool("single-context", false, "Single, if set, lints all the objects of an invocation together, regardless of their directories.")
+	if err := v.BindPFlag("contexts.single", c.Flags().Lookup("single-context")); err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"maps"
+	"slices"
+)
+
+// Merge returns the config that results from applying override on top of base, which is how a config that extends
+// base, or that is nested beneath the directory of base, is resolved:
+//
+//   - A check that override includes is removed from the exclusions of base, and the other way around, so that
+//     override can re-enable a check that base excludes.
+//   - Custom checks of override that have the name of a custom check of base replace its fields that they set, and
+//     their params are merged with its params. The other custom checks are added.
+//   - Lists are appended, maps are merged, and the other settings of override replace the ones of base if they
+//     are set (or, for booleans, if they are true).
+func Merge(base, override Config) Config {
+	merged := base
+	merged.Extends = nil
+	merged.CustomChecks = mergeCustomChecks(base.CustomChecks, override.CustomChecks)
+	merged.Templates = mergeTemplates(base.Templates, override.Templates)
+	merged.Suppressions = slices.Concat(base.Suppressions, override.Suppressions)
+	merged.Checks = mergeChecksConfig(base.Checks, override.Checks)
+	merged.Helm = mergeHelmConfig(base.Helm, override.Helm)
+	merged.Contexts = ContextsConfig{
+		Single: base.Contexts.Single || override.Contexts.Single,
+		Groups: slices.Concat(base.Contexts.Groups, override.Contexts.Groups),
+	}
+	return merged
+}
+
+func mergeChecksConfig(base, override ChecksConfig) ChecksConfig {
+	merged := ChecksConfig{
+		AddAllBuiltIn:        base.AddAllBuiltIn || override.AddAllBuiltIn,
+		DoNotAutoAddDefaults: base.DoNotAutoAddDefaults || override.DoNotAutoAddDefaults,
+		Include:              appendUnique(without(base.Include, override.Exclude), override.Include),
+		Exclude:              appendUnique(without(base.Exclude, override.Include), override.Exclude),
+		Exclusions:           slices.Concat(base.Exclusions, override.Exclusions),
+		IgnorePaths:          appendUnique(base.IgnorePaths, override.IgnorePaths),
+		SeverityOverrides:    mergeSeverities(base.SeverityOverrides, override.SeverityOverrides),
+		Timeout:              base.Timeout,
+	}
+	if override.Timeout != "" {
+		merged.Timeout = override.Timeout
+	}
+	return merged
+}
+
+func mergeCustomChecks(base, override []Check) []Check {
+	merged := slices.Clone(base)
+	indices := make(map[string]int, len(base))
+	for i, check := range merged {
+		indices[check.Name] = i
+	}
+	for _, check := range override {
+		i, found := indices[check.Name]
+		if !found {
+			indices[check.Name] = len(merged)
+			merged = append(merged, check)
+			continue
+		}
+		baseCheck := &merged[i]
+		if check.Description != "" {
+			baseCheck.Description = check.Description
+		}
+		if check.Remediation != "" {
+			baseCheck.Remediation = check.Remediation
+		}
+		if check.Scope != nil {
+			baseCheck.Scope = check.Scope
+		}
+		if check.Template != "" {
+			baseCheck.Template = check.Template
+		}
+		if check.Severity != "" {
+			baseCheck.Severity = check.Severity
+		}
+		baseCheck.Params = mergeParams(baseCheck.Params, check.Params)
+	}
+	return merged
+}
+
+func mergeTemplates(base, override []TemplateConfig) []TemplateConfig {
+	merged := slices.Clone(base)
+	for _, template := range override {
+		replaced := false
+		for i := range merged {
+			if merged[i].Key == template.Key {
+				merged[i] = template
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, template)
+		}
+	}
+	return merged
+}
+
+func mergeHelmConfig(base, override HelmConfig) HelmConfig {
+	merged := HelmConfig{
+		ValuesFiles: slices.Concat(base.ValuesFiles, override.ValuesFiles),
+		Set:         slices.Concat(base.Set, override.Set),
+		ReleaseName: base.ReleaseName,
+		Namespace:   base.Namespace,
+		KubeVersion: base.KubeVersion,
+		APIVersions: appendUnique(base.APIVersions, override.APIVersions),
+		Variants:    mergeHelmConfigs(base.Variants, override.Variants),
+		Charts:      mergeHelmConfigs(base.Charts, override.Charts),
+	}
+	if override.ReleaseName != "" {
+		merged.ReleaseName = override.ReleaseName
+	}
+	if override.Namespace != "" {
+		merged.Namespace = override.Namespace
+	}
+	if override.KubeVersion != "" {
+		merged.KubeVersion = override.KubeVersion
+	}
+	return merged
+}
+
+// appendUnique appends the elements of override that are not already present to a copy of base.
+func appendUnique(base, override []string) []string {
+	merged := slices.Clone(base)
+	for _, value := range override {
+		if !slices.Contains(merged, value) {
+			merged = append(merged, value)
+		}
+	}
+	return merged
+}
+
+// without returns the elements of values that are not in removed.
+func without(values, removed []string) []string {
+	var remaining []string
+	for _, value := range values {
+		if !slices.Contains(removed, value) {
+			remaining = append(remaining, value)
+		}
+	}
+	return remaining
+}
+
+func mergeSeverities(base, override map[string]Severity) map[string]Severity {
+	if len(override) == 0 {
+		return base
+	}
+	merged := maps.Clone(base)
+	if merged == nil {
+		merged = make(map[string]Severity, len(override))
+	}
+	maps.Copy(merged, override)
+	return merged
+}
+
+func mergeParams(base, override map[string]interface{}) map[string]interface{} {
+	if len(override) == 0 {
+		return base
+	}
+	merged := maps.Clone(base)
+	if merged == nil {
+		merged = make(map[string]interface{}, len(override))
+	}
+	maps.Copy(merged, override)
+	return merged
+}
+
+func mergeHelmConfigs(base, override map[string]HelmConfig) map[string]HelmConfig {
+	if len(override) == 0 {
+		return base
+	}
+	merged := maps.Clone(base)
+	if merged == nil {
+		merged = make(map[string]HelmConfig, len(override))
+	}
+	maps.Copy(merged, override)
+	return merged
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	base := Config{
+		CustomChecks: []Check{
+			{Name: "team-label", Template: "required-label", Remediation: "Add a team label.", Params: map[string]interface{}{"key": "team", "value": ".+"}},
+			{Name: "owner-annotation", Template: "required-annotation", Params: map[string]interface{}{"key": "owner"}},
+		},
+		Suppressions: []SuppressionConfig{{Checks: []string{"latest-tag"}}},
+		Checks: ChecksConfig{
+			DoNotAutoAddDefaults: true,
+			Include:              []string{"privileged-container", "latest-tag", "team-label"},
+			Exclude:              []string{"run-as-non-root", "no-anti-affinity"},
+			SeverityOverrides:    map[string]Severity{"latest-tag": SeverityWarning},
+			Timeout:              "10s",
+		},
+		Helm: HelmConfig{Namespace: "default", ValuesFiles: []string{"values-base.yaml"}},
+	}
+	override := Config{
+		Extends: []string{"base.yaml"},
+		CustomChecks: []Check{
+			{Name: "team-label", Params: map[string]interface{}{"key": "owner"}},
+			{Name: "app-label", Template: "required-label", Params: map[string]interface{}{"key": "app"}},
+		},
+		Suppressions: []SuppressionConfig{{Checks: []string{"sorted-keys"}}},
+		Checks: ChecksConfig{
+			Include:           []string{"run-as-non-root"},
+			Exclude:           []string{"latest-tag"},
+			SeverityOverrides: map[string]Severity{"privileged-container": SeverityInfo},
+		},
+		Helm: HelmConfig{ReleaseName: "payments", ValuesFiles: []string{"values-payments.yaml"}},
+	}
+
+	merged := Merge(base, override)
+	assert.Nil(t, merged.Extends)
+	assert.Equal(t, []Check{
+		{Name: "team-label", Template: "required-label", Remediation: "Add a team label.", Params: map[string]interface{}{"key": "owner", "value": ".+"}},
+		{Name: "owner-annotation", Template: "required-annotation", Params: map[string]interface{}{"key": "owner"}},
+		{Name: "app-label", Template: "required-label", Params: map[string]interface{}{"key": "app"}},
+	}, merged.CustomChecks)
+	assert.Equal(t, []SuppressionConfig{{Checks: []string{"latest-tag"}}, {Checks: []string{"sorted-keys"}}}, merged.Suppressions)
+	assert.Equal(t, ChecksConfig{
+		DoNotAutoAddDefaults: true,
+		Include:              []string{"privileged-container", "team-label", "run-as-non-root"},
+		Exclude:              []string{"no-anti-affinity", "latest-tag"},
+		SeverityOverrides:    map[string]Severity{"latest-tag": SeverityWarning, "privileged-container": SeverityInfo},
+		Timeout:              "10s",
+	}, merged.Checks)
+	assert.Equal(t, HelmConfig{
+		Namespace:   "default",
+		ReleaseName: "payments",
+		ValuesFiles: []string{"values-base.yaml", "values-payments.yaml"},
+	}, merged.Helm)
+
+	// The configs that are merged are not modified.
+	assert.Equal(t, map[string]interface{}{"key": "team", "value": ".+"}, base.CustomChecks[0].Params)
+	assert.Len(t, base.Checks.Include, 3)
+}
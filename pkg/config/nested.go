@@ -0,0 +1,103 @@
+package config
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// A NestedConfig is a config file in a subdirectory, which applies to the files beneath it.
+type NestedConfig struct {
+	// Dir is the absolute path of the directory of the config file.
+	Dir string
+	// Config is the config that applies to the files beneath Dir: the root config, merged with the nested configs
+	// of the parent directories of Dir, and then with the config file of Dir (see Merge).
+	Config Config
+}
+
+// Contains returns whether the given absolute path is beneath the directory of the nested config.
+func (n NestedConfig) Contains(path string) bool {
+	return strings.HasPrefix(path, n.Dir+string(filepath.Separator))
+}
+
+// LoadNested loads the config files, named like the default config files, that apply to the given absolute paths
+// of files and directories: the ones in the directories beneath them, and the ones in their parent directories
+// beneath baseDir, which is the directory whose config file is the root config. The config files of baseDir
+// itself are not nested configs. The nested configs are returned deepest first, so the first one that contains a
+// file is the one that applies to it.
+func LoadNested(root Config, baseDir string, paths []string) ([]NestedConfig, error) {
+	baseDir, err := filepath.Abs(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]string)
+	addDir := func(dir string) {
+		if dir == baseDir {
+			return
+		}
+		if _, found := files[dir]; found {
+			return
+		}
+		if path := findConfigFile(dir); path != "" {
+			files[dir] = path
+		}
+	}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			// Paths that cannot be read, such as "-" for the standard input, have no nested configs.
+			continue
+		}
+		dir := path
+		if !info.IsDir() {
+			dir = filepath.Dir(path)
+		}
+		for parent := filepath.Dir(dir); strings.HasPrefix(parent, baseDir+string(filepath.Separator)); parent = filepath.Dir(parent) {
+			addDir(parent)
+		}
+		if !info.IsDir() {
+			addDir(dir)
+			continue
+		}
+		err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				addDir(path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dirs := make([]string, 0, len(files))
+	for dir := range files {
+		dirs = append(dirs, dir)
+	}
+	// Parent directories sort before their subdirectories, so that their configs are merged first.
+	sort.Strings(dirs)
+	nested := make([]NestedConfig, 0, len(dirs))
+	for _, dir := range dirs {
+		conf, err := loadFile(files[dir], nil)
+		if err != nil {
+			return nil, err
+		}
+		parent := root
+		for i := len(nested) - 1; i >= 0; i-- {
+			if nested[i].Contains(dir) {
+				parent = nested[i].Config
+				break
+			}
+		}
+		nested = append(nested, NestedConfig{Dir: dir, Config: Merge(parent, conf)})
+	}
+	sort.SliceStable(nested, func(i, j int) bool {
+		return strings.Count(nested[i].Dir, string(filepath.Separator)) > strings.Count(nested[j].Dir, string(filepath.Separator))
+	})
+	return nested, nil
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+}
+
+func TestLoadExtends(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"shared/security.yaml": "extends: [defaults.yaml]\nchecks:\n  include: [privileged-container]\n",
+		"shared/defaults.yaml": "checks:\n  doNotAutoAddDefaults: true\n  include: [latest-tag]\n  exclude: [run-as-non-root]\n",
+		"team/config.yaml":     "extends: [../shared/security.yaml]\nchecks:\n  include: [run-as-non-root]\n",
+		"cycle/a.yaml":         "extends: [b.yaml]\n",
+		"cycle/b.yaml":         "extends: [a.yaml]\n",
+	})
+
+	cfg, err := Load(viper.New(), filepath.Join(dir, "team", "config.yaml"))
+	require.NoError(t, err)
+	assert.Nil(t, cfg.Extends)
+	assert.True(t, cfg.Checks.DoNotAutoAddDefaults)
+	assert.Equal(t, []string{"latest-tag", "privileged-container", "run-as-non-root"}, cfg.Checks.Include)
+	assert.Empty(t, cfg.Checks.Exclude)
+
+	_, err = Load(viper.New(), filepath.Join(dir, "cycle", "a.yaml"))
+	assert.ErrorContains(t, err, "cycle in extends")
+
+	writeFiles(t, dir, map[string]string{"missing.yaml": "extends: [nowhere.yaml]\n"})
+	_, err = Load(viper.New(), filepath.Join(dir, "missing.yaml"))
+	assert.ErrorContains(t, err, "nowhere.yaml")
+}
+
+func TestLoadNested(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".kube-linter.yaml":                    "checks:\n  include: [ignored-because-root]\n",
+		"teams/.kube-linter.yaml":              "checks:\n  include: [latest-tag]\n",
+		"teams/payments/.kube-linter.yml":      "checks:\n  exclude: [latest-tag]\n  include: [run-as-non-root]\n",
+		"teams/payments/app/deploy.yaml":       "kind: Deployment\n",
+		"teams/search/deploy.yaml":             "kind: Deployment\n",
+		"teams/search-infra/.kube-linter.yaml": "checks:\n  include: [sorted-keys]\n",
+		"other/.kube-linter.yaml":              "checks:\n  include: [not-linted]\n",
+	})
+	root := Config{Checks: ChecksConfig{Include: []string{"privileged-container"}}}
+
+	// The config of the parent directory of a linted path applies, like the ones beneath it.
+	nested, err := LoadNested(root, dir, []string{filepath.Join(dir, "teams", "payments"), filepath.Join(dir, "teams", "search", "deploy.yaml"), filepath.Join(dir, "teams", "search-infra")})
+	require.NoError(t, err)
+	require.Len(t, nested, 3)
+
+	assert.Equal(t, filepath.Join(dir, "teams", "payments"), nested[0].Dir)
+	assert.Equal(t, []string{"privileged-container", "run-as-non-root"}, nested[0].Config.Checks.Include)
+	assert.Equal(t, []string{"latest-tag"}, nested[0].Config.Checks.Exclude)
+	assert.True(t, nested[0].Contains(filepath.Join(dir, "teams", "payments", "app", "deploy.yaml")))
+
+	assert.Equal(t, filepath.Join(dir, "teams", "search-infra"), nested[1].Dir)
+	assert.Equal(t, []string{"privileged-container", "latest-tag", "sorted-keys"}, nested[1].Config.Checks.Include)
+
+	assert.Equal(t, filepath.Join(dir, "teams"), nested[2].Dir)
+	assert.Equal(t, []string{"privileged-container", "latest-tag"}, nested[2].Config.Checks.Include)
+	assert.True(t, nested[2].Contains(filepath.Join(dir, "teams", "search", "deploy.yaml")))
+	assert.False(t, nested[2].Contains(filepath.Join(dir, "teams")))
+
+	nested, err = LoadNested(root, dir, []string{dir + "/-"})
+	require.NoError(t, err)
+	assert.Empty(t, nested)
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity is the severity of the findings reported by a check.
+type Severity string
+
+const (
+	// SeverityError is for findings that must be fixed.
+	SeverityError Severity = "error"
+	// SeverityWarning is for findings that should be fixed, but are not enforced yet.
+	SeverityWarning Severity = "warning"
+	// SeverityInfo is for informational findings.
+	SeverityInfo Severity = "info"
+
+	// DefaultSeverity is the severity of checks that do not specify one.
+	DefaultSeverity = SeverityError
+)
+
+var severityRanks = map[Severity]int{
+	SeverityInfo:    0,
+	SeverityWarning: 1,
+	SeverityError:   2,
+}
+
+// AllSeverities lists all valid severities, from the most to the least severe.
+func AllSeverities() []string {
+	return []string{string(SeverityError), string(SeverityWarning), string(SeverityInfo)}
+}
+
+// ParseSeverity parses the given string into a Severity. The empty string is parsed as DefaultSeverity.
+func ParseSeverity(s string) (Severity, error) {
+	if s == "" {
+		return DefaultSeverity, nil
+	}
+	severity := Severity(strings.ToLower(s))
+	if _, ok := severityRanks[severity]; !ok {
+		return "", fmt.Errorf("invalid severity %q: allowed values are: %s", s, strings.Join(AllSeverities(), ", "))
+	}
+	return severity, nil
+}
+
+// OrDefault returns the severity, or DefaultSeverity if it is not set.
+func (s Severity) OrDefault() Severity {
+	if s == "" {
+		return DefaultSeverity
+	}
+	return s
+}
+
+// AtLeast returns whether the severity is at least as severe as the given threshold.
+func (s Severity) AtLeast(threshold Severity) bool {
+	return severityRanks[s.OrDefault()] >= severityRanks[threshold.OrDefault()]
+}
@@ -0,0 +1,21 @@
+package config
+
+// A SuppressionConfig ignores the findings of some checks for the objects that it matches, like an ignore-check
+// annotation does, for objects that cannot be annotated.
+type SuppressionConfig struct {
+	// Checks are the names of the checks whose findings are suppressed.
+	Checks []string `json:"checks"`
+	// Kind, Namespace and Name select the objects that the suppression applies to. Empty values match all objects.
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Until is the last day on which the suppression applies, in the format YYYY-MM-DD. After it, the suppression
+	// is reported as expired. If it is empty, the suppression does not expire.
+	Until string `json:"until"`
+	// Reason explains why the findings are suppressed.
+	Reason string `json:"reason"`
+	// Ticket tracks the work that will remove the need for the suppression.
+	Ticket string `json:"ticket"`
+	// Owner is the person or team that is responsible for the suppression.
+	Owner string `json:"owner"`
+}
@@ -0,0 +1,41 @@
+package config
+
+// A TemplateConfig declares a template that is implemented by an external program, so that custom checks can use
+// it like a built-in template.
+type TemplateConfig struct {
+	// Key is the name that custom checks refer to the template by.
+	Key string `json:"key"`
+	// Name is a human-friendly name for the template, used in documentation.
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// SupportedObjectKinds are the kinds of objects that checks of the template run on, unless they set a scope.
+	SupportedObjectKinds ObjectKindsDesc `json:"supportedObjectKinds"`
+	// Parameters describes the params that checks of the template can set.
+	Parameters []TemplateParameterConfig `json:"parameters"`
+	// Exec is the program that implements the template.
+	Exec ExecTemplateConfig `json:"exec"`
+}
+
+// A TemplateParameterConfig describes a parameter of a template. Its fields have the same meaning as the ones of
+// check.ParameterDesc.
+type TemplateParameterConfig struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Description string   `json:"description"`
+	Required    bool     `json:"required"`
+	Examples    []string `json:"examples"`
+	Enum        []string `json:"enum"`
+	// ArrayElemType is the type of the elements of array parameters.
+	ArrayElemType string `json:"arrayElemType"`
+}
+
+// ExecTemplateConfig is a program that checks objects. The program is run once per object, with a JSON request on
+// its standard input, and must write a JSON response with the diagnostics for the object to its standard output.
+type ExecTemplateConfig struct {
+	// Command is the path of the program, or its name if it is in the PATH.
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+	// IncludeObjects, if set, adds all the objects of the lint context to the request, for checks that relate
+	// objects to each other.
+	IncludeObjects bool `json:"includeObjects"`
+}
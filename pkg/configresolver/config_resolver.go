@@ -1,13 +1,24 @@
 package configresolver
 
 import (
+	"fmt"
+	"path/filepath"
+	"time"
+
 	"golang.stackrox.io/kube-linter/internal/defaultchecks"
 	"golang.stackrox.io/kube-linter/internal/errorhelpers"
 	"golang.stackrox.io/kube-linter/internal/set"
 	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
+	"golang.stackrox.io/kube-linter/pkg/check"
 	"golang.stackrox.io/kube-linter/pkg/checkregistry"
 	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/ignore"
+	"golang.stackrox.io/kube-linter/pkg/instantiatedcheck"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
 	"golang.stackrox.io/kube-linter/pkg/pathutil"
+	"golang.stackrox.io/kube-linter/pkg/run"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/external"
 )
 
 // LoadCustomChecksInto loads the custom checks from the config into the check registry.
@@ -57,6 +68,187 @@ func GetEnabledChecksAndValidate(cfg *config.Config, checkRegistry checkregistry
 	}), nil
 }
 
+// ApplySeverityOverrides validates the severity overrides from the config, and applies them to the
+// checks in the given checkRegistry.
+func ApplySeverityOverrides(cfg *config.Config, checkRegistry checkregistry.CheckRegistry) error {
+	errorList := errorhelpers.NewErrorList("severity overrides")
+	for checkName, severity := range cfg.Checks.SeverityOverrides {
+		parsed, err := config.ParseSeverity(string(severity))
+		if err != nil {
+			errorList.AddWrapf(err, "check %q", checkName)
+			continue
+		}
+		instantiatedCheck := checkRegistry.Load(checkName)
+		if instantiatedCheck == nil {
+			errorList.AddStringf("check %q not found", checkName)
+			continue
+		}
+		instantiatedCheck.Spec.Severity = parsed
+	}
+	return errorList.ToError()
+}
+
+// GetTemplates creates the templates that the config declares. Their keys must differ from the keys of the
+// templates registered with templates.Register.
+func GetTemplates(cfg *config.Config) ([]check.Template, error) {
+	errorList := errorhelpers.NewErrorList("templates")
+	keys := set.NewStringSet()
+	out := make([]check.Template, 0, len(cfg.Templates))
+	for _, templateCfg := range cfg.Templates {
+		if _, found := templates.Get(templateCfg.Key); found {
+			errorList.AddStringf("template %s: a built-in template has the same key", templateCfg.Key)
+			continue
+		}
+		if templateCfg.Key != "" && !keys.Add(templateCfg.Key) {
+			errorList.AddStringf("template %s: duplicate key", templateCfg.Key)
+			continue
+		}
+		template, err := external.New(templateCfg)
+		if err != nil {
+			errorList.AddError(err)
+			continue
+		}
+		out = append(out, template)
+	}
+	if err := errorList.ToError(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCheckTimeout parses the check timeout of the config, which is zero if it is not set.
+func GetCheckTimeout(cfg *config.Config) (time.Duration, error) {
+	if cfg.Checks.Timeout == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(cfg.Checks.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid check timeout: %w", err)
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("invalid check timeout %s: must be positive", cfg.Checks.Timeout)
+	}
+	return timeout, nil
+}
+
+// GetSuppressions returns the suppressions declared in the config, and validates them.
+func GetSuppressions(cfg *config.Config) ([]ignore.Suppression, error) {
+	errorList := errorhelpers.NewErrorList("suppressions")
+	suppressions := make([]ignore.Suppression, 0, len(cfg.Suppressions))
+	for i, suppressionCfg := range cfg.Suppressions {
+		if len(suppressionCfg.Checks) == 0 {
+			errorList.AddStringf("suppression %d: no checks specified", i)
+			continue
+		}
+		suppression := ignore.Suppression{
+			Checks:    suppressionCfg.Checks,
+			Kind:      suppressionCfg.Kind,
+			Namespace: suppressionCfg.Namespace,
+			Name:      suppressionCfg.Name,
+			Justification: ignore.Justification{
+				Reason: suppressionCfg.Reason,
+				Owner:  suppressionCfg.Owner,
+				Ticket: suppressionCfg.Ticket,
+			},
+		}
+		if suppressionCfg.Until != "" {
+			until, err := ignore.ParseDate(suppressionCfg.Until)
+			if err != nil {
+				errorList.AddWrapf(err, "suppression %d", i)
+				continue
+			}
+			suppression.Until = until
+		}
+		suppressions = append(suppressions, suppression)
+	}
+	if err := errorList.ToError(); err != nil {
+		return nil, err
+	}
+	return suppressions, nil
+}
+
+// GetExclusions returns the scoped exclusions of checks declared in the config, and validates them.
+func GetExclusions(cfg *config.Config) ([]*ignore.Exclusion, error) {
+	errorList := errorhelpers.NewErrorList("check exclusions")
+	exclusions := make([]*ignore.Exclusion, 0, len(cfg.Checks.Exclusions))
+	for i, exclusionCfg := range cfg.Checks.Exclusions {
+		if len(exclusionCfg.Checks) == 0 {
+			errorList.AddStringf("exclusion %d: no checks specified", i)
+			continue
+		}
+		paths := make([]string, 0, len(exclusionCfg.Paths))
+		for _, path := range exclusionCfg.Paths {
+			absPath, err := pathutil.GetAbsolutPath(path)
+			if err != nil {
+				errorList.AddWrapf(err, "exclusion %d", i)
+				continue
+			}
+			paths = append(paths, absPath)
+		}
+		exclusion, err := ignore.NewExclusion(exclusionCfg.Checks, exclusionCfg.Namespace, exclusionCfg.Labels, paths)
+		if err != nil {
+			errorList.AddWrapf(err, "exclusion %d", i)
+			continue
+		}
+		exclusions = append(exclusions, exclusion)
+	}
+	if err := errorList.ToError(); err != nil {
+		return nil, err
+	}
+	return exclusions, nil
+}
+
+// GetOverrides returns the overrides that run the checks of the nested configs on the files beneath their
+// directories, in the same order. The custom checks of nested configs can use the given templates, which are the
+// external templates of the root config.
+func GetOverrides(nested []config.NestedConfig, templates []check.Template) ([]run.Override, error) {
+	overrides := make([]run.Override, 0, len(nested))
+	for i := range nested {
+		override, err := getOverride(nested[i], templates)
+		if err != nil {
+			return nil, fmt.Errorf("config of %s: %w", nested[i].Dir, err)
+		}
+		overrides = append(overrides, override)
+	}
+	return overrides, nil
+}
+
+func getOverride(nested config.NestedConfig, templates []check.Template) (run.Override, error) {
+	cfg := nested.Config
+	registry := checkregistry.NewWithOptions(instantiatedcheck.Options{Templates: instantiatedcheck.TemplatesByKey(templates...)})
+	if err := builtinchecks.LoadInto(registry); err != nil {
+		return run.Override{}, err
+	}
+	if err := LoadCustomChecksInto(&cfg, registry); err != nil {
+		return run.Override{}, err
+	}
+	checks, err := GetEnabledChecksAndValidate(&cfg, registry)
+	if err != nil {
+		return run.Override{}, err
+	}
+	if err := ApplySeverityOverrides(&cfg, registry); err != nil {
+		return run.Override{}, err
+	}
+	suppressions, err := GetSuppressions(&cfg)
+	if err != nil {
+		return run.Override{}, err
+	}
+	exclusions, err := GetExclusions(&cfg)
+	if err != nil {
+		return run.Override{}, err
+	}
+	return run.Override{
+		Matches: func(obj lintcontext.Object) bool {
+			path, err := filepath.Abs(obj.Metadata.FilePath)
+			return err == nil && nested.Contains(path)
+		},
+		Registry:     registry,
+		Checks:       checks,
+		Suppressions: suppressions,
+		Exclusions:   exclusions,
+	}, nil
+}
+
 // GetIgnorePaths loads the paths from the config into the check registry.
 func GetIgnorePaths(cfg *config.Config) ([]string, error) {
 	errorList := errorhelpers.NewErrorList("check ignore paths")
@@ -77,3 +269,116 @@ func GetIgnorePaths(cfg *config.Config) ([]string, error) {
 		return i < j
 	}), nil
 }
+
+// GetHelmOptions converts the Helm config into the options to render charts with,
+// along with the per-chart overrides keyed by absolute chart path.
+func GetHelmOptions(cfg *config.Config) (lintcontext.HelmOptions, map[string]lintcontext.HelmOptions, error) {
+	errorList := errorhelpers.NewErrorList("helm charts")
+	validateHelmVariants(errorList, "", cfg.Helm.Variants)
+	charts := make(map[string]lintcontext.HelmOptions, len(cfg.Helm.Charts))
+	for path, chartCfg := range cfg.Helm.Charts {
+		if len(chartCfg.Charts) > 0 {
+			errorList.AddStringf("chart %s: charts cannot be nested", path)
+			continue
+		}
+		validateHelmVariants(errorList, "chart "+path+": ", chartCfg.Variants)
+		absPath, err := pathutil.GetAbsolutPath(path)
+		if err != nil {
+			errorList.AddError(err)
+			continue
+		}
+		charts[filepath.Clean(absPath)] = helmOptionsFromConfig(chartCfg)
+	}
+	if err := errorList.ToError(); err != nil {
+		return lintcontext.HelmOptions{}, nil, err
+	}
+	return helmOptionsFromConfig(cfg.Helm), charts, nil
+}
+
+// GetContextOptions returns the options to create lint contexts with: how to render Helm charts, and how to group
+// the objects into contexts.
+func GetContextOptions(cfg *config.Config) (lintcontext.Options, error) {
+	helmOptions, helmCharts, err := GetHelmOptions(cfg)
+	if err != nil {
+		return lintcontext.Options{}, err
+	}
+	contextGroups, err := GetContextGroups(cfg)
+	if err != nil {
+		return lintcontext.Options{}, err
+	}
+	return lintcontext.Options{
+		Helm:          helmOptions,
+		HelmCharts:    helmCharts,
+		ContextGroups: contextGroups,
+		SingleContext: cfg.Contexts.Single,
+	}, nil
+}
+
+// GetContextGroups converts the context groups of the config into the groups to create lint contexts with,
+// whose paths are absolute.
+func GetContextGroups(cfg *config.Config) ([]lintcontext.ContextGroup, error) {
+	errorList := errorhelpers.NewErrorList("context groups")
+	names := set.NewStringSet()
+	groups := make([]lintcontext.ContextGroup, 0, len(cfg.Contexts.Groups))
+	for _, groupCfg := range cfg.Contexts.Groups {
+		if groupCfg.Name == "" {
+			errorList.AddString("group names cannot be empty")
+			continue
+		}
+		if !names.Add(groupCfg.Name) {
+			errorList.AddStringf("group %s: duplicate name", groupCfg.Name)
+			continue
+		}
+		if len(groupCfg.Paths) == 0 {
+			errorList.AddStringf("group %s: no paths specified", groupCfg.Name)
+			continue
+		}
+		group := lintcontext.ContextGroup{Name: groupCfg.Name}
+		for _, path := range groupCfg.Paths {
+			if path == "" {
+				errorList.AddStringf("group %s: paths cannot be empty", groupCfg.Name)
+				continue
+			}
+			absPath, err := pathutil.GetAbsolutPath(path)
+			if err != nil {
+				errorList.AddError(err)
+				continue
+			}
+			group.Paths = append(group.Paths, absPath)
+		}
+		groups = append(groups, group)
+	}
+	if err := errorList.ToError(); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func validateHelmVariants(errorList *errorhelpers.ErrorList, prefix string, variants map[string]config.HelmConfig) {
+	for name, variantCfg := range variants {
+		if name == "" {
+			errorList.AddStringf("%svariant names cannot be empty", prefix)
+		}
+		if len(variantCfg.Charts) > 0 || len(variantCfg.Variants) > 0 {
+			errorList.AddStringf("%svariant %s: variants cannot have charts or variants", prefix, name)
+		}
+	}
+}
+
+func helmOptionsFromConfig(helmCfg config.HelmConfig) lintcontext.HelmOptions {
+	options := lintcontext.HelmOptions{
+		ValuesFiles: helmCfg.ValuesFiles,
+		SetValues:   helmCfg.Set,
+		ReleaseName: helmCfg.ReleaseName,
+		Namespace:   helmCfg.Namespace,
+		KubeVersion: helmCfg.KubeVersion,
+		APIVersions: helmCfg.APIVersions,
+	}
+	if len(helmCfg.Variants) > 0 {
+		options.Variants = make(map[string]lintcontext.HelmOptions, len(helmCfg.Variants))
+		for name, variantCfg := range helmCfg.Variants {
+			options.Variants[name] = helmOptionsFromConfig(variantCfg)
+		}
+	}
+	return options
+}
@@ -4,10 +4,19 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/mitchellh/go-homedir"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
+	"golang.stackrox.io/kube-linter/pkg/checkregistry"
 	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/ignore"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	_ "golang.stackrox.io/kube-linter/pkg/templates/all" // Register all templates
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestIgnorePaths(t *testing.T) {
@@ -50,3 +59,169 @@ func TestIgnorePaths(t *testing.T) {
 		}
 	}
 }
+
+func TestApplySeverityOverrides(t *testing.T) {
+	checkRegistry := checkregistry.New()
+	require.NoError(t, builtinchecks.LoadInto(checkRegistry))
+
+	require.NoError(t, ApplySeverityOverrides(&config.Config{Checks: config.ChecksConfig{
+		SeverityOverrides: map[string]config.Severity{"latest-tag": "Warning", "privileged-container": "info"},
+	}}, checkRegistry))
+	assert.Equal(t, config.SeverityWarning, checkRegistry.Load("latest-tag").Spec.Severity)
+	assert.Equal(t, config.SeverityInfo, checkRegistry.Load("privileged-container").Spec.Severity)
+	assert.Equal(t, config.SeverityError, checkRegistry.Load("run-as-non-root").Spec.Severity)
+
+	assert.Error(t, ApplySeverityOverrides(&config.Config{Checks: config.ChecksConfig{
+		SeverityOverrides: map[string]config.Severity{"latest-tag": "critical"},
+	}}, checkRegistry))
+	assert.Error(t, ApplySeverityOverrides(&config.Config{Checks: config.ChecksConfig{
+		SeverityOverrides: map[string]config.Severity{"no-such-check": "info"},
+	}}, checkRegistry))
+}
+
+func TestGetHelmOptions(t *testing.T) {
+	cfg := &config.Config{Helm: config.HelmConfig{
+		ReleaseName: "release",
+		Set:         []string{"a=b"},
+		Charts: map[string]config.HelmConfig{
+			"/charts/app/": {Namespace: "app"},
+		},
+	}}
+	helmOptions, helmCharts, err := GetHelmOptions(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, lintcontext.HelmOptions{ReleaseName: "release", SetValues: []string{"a=b"}}, helmOptions)
+	assert.Equal(t, map[string]lintcontext.HelmOptions{"/charts/app": {Namespace: "app"}}, helmCharts)
+
+	cfg.Helm.Charts["/charts/app/"] = config.HelmConfig{Charts: map[string]config.HelmConfig{"nested": {}}}
+	_, _, err = GetHelmOptions(cfg)
+	assert.ErrorContains(t, err, "cannot be nested")
+}
+
+func TestGetContextGroups(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	cfg := &config.Config{Contexts: config.ContextsConfig{Groups: []config.ContextGroupConfig{
+		{Name: "app", Paths: []string{"services/**", "/shared"}},
+	}}}
+	groups, err := GetContextGroups(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []lintcontext.ContextGroup{{Name: "app", Paths: []string{filepath.Join(wd, "services/**"), "/shared"}}}, groups)
+
+	cfg.Contexts.Groups = append(cfg.Contexts.Groups, config.ContextGroupConfig{Name: "app", Paths: []string{"other"}}, config.ContextGroupConfig{Name: "empty"})
+	_, err = GetContextGroups(cfg)
+	assert.ErrorContains(t, err, "group app: duplicate name")
+	assert.ErrorContains(t, err, "group empty: no paths specified")
+}
+
+func TestGetContextOptions(t *testing.T) {
+	cfg := &config.Config{
+		Helm:     config.HelmConfig{ReleaseName: "release"},
+		Contexts: config.ContextsConfig{Single: true, Groups: []config.ContextGroupConfig{{Name: "app", Paths: []string{"/services"}}}},
+	}
+	options, err := GetContextOptions(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, lintcontext.Options{
+		Helm:          lintcontext.HelmOptions{ReleaseName: "release"},
+		HelmCharts:    map[string]lintcontext.HelmOptions{},
+		ContextGroups: []lintcontext.ContextGroup{{Name: "app", Paths: []string{"/services"}}},
+		SingleContext: true,
+	}, options)
+
+	cfg.Contexts.Groups = append(cfg.Contexts.Groups, config.ContextGroupConfig{Name: "empty"})
+	_, err = GetContextOptions(cfg)
+	assert.ErrorContains(t, err, "group empty: no paths specified")
+}
+
+func TestGetCheckTimeout(t *testing.T) {
+	for _, tc := range []struct {
+		timeout  string
+		expected time.Duration
+		err      string
+	}{
+		{timeout: "", expected: 0},
+		{timeout: "1m30s", expected: 90 * time.Second},
+		{timeout: "0s", err: "must be positive"},
+		{timeout: "soon", err: "invalid check timeout"},
+	} {
+		timeout, err := GetCheckTimeout(&config.Config{Checks: config.ChecksConfig{Timeout: tc.timeout}})
+		if tc.err != "" {
+			assert.ErrorContains(t, err, tc.err, tc.timeout)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, timeout)
+	}
+}
+
+func TestGetSuppressions(t *testing.T) {
+	suppressions, err := GetSuppressions(&config.Config{Suppressions: []config.SuppressionConfig{
+		{Checks: []string{"latest-tag"}, Namespace: "legacy", Until: "2026-12-31", Reason: "Migrating", Ticket: "SEC-1", Owner: "sre"},
+		{Checks: []string{"run-as-non-root"}, Name: "agent"},
+	}})
+	require.NoError(t, err)
+	require.Len(t, suppressions, 2)
+	assert.Equal(t, ignore.Suppression{
+		Checks:    []string{"latest-tag"},
+		Namespace: "legacy",
+		Justification: ignore.Justification{
+			Reason: "Migrating",
+			Owner:  "sre",
+			Ticket: "SEC-1",
+			Until:  time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+	}, suppressions[0])
+	assert.True(t, suppressions[1].Until.IsZero())
+
+	_, err = GetSuppressions(&config.Config{Suppressions: []config.SuppressionConfig{
+		{Name: "agent"},
+		{Checks: []string{"latest-tag"}, Until: "next year"},
+	}})
+	assert.ErrorContains(t, err, "suppression 0: no checks specified")
+	assert.ErrorContains(t, err, `suppression 1: invalid expiry date "next year"`)
+}
+
+func TestGetExclusions(t *testing.T) {
+	exclusions, err := GetExclusions(&config.Config{Checks: config.ChecksConfig{Exclusions: []config.ExclusionConfig{
+		{Checks: []string{"privileged-container"}, Namespace: "kube-system", Labels: map[string]string{"app": "node-exporter"}, Paths: []string{"charts/monitoring/**"}},
+	}}})
+	require.NoError(t, err)
+	require.Len(t, exclusions, 1)
+
+	// Relative paths are resolved against the working directory.
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	obj := lintcontext.Object{
+		Metadata: lintcontext.ObjectMetadata{FilePath: filepath.Join(wd, "charts", "monitoring", "templates", "ds.yaml")},
+		K8sObject: &v1.Pod{ObjectMeta: metaV1.ObjectMeta{
+			Namespace: "kube-system",
+			Labels:    map[string]string{"app": "node-exporter"},
+		}},
+	}
+	assert.True(t, exclusions[0].Matches(obj, "privileged-container"))
+
+	_, err = GetExclusions(&config.Config{Checks: config.ChecksConfig{Exclusions: []config.ExclusionConfig{
+		{Namespace: "kube-system"},
+		{Checks: []string{"latest-tag"}, Namespace: "(kube-system"},
+	}}})
+	assert.ErrorContains(t, err, "exclusion 0: no checks specified")
+	assert.ErrorContains(t, err, "exclusion 1: invalid namespace")
+}
+
+func TestGetTemplates(t *testing.T) {
+	exec := config.ExecTemplateConfig{Command: "check-labels"}
+	cfg := &config.Config{Templates: []config.TemplateConfig{{Key: "labels", Exec: exec}}}
+	templates, err := GetTemplates(cfg)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, "labels", templates[0].Key)
+
+	cfg.Templates = append(cfg.Templates,
+		config.TemplateConfig{Key: "labels", Exec: exec},
+		config.TemplateConfig{Key: "cel-expression", Exec: exec},
+		config.TemplateConfig{Key: "no-command"},
+	)
+	_, err = GetTemplates(cfg)
+	assert.ErrorContains(t, err, "template labels: duplicate key")
+	assert.ErrorContains(t, err, "template cel-expression: a built-in template has the same key")
+	assert.ErrorContains(t, err, "no exec command specified")
+}
@@ -1,6 +1,7 @@
 package diagnostic
 
 import (
+	"golang.stackrox.io/kube-linter/pkg/config"
 	"golang.stackrox.io/kube-linter/pkg/lintcontext"
 )
 
@@ -8,7 +9,48 @@ import (
 type Diagnostic struct {
 	Message string
 
-	// TODO: add line number/col number
+	// Path optionally identifies the field at fault, relative to the root of the object,
+	// e.g. spec.template.spec.containers[1].securityContext.
+	// If it is empty, the diagnostic applies to the object as a whole.
+	Path string `json:",omitempty"`
+
+	// Line and Column locate the diagnostic in the source file (both 1-based).
+	// Checks do not need to set them: they are resolved from Path when the linter runs.
+	// They are zero if the position is unknown.
+	Line   int `json:",omitempty"`
+	Column int `json:",omitempty"`
+
+	// Code is a stable identifier of the kind of problem, for tools that process diagnostics. It is the key of the
+	// template of the check, optionally followed by a slash and the kind of problem for templates that diagnose
+	// several, e.g. run-as-non-root/run-as-group-zero. Checks do not need to set it: it defaults to the key of their
+	// template when the linter runs.
+	Code string `json:",omitempty"`
+	// Container optionally names the container at fault. Checks built with util.PerContainerCheck do not need to set
+	// it.
+	Container string `json:",omitempty"`
+	// Value optionally gives the offending value, e.g. the image of a container, and Expected optionally describes
+	// the values that the check accepts.
+	Value    string `json:",omitempty"`
+	Expected string `json:",omitempty"`
+
+	// Fix optionally suggests how to resolve the diagnostic. It is applied by kube-linter lint --fix.
+	// Paths of its edits are relative to the root of the object, like Path.
+	Fix *Fix `json:",omitempty"`
+}
+
+// Locate fills in the Line and Column of the diagnostic by resolving its Path against the given object.
+// Positions that were already set are left untouched.
+func (d *Diagnostic) Locate(object lintcontext.Object) {
+	d.LocateWith(object.Metadata.NewLocator())
+}
+
+// LocateWith is like Locate, with a locator for the object, which can be shared by all its diagnostics so that the
+// object is only parsed once.
+func (d *Diagnostic) LocateWith(locator *lintcontext.Locator) {
+	if d.Line != 0 {
+		return
+	}
+	d.Line, d.Column = locator.Locate(d.Path)
 }
 
 // WithContext puts a diagnostic in the context of which check emitted it,
@@ -16,6 +58,7 @@ type Diagnostic struct {
 type WithContext struct {
 	Diagnostic  Diagnostic
 	Check       string
+	Severity    config.Severity
 	Remediation string
 	Object      lintcontext.Object
 }
@@ -0,0 +1,50 @@
+package diagnostic
+
+// EditKind is the kind of change that an Edit makes.
+type EditKind string
+
+const (
+	// EditSet sets the field at Path to Value, creating missing parent fields.
+	EditSet EditKind = "set"
+	// EditAppend appends Value to the list at Path, creating the list if it is missing.
+	// It does nothing if the list already contains Value.
+	EditAppend EditKind = "append"
+	// EditSortKeys sorts the keys of the mapping at Path.
+	EditSortKeys EditKind = "sortKeys"
+)
+
+// An Edit is a single change to an object.
+type Edit struct {
+	Kind EditKind
+	// Path identifies the field to change, in the same format as Diagnostic.Path.
+	Path string `json:",omitempty"`
+	// Value is the scalar value to set or append.
+	Value interface{} `json:",omitempty"`
+}
+
+// A Fix is a suggested change to an object that resolves a diagnostic.
+// Fixes are applied to the YAML source of the object, preserving its comments and formatting.
+type Fix struct {
+	Description string
+	Edits       []Edit
+}
+
+// SetFix returns a fix that sets the field at the given path to the given value.
+func SetFix(description, path string, value interface{}) *Fix {
+	return &Fix{Description: description, Edits: []Edit{{Kind: EditSet, Path: path, Value: value}}}
+}
+
+// AppendFix returns a fix that appends the given value to the list at the given path.
+func AppendFix(description, path string, value interface{}) *Fix {
+	return &Fix{Description: description, Edits: []Edit{{Kind: EditAppend, Path: path, Value: value}}}
+}
+
+// PrefixPaths prefixes the paths of the edits of the fix, for checks that compute fixes relative to a sub-object.
+func (f *Fix) PrefixPaths(prefix func(path string) string) {
+	if f == nil {
+		return
+	}
+	for i := range f.Edits {
+		f.Edits[i].Path = prefix(f.Edits[i].Path)
+	}
+}
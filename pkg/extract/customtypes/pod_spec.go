@@ -1,6 +1,8 @@
 package customtypes
 
 import (
+	"fmt"
+
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -28,6 +30,28 @@ func (p *PodSpec) AllContainers() []v1.Container {
 	return allContainers
 }
 
+// AllContainerPaths returns the field paths, relative to the pod spec, of the containers returned by
+// AllContainers, in the same order. For example, the first regular container is at containers[0].
+func (p *PodSpec) AllContainerPaths() []string {
+	paths := make([]string, 0, len(p.PodSpec.InitContainers)+len(p.PodSpec.Containers)+len(p.PodSpec.EphemeralContainers))
+	paths = appendContainerPaths(paths, "initContainers", len(p.PodSpec.InitContainers))
+	paths = appendContainerPaths(paths, "containers", len(p.PodSpec.Containers))
+	return appendContainerPaths(paths, "ephemeralContainers", len(p.PodSpec.EphemeralContainers))
+}
+
+// NonInitContainerPaths returns the field paths, relative to the pod spec, of the containers returned by
+// NonInitContainers, in the same order.
+func (p *PodSpec) NonInitContainerPaths() []string {
+	return appendContainerPaths(nil, "containers", len(p.PodSpec.Containers))
+}
+
+func appendContainerPaths(paths []string, field string, count int) []string {
+	for i := 0; i < count; i++ {
+		paths = append(paths, fmt.Sprintf("%s[%d]", field, i))
+	}
+	return paths
+}
+
 // NonInitContainers returns a list of all regular (non-init) containers in the Pod
 func (p *PodSpec) NonInitContainers() []v1.Container {
 	return p.PodSpec.Containers
@@ -2,6 +2,7 @@ package extract
 
 import (
 	"reflect"
+	"strings"
 
 	ocsAppsV1 "github.com/openshift/api/apps/v1"
 	"golang.stackrox.io/kube-linter/pkg/extract/customtypes"
@@ -54,6 +55,35 @@ func PodSpec(obj k8sutil.Object) (customtypes.PodSpec, bool) {
 	return customtypes.PodSpec{PodSpec: podTemplateSpec.Spec}, true
 }
 
+// PodSpecPath returns the field path of the pod spec within the given object, if the object has one.
+// The path is in the format expected by diagnostic.Diagnostic, e.g. spec.template.spec.
+func PodSpecPath(obj k8sutil.Object) (string, bool) {
+	if _, found := PodTemplateSpec(obj); !found {
+		return "", false
+	}
+	switch obj.(type) {
+	case *coreV1.Pod:
+		return "spec", true
+	case *batchV1Beta1.CronJob, *batchV1.CronJob:
+		return "spec.jobTemplate.spec.template.spec", true
+	default:
+		return "spec.template.spec", true
+	}
+}
+
+// PodMetadataPath returns the field path of the metadata of the pods of the given object, if the object has a pod
+// spec, e.g. spec.template.metadata.
+func PodMetadataPath(obj k8sutil.Object) (string, bool) {
+	switch podSpecPath, found := PodSpecPath(obj); {
+	case !found:
+		return "", false
+	case podSpecPath == "spec":
+		return "metadata", true
+	default:
+		return strings.TrimSuffix(podSpecPath, ".spec") + ".metadata", true
+	}
+}
+
 // Selector extracts a selector from the given object, if available.
 func Selector(obj k8sutil.Object) (*metaV1.LabelSelector, bool) {
 	switch obj := obj.(type) {
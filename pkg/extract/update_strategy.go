@@ -17,6 +17,10 @@ type UpdateStrategyValues struct {
 	MaxUnavailable       *intstr.IntOrString
 	MaxSurgeExists       bool
 	MaxSurge             *intstr.IntOrString
+	// Path is the field path of the update strategy within the object, e.g. spec.strategy, and RollingConfigPath the
+	// one of its rolling update parameters, e.g. spec.strategy.rollingUpdate.
+	Path              string
+	RollingConfigPath string
 }
 
 // UpdateStrategy will extract the data from an UpdateStrategy into a common struct
@@ -26,13 +30,17 @@ func UpdateStrategy(obj k8sutil.Object) (*UpdateStrategyValues, bool) {
 	if !spec.IsValid() {
 		return nil, false
 	}
-	strategy := spec.FieldByName("Strategy")
+	strategy, path := spec.FieldByName("Strategy"), "spec.strategy"
 	if !strategy.IsValid() {
-		strategy = spec.FieldByName("UpdateStrategy")
+		strategy, path = spec.FieldByName("UpdateStrategy"), "spec.updateStrategy"
 		if !strategy.IsValid() {
 			return nil, false
 		}
 	}
+	rollingConfigPath := path + ".rollingUpdate"
+	if !reflect.Indirect(strategy).FieldByName("RollingUpdate").IsValid() {
+		rollingConfigPath = path + ".rollingParams"
+	}
 	strategyType, typeFound := typeFromUpdateStrategy(strategy)
 	rollingUpdate, rollingUpdateFound := rollingUpdateFromUpdateStrategy(strategy)
 	maxUnavailable, maxUnavailableFound := maxUnavailableFromRollingUpdate(rollingUpdate)
@@ -47,6 +55,8 @@ func UpdateStrategy(obj k8sutil.Object) (*UpdateStrategyValues, bool) {
 		MaxUnavailableExists: maxUnavailableFound,
 		MaxSurge:             maxSurge,
 		MaxSurgeExists:       maxSurgeFound,
+		Path:                 path,
+		RollingConfigPath:    rollingConfigPath,
 	}, true
 }
 
@@ -0,0 +1,220 @@
+package ignore
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml/ast"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+)
+
+// CommentScope is the part of a file that a suppression comment applies to.
+type CommentScope string
+
+const (
+	// ScopeNode is the scope of "# kube-linter:ignore" comments, which apply to the node that they are on the line
+	// of, or to the node on the next line, and to all the fields under it.
+	ScopeNode CommentScope = "node"
+	// ScopeDocument is the scope of "# kube-linter:ignore-document" comments, which apply to the whole YAML document
+	// that they are in.
+	ScopeDocument CommentScope = "document"
+	// ScopeFile is the scope of "# kube-linter:ignore-file" comments, which apply to all the documents of the file
+	// that they are in.
+	ScopeFile CommentScope = "file"
+)
+
+// commentRegex matches suppression comments, such as:
+//
+//	# kube-linter:ignore privileged-container,run-as-non-root -- The node agent needs full access to the host
+var commentRegex = regexp.MustCompile(`#\s*kube-linter:ignore(-document|-file)?\s+([^\s]+)(?:\s+--\s*(.*?))?\s*$`)
+
+// A Comment is a suppression comment, which ignores the findings of some checks in part of a file.
+type Comment struct {
+	Checks []string
+	// Reason is the justification that follows "--" in the comment. It is empty if there is none.
+	Reason string
+	Scope  CommentScope
+	// Line and Column locate the comment within the raw YAML that it was parsed from (both 1-based).
+	Line, Column int
+	// Path is the field path of the node that a ScopeNode comment applies to. It is nil if the comment is not
+	// followed by a node, in which case it applies to nothing.
+	Path []lintcontext.FieldPathSegment
+}
+
+// ParseComments returns the suppression comments of the raw YAML of an object, in the order in which they appear.
+func ParseComments(raw []byte) []Comment {
+	return ParseObjectComments(lintcontext.ObjectMetadata{Raw: raw}.NewLocator())
+}
+
+// ParseObjectComments is like ParseComments, and reuses the YAML that the given locator already parsed, if any.
+func ParseObjectComments(locator *lintcontext.Locator) []Comment {
+	raw := locator.Raw()
+	if !bytes.Contains(raw, []byte("kube-linter:ignore")) {
+		return nil
+	}
+	var comments []Comment
+	var pending []int
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	scanner.Buffer(nil, len(raw)+1)
+	nodePath := func(line int) []lintcontext.FieldPathSegment {
+		path, found := findNodeAtLine(locator.Root(), nil, line)
+		if !found {
+			return nil
+		}
+		return path
+	}
+
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		isCommentLine := strings.HasPrefix(trimmed, "#")
+		// A node on this line is the target of the preceding comments.
+		if trimmed != "" && !isCommentLine && trimmed != "---" {
+			for _, idx := range pending {
+				comments[idx].Path = nodePath(lineNum)
+			}
+			pending = nil
+		}
+		match := commentRegex.FindStringSubmatchIndex(line)
+		if match == nil {
+			continue
+		}
+		comment := Comment{
+			Checks: strings.Split(line[match[4]:match[5]], ","),
+			Scope:  ScopeNode,
+			Line:   lineNum,
+			Column: match[0] + 1,
+		}
+		if match[6] >= 0 {
+			comment.Reason = line[match[6]:match[7]]
+		}
+		if match[2] >= 0 {
+			switch line[match[2]:match[3]] {
+			case "-document":
+				comment.Scope = ScopeDocument
+			case "-file":
+				comment.Scope = ScopeFile
+			}
+		}
+		comments = append(comments, comment)
+		if comment.Scope != ScopeNode {
+			continue
+		}
+		if isCommentLine {
+			pending = append(pending, len(comments)-1)
+		} else {
+			comments[len(comments)-1].Path = nodePath(lineNum)
+		}
+	}
+	return comments
+}
+
+// AppliesTo returns whether the comment suppresses the findings of the given check for the field at the given
+// path, which is relative to the root of the object (see diagnostic.Diagnostic).
+func (c *Comment) AppliesTo(checkName, fieldPath string) bool {
+	if !c.IgnoresCheck(checkName) {
+		return false
+	}
+	if c.Scope != ScopeNode {
+		return true
+	}
+	if c.Path == nil {
+		return false
+	}
+	segments, err := lintcontext.ParseFieldPath(fieldPath)
+	if err != nil || len(segments) < len(c.Path) {
+		return false
+	}
+	for i, segment := range c.Path {
+		if segments[i] != segment {
+			return false
+		}
+	}
+	return true
+}
+
+// IgnoresCheck returns whether the comment names the given check.
+func (c *Comment) IgnoresCheck(checkName string) bool {
+	for _, name := range c.Checks {
+		if name == checkName {
+			return true
+		}
+	}
+	return false
+}
+
+// PathString returns the field path of the node that the comment applies to, in the notation of diagnostic paths.
+func (c *Comment) PathString() string {
+	var sb strings.Builder
+	for _, segment := range c.Path {
+		switch {
+		case segment.IsIndex:
+			sb.WriteString("[" + strconv.Itoa(segment.Index) + "]")
+		case strings.ContainsAny(segment.Key, ".[]\""):
+			sb.WriteString("[" + strconv.Quote(segment.Key) + "]")
+		default:
+			if sb.Len() > 0 {
+				sb.WriteString(".")
+			}
+			sb.WriteString(segment.Key)
+		}
+	}
+	return sb.String()
+}
+
+// findNodeAtLine returns the path of the outermost node under node that starts at the given line.
+func findNodeAtLine(node ast.Node, path []lintcontext.FieldPathSegment, line int) ([]lintcontext.FieldPathSegment, bool) {
+	switch n := lintcontext.UnwrapNode(node).(type) {
+	case *ast.MappingNode:
+		for _, value := range n.Values {
+			if found, ok := findInMappingValue(value, path, line); ok {
+				return found, true
+			}
+		}
+	case *ast.MappingValueNode:
+		return findInMappingValue(n, path, line)
+	case *ast.SequenceNode:
+		for i, value := range n.Values {
+			valuePath := appendSegment(path, lintcontext.FieldPathSegment{Index: i, IsIndex: true})
+			entryLine := nodeLine(value)
+			if i < len(n.Entries) && n.Entries[i].Start != nil {
+				entryLine = n.Entries[i].Start.Position.Line
+			}
+			if entryLine == line {
+				return valuePath, true
+			}
+			if found, ok := findNodeAtLine(value, valuePath, line); ok {
+				return found, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func findInMappingValue(value *ast.MappingValueNode, path []lintcontext.FieldPathSegment, line int) ([]lintcontext.FieldPathSegment, bool) {
+	if value.Key == nil {
+		return nil, false
+	}
+	valuePath := appendSegment(path, lintcontext.FieldPathSegment{Key: lintcontext.KeyString(value.Key)})
+	if nodeLine(value.Key) == line {
+		return valuePath, true
+	}
+	return findNodeAtLine(value.Value, valuePath, line)
+}
+
+func appendSegment(path []lintcontext.FieldPathSegment, segment lintcontext.FieldPathSegment) []lintcontext.FieldPathSegment {
+	return append(append(make([]lintcontext.FieldPathSegment, 0, len(path)+1), path...), segment)
+}
+
+func nodeLine(node ast.Node) int {
+	if node == nil {
+		return 0
+	}
+	if tok := node.GetToken(); tok != nil && tok.Position != nil {
+		return tok.Position.Line
+	}
+	return 0
+}
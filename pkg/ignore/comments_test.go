@@ -0,0 +1,76 @@
+package ignore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const commentedDeployment = `# kube-linter:ignore-file latest-tag -- Images are pinned by the deploy pipeline
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: agent
+  # kube-linter:ignore-document no-anti-affinity
+spec:
+  template:
+    spec:
+      containers:
+      # kube-linter:ignore privileged-container,run-as-non-root -- The agent needs full access to the host
+      - name: agent
+        securityContext:
+          privileged: true
+      - name: sidecar
+        securityContext:
+          readOnlyRootFilesystem: false # kube-linter:ignore no-read-only-root-fs -- Writes its cache
+      # kube-linter:ignore sorted-keys -- Not followed by a node
+`
+
+func TestParseComments(t *testing.T) {
+	comments := ParseComments([]byte(commentedDeployment))
+	require.Len(t, comments, 5)
+
+	assert.Equal(t, ScopeFile, comments[0].Scope)
+	assert.Equal(t, []string{"latest-tag"}, comments[0].Checks)
+	assert.Equal(t, "Images are pinned by the deploy pipeline", comments[0].Reason)
+	assert.Equal(t, 1, comments[0].Line)
+
+	assert.Equal(t, ScopeDocument, comments[1].Scope)
+	assert.Empty(t, comments[1].Reason)
+	assert.Equal(t, 6, comments[1].Line)
+	assert.Equal(t, 3, comments[1].Column)
+
+	assert.Equal(t, ScopeNode, comments[2].Scope)
+	assert.Equal(t, []string{"privileged-container", "run-as-non-root"}, comments[2].Checks)
+	assert.Equal(t, "spec.template.spec.containers[0]", comments[2].PathString())
+
+	assert.Equal(t, "spec.template.spec.containers[1].securityContext.readOnlyRootFilesystem", comments[3].PathString())
+	assert.Equal(t, "Writes its cache", comments[3].Reason)
+
+	assert.Nil(t, comments[4].Path)
+
+	assert.Empty(t, ParseComments([]byte("kind: Pod\n")))
+}
+
+func TestCommentAppliesTo(t *testing.T) {
+	comments := ParseComments([]byte(commentedDeployment))
+	for _, tc := range []struct {
+		comment   int
+		check     string
+		path      string
+		isApplied bool
+	}{
+		{comment: 0, check: "latest-tag", path: "spec.template.spec.containers[1].image", isApplied: true},
+		{comment: 0, check: "privileged-container", path: ""},
+		{comment: 1, check: "no-anti-affinity", path: "", isApplied: true},
+		{comment: 2, check: "privileged-container", path: "spec.template.spec.containers[0].securityContext.privileged", isApplied: true},
+		{comment: 2, check: "run-as-non-root", path: "spec.template.spec.containers[0]", isApplied: true},
+		{comment: 2, check: "privileged-container", path: "spec.template.spec.containers[1].securityContext"},
+		{comment: 2, check: "privileged-container", path: "spec.template.spec"},
+		{comment: 3, check: "no-read-only-root-fs", path: "spec.template.spec.containers[1].securityContext.readOnlyRootFilesystem", isApplied: true},
+		{comment: 4, check: "sorted-keys", path: ""},
+	} {
+		assert.Equal(t, tc.isApplied, comments[tc.comment].AppliesTo(tc.check, tc.path), "%+v", tc)
+	}
+}
@@ -0,0 +1,104 @@
+package ignore
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
+)
+
+// An Exclusion excludes checks for the objects that match all of its scope. Exclusions are declared in the config,
+// for objects that cannot be annotated.
+type Exclusion struct {
+	checks    []string
+	namespace func(string) bool
+	labels    []labelMatcher
+	paths     []string
+}
+
+type labelMatcher struct {
+	key, value func(string) bool
+}
+
+// NewExclusion creates an exclusion of the given checks. The namespace, and the keys and values of labels, are
+// matched with matcher.ForString. Paths are glob patterns (which support **) of absolute paths of files and
+// directories, and a pattern that matches a directory matches all the files in it. Empty values match all objects.
+func NewExclusion(checks []string, namespace string, labels map[string]string, paths []string) (*Exclusion, error) {
+	namespaceMatcher, err := matcher.ForString(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace: %w", err)
+	}
+	e := &Exclusion{checks: checks, namespace: namespaceMatcher}
+	for key, value := range labels {
+		keyMatcher, err := matcher.ForString(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label key: %w", err)
+		}
+		valueMatcher, err := matcher.ForString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of label %s: %w", key, err)
+		}
+		e.labels = append(e.labels, labelMatcher{key: keyMatcher, value: valueMatcher})
+	}
+	for _, path := range paths {
+		if !doublestar.ValidatePattern(path) {
+			return nil, fmt.Errorf("invalid path pattern %s", path)
+		}
+		e.paths = append(e.paths, path)
+	}
+	return e, nil
+}
+
+// Matches returns whether the given check is excluded for the given object.
+func (e *Exclusion) Matches(obj lintcontext.Object, checkName string) bool {
+	found := false
+	for _, name := range e.checks {
+		if name == checkName {
+			found = true
+			break
+		}
+	}
+	if !found || !e.namespace(obj.K8sObject.GetNamespace()) {
+		return false
+	}
+	labels := obj.K8sObject.GetLabels()
+	for _, m := range e.labels {
+		if !m.matchesAny(labels) {
+			return false
+		}
+	}
+	return len(e.paths) == 0 || e.matchesPath(obj.Metadata.FilePath)
+}
+
+func (m labelMatcher) matchesAny(labels map[string]string) bool {
+	for k, v := range labels {
+		if m.key(k) && m.value(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPath returns whether the given path, or one of its parent directories, matches a path of the exclusion.
+func (e *Exclusion) matchesPath(path string) bool {
+	if path == "" {
+		return false
+	}
+	if absPath, err := filepath.Abs(path); err == nil {
+		path = absPath
+	}
+	for _, pattern := range e.paths {
+		for candidate := path; ; candidate = filepath.Dir(candidate) {
+			// The patterns are validated by NewExclusion, so matching cannot fail.
+			if match, _ := doublestar.PathMatch(pattern, candidate); match {
+				return true
+			}
+			if filepath.Dir(candidate) == candidate {
+				break
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package ignore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExclusion(t *testing.T) {
+	exclusion, err := NewExclusion(
+		[]string{"privileged-container"},
+		"^kube-system$",
+		map[string]string{"app": "^node-exporter$"},
+		[]string{"/repo/charts/monitoring/**"},
+	)
+	require.NoError(t, err)
+
+	pod := func(filePath, namespace string, labels map[string]string) lintcontext.Object {
+		return lintcontext.Object{
+			Metadata: lintcontext.ObjectMetadata{FilePath: filePath},
+			K8sObject: &v1.Pod{
+				ObjectMeta: metaV1.ObjectMeta{Name: "pod", Namespace: namespace, Labels: labels},
+			},
+		}
+	}
+	exporterLabels := map[string]string{"app": "node-exporter", "tier": "monitoring"}
+	for _, tc := range []struct {
+		obj        lintcontext.Object
+		check      string
+		isExcluded bool
+	}{
+		{obj: pod("/repo/charts/monitoring/templates/ds.yaml", "kube-system", exporterLabels), check: "privileged-container", isExcluded: true},
+		{obj: pod("/repo/charts/monitoring/templates/ds.yaml", "kube-system", exporterLabels), check: "run-as-non-root"},
+		{obj: pod("/repo/charts/monitoring/templates/ds.yaml", "default", exporterLabels), check: "privileged-container"},
+		{obj: pod("/repo/charts/monitoring/templates/ds.yaml", "kube-system", map[string]string{"app": "node-exporter-2"}), check: "privileged-container"},
+		{obj: pod("/repo/charts/logging/templates/ds.yaml", "kube-system", exporterLabels), check: "privileged-container"},
+		{obj: pod("", "kube-system", exporterLabels), check: "privileged-container"},
+	} {
+		assert.Equal(t, tc.isExcluded, exclusion.Matches(tc.obj, tc.check), "%+v %s", tc.obj.Metadata, tc.check)
+	}
+
+	negated, err := NewExclusion([]string{"latest-tag"}, "!^prod-", nil, nil)
+	require.NoError(t, err)
+	assert.True(t, negated.Matches(pod("", "dev-payments", nil), "latest-tag"))
+	assert.False(t, negated.Matches(pod("", "prod-payments", nil), "latest-tag"))
+
+	_, err = NewExclusion([]string{"latest-tag"}, "(", nil, nil)
+	assert.ErrorContains(t, err, "invalid namespace")
+	_, err = NewExclusion([]string{"latest-tag"}, "", map[string]string{"app": "["}, nil)
+	assert.ErrorContains(t, err, "invalid value of label app")
+	_, err = NewExclusion([]string{"latest-tag"}, "", nil, []string{"charts/[a-"})
+	assert.ErrorContains(t, err, "invalid path pattern")
+}
@@ -1,7 +1,9 @@
 package ignore
 
 import (
-	"golang.stackrox.io/kube-linter/internal/stringutils"
+	"time"
+
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
 )
 
 const (
@@ -12,14 +14,62 @@ const (
 	AllAnnotationKey = "kube-linter.io/ignore-all"
 )
 
-// ObjectForCheck returns whether to ignore the given object for the passed check name.
+// ObjectForCheck returns whether to ignore the given object for the passed check name. Suppressions whose
+// justification is invalid or has expired do not apply, so the object is ignored if either the annotation for the
+// check or AllAnnotationKey applies.
 func ObjectForCheck(annotations map[string]string, checkName string) bool {
-	for k := range annotations {
-		if k == AllAnnotationKey {
+	for _, key := range annotationKeysForCheck(checkName) {
+		value, found := annotations[key]
+		if !found {
+			continue
+		}
+		if j, err := ParseJustification(value); err == nil && !j.Expired(time.Now()) {
 			return true
 		}
-		key := k
-		if stringutils.ConsumePrefix(&key, AnnotationKeyPrefix) && key == checkName {
+	}
+	return false
+}
+
+// AnnotationForCheck returns the key and the value of the annotation that suppresses the passed check name, if
+// any. The value is the justification of the suppression (see ParseJustification). The annotation for the check is
+// preferred to AllAnnotationKey if both are present.
+func AnnotationForCheck(annotations map[string]string, checkName string) (key, value string, found bool) {
+	for _, key := range annotationKeysForCheck(checkName) {
+		if value, found := annotations[key]; found {
+			return key, value, true
+		}
+	}
+	return "", "", false
+}
+
+// annotationKeysForCheck returns the keys of the annotations that suppress the given check, the most specific first.
+func annotationKeysForCheck(checkName string) []string {
+	return []string{AnnotationKeyPrefix + checkName, AllAnnotationKey}
+}
+
+// A Suppression ignores the findings of some checks for the objects that it matches. Suppressions are declared in
+// the config, for objects that cannot be annotated.
+type Suppression struct {
+	Checks []string
+	// Kind, Namespace and Name select the objects that the suppression applies to. Empty values match all objects.
+	Kind, Namespace, Name string
+	Justification
+}
+
+// Matches returns whether the suppression applies to the given check for the given object, regardless of its
+// expiry.
+func (s *Suppression) Matches(obj k8sutil.Object, checkName string) bool {
+	if s.Kind != "" && obj.GetObjectKind().GroupVersionKind().Kind != s.Kind {
+		return false
+	}
+	if s.Namespace != "" && obj.GetNamespace() != s.Namespace {
+		return false
+	}
+	if s.Name != "" && obj.GetName() != s.Name {
+		return false
+	}
+	for _, name := range s.Checks {
+		if name == checkName {
 			return true
 		}
 	}
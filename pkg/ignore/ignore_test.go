@@ -68,6 +68,41 @@ func TestObjectForCheck(t *testing.T) {
 			checkName:    "some-other-check",
 			shouldIgnore: true,
 		},
+		{
+			annotations: map[string]string{
+				"ignore-check.kube-linter.io/some-check": "until=2001-01-01; reason=Expired",
+			},
+			checkName: "some-check",
+		},
+		{
+			annotations: map[string]string{
+				"ignore-check.kube-linter.io/some-check": "until=2001-13-01",
+			},
+			checkName: "some-check",
+		},
+		{
+			annotations: map[string]string{
+				"ignore-check.kube-linter.io/some-check": "until=2999-01-01; reason=Not applicable",
+			},
+			checkName:    "some-check",
+			shouldIgnore: true,
+		},
+		{
+			annotations: map[string]string{
+				"ignore-check.kube-linter.io/some-check": "until=2001-01-01; reason=Expired",
+				"kube-linter.io/ignore-all":              "Too much of a mess",
+			},
+			checkName:    "some-check",
+			shouldIgnore: true,
+		},
+		{
+			annotations: map[string]string{
+				"ignore-check.kube-linter.io/some-check": "Not applicable",
+				"kube-linter.io/ignore-all":              "until=2001-01-01; reason=Expired",
+			},
+			checkName:    "some-check",
+			shouldIgnore: true,
+		},
 	} {
 		c := testCase
 		t.Run(fmt.Sprintf("%+v", c), func(t *testing.T) {
@@ -75,3 +110,25 @@ func TestObjectForCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestAnnotationForCheck(t *testing.T) {
+	annotations := map[string]string{
+		"ignore-check.kube-linter.io/some-check": "Not applicable",
+		"kube-linter.io/ignore-all":              "Too much of a mess",
+	}
+	// The annotation for the check is preferred, regardless of the order of the map.
+	for i := 0; i < 20; i++ {
+		key, value, found := AnnotationForCheck(annotations, "some-check")
+		assert.True(t, found)
+		assert.Equal(t, "ignore-check.kube-linter.io/some-check", key)
+		assert.Equal(t, "Not applicable", value)
+	}
+
+	key, value, found := AnnotationForCheck(annotations, "other-check")
+	assert.True(t, found)
+	assert.Equal(t, AllAnnotationKey, key)
+	assert.Equal(t, "Too much of a mess", value)
+
+	_, _, found = AnnotationForCheck(map[string]string{"random-unrelated": "blah"}, "some-check")
+	assert.False(t, found)
+}
@@ -0,0 +1,80 @@
+package ignore
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DateFormat is the format of the expiry dates of suppressions.
+const DateFormat = "2006-01-02"
+
+// justificationFieldRegex matches the fields of a structured justification, such as "until=2026-12-31".
+var justificationFieldRegex = regexp.MustCompile(`^\s*(until|reason|ticket|owner)\s*=(.*)$`)
+
+// A Justification explains why findings are suppressed, and for how long. It is given as the value of an
+// ignore-check annotation, as the reason of a suppression comment, or in the suppressions of the config.
+type Justification struct {
+	Reason string
+	// Owner is the person or team that is responsible for the suppression.
+	Owner string
+	// Ticket tracks the work that will remove the need for the suppression.
+	Ticket string
+	// Until is the last day (in UTC) on which the suppression applies. It is zero if the suppression does not expire.
+	Until time.Time
+}
+
+// ParseJustification parses a justification, which is either free text, in which case it is the reason, or a list
+// of fields separated by semicolons, such as:
+//
+//	until=2026-12-31; reason=The agent needs full access to the host; ticket=SEC-123; owner=platform-team
+//
+// Values that contain a part that is not a known field are free text.
+func ParseJustification(value string) (Justification, error) {
+	parts := strings.Split(value, ";")
+	fields := make(map[string]string, len(parts))
+	for _, part := range parts {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		match := justificationFieldRegex.FindStringSubmatch(part)
+		if match == nil {
+			return Justification{Reason: strings.TrimSpace(value)}, nil
+		}
+		fields[match[1]] = strings.TrimSpace(match[2])
+	}
+	if len(fields) == 0 {
+		return Justification{}, nil
+	}
+	j := Justification{Reason: fields["reason"], Owner: fields["owner"], Ticket: fields["ticket"]}
+	if until, ok := fields["until"]; ok {
+		var err error
+		if j.Until, err = ParseDate(until); err != nil {
+			return Justification{}, err
+		}
+	}
+	return j, nil
+}
+
+// ParseDate parses an expiry date in DateFormat.
+func ParseDate(value string) (time.Time, error) {
+	date, err := time.Parse(DateFormat, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid expiry date %q, must be in the format YYYY-MM-DD", value)
+	}
+	return date, nil
+}
+
+// Expired returns whether the suppression no longer applies at the given time.
+func (j Justification) Expired(now time.Time) bool {
+	return !j.Until.IsZero() && !now.Before(j.Until.AddDate(0, 0, 1))
+}
+
+// UntilString returns the expiry date in DateFormat, or "never" if the suppression does not expire.
+func (j Justification) UntilString() string {
+	if j.Until.IsZero() {
+		return "never"
+	}
+	return j.Until.Format(DateFormat)
+}
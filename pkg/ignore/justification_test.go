@@ -0,0 +1,51 @@
+package ignore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJustification(t *testing.T) {
+	for _, tc := range []struct {
+		value    string
+		expected Justification
+		err      string
+	}{
+		{value: ""},
+		{value: "Not applicable", expected: Justification{Reason: "Not applicable"}},
+		{value: "Needs a=b; not structured", expected: Justification{Reason: "Needs a=b; not structured"}},
+		{
+			value: "until=2026-12-31; reason=Needs host access; ticket=SEC-123; owner=platform",
+			expected: Justification{
+				Reason: "Needs host access",
+				Owner:  "platform",
+				Ticket: "SEC-123",
+				Until:  time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{value: "reason = Spaces are trimmed ;", expected: Justification{Reason: "Spaces are trimmed"}},
+		{value: "until=31/12/2026; reason=Wrong format", err: `invalid expiry date "31/12/2026"`},
+	} {
+		j, err := ParseJustification(tc.value)
+		if tc.err != "" {
+			assert.ErrorContains(t, err, tc.err, tc.value)
+			continue
+		}
+		require.NoError(t, err, tc.value)
+		assert.Equal(t, tc.expected, j, tc.value)
+	}
+}
+
+func TestJustificationExpired(t *testing.T) {
+	j, err := ParseJustification("until=2026-12-31")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-12-31", j.UntilString())
+	assert.False(t, j.Expired(time.Date(2026, 12, 31, 23, 59, 0, 0, time.UTC)))
+	assert.True(t, j.Expired(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	assert.False(t, Justification{}.Expired(time.Now()))
+	assert.Equal(t, "never", Justification{}.UntilString())
+}
@@ -1,12 +1,15 @@
 package instantiatedcheck
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 
 	"golang.stackrox.io/kube-linter/internal/errorhelpers"
 	"golang.stackrox.io/kube-linter/pkg/check"
 	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 )
@@ -14,8 +17,13 @@ import (
 // An InstantiatedCheck is the runtime instantiation of a check, which fuses the metadata in a check
 // spec with the runtime information from a template.
 type InstantiatedCheck struct {
-	Func    check.Func
-	Matcher objectkinds.Matcher
+	Func check.Func
+	// ContextFunc is Func, also passed the context of the run (see check.ContextFunc). It is always set, and preferred
+	// by the linter.
+	ContextFunc check.ContextFunc
+	Matcher     objectkinds.Matcher
+	// NeedsOtherObjects is copied from the template of the check (see check.Template).
+	NeedsOtherObjects bool
 
 	Spec config.Check
 }
@@ -24,9 +32,30 @@ var (
 	validCheckNameRegex = regexp.MustCompile(`^[a-zA-Z0-9-_]+$`)
 )
 
+// Options represent values that can be provided to modify how checks are instantiated.
+type Options struct {
+	// Templates are looked up by key before the templates registered with templates.Register.
+	Templates map[string]check.Template
+}
+
+// TemplatesByKey returns the given templates keyed by their keys, as Options.Templates expects them.
+func TemplatesByKey(templates ...check.Template) map[string]check.Template {
+	out := make(map[string]check.Template, len(templates))
+	for _, template := range templates {
+		out[template.Key] = template
+	}
+	return out
+}
+
 // ValidateAndInstantiate validates the check, and creates an instantiated check if the check
 // is valid.
 func ValidateAndInstantiate(c *config.Check) (*InstantiatedCheck, error) {
+	return ValidateAndInstantiateWithOptions(Options{}, c)
+}
+
+// ValidateAndInstantiateWithOptions validates the check, and creates an instantiated check with additional Options
+// if the check is valid.
+func ValidateAndInstantiateWithOptions(options Options, c *config.Check) (*InstantiatedCheck, error) {
 	validationErrs := errorhelpers.NewErrorList("validating check")
 	if c.Name == "" {
 		validationErrs.AddString("no name specified")
@@ -34,7 +63,14 @@ func ValidateAndInstantiate(c *config.Check) (*InstantiatedCheck, error) {
 	if !validCheckNameRegex.MatchString(c.Name) {
 		validationErrs.AddStringf("invalid name %s, must match regex %s", c.Name, validCheckNameRegex.String())
 	}
-	template, found := templates.Get(c.Template)
+	severity, err := config.ParseSeverity(string(c.Severity))
+	if err != nil {
+		validationErrs.AddError(err)
+	}
+	template, found := options.Templates[c.Template]
+	if !found {
+		template, found = templates.Get(c.Template)
+	}
 	if !found {
 		validationErrs.AddStringf("template %q not found", c.Template)
 		return nil, validationErrs.ToError()
@@ -49,7 +85,8 @@ func ValidateAndInstantiate(c *config.Check) (*InstantiatedCheck, error) {
 		return nil, err
 	}
 
-	i := &InstantiatedCheck{Spec: *c}
+	i := &InstantiatedCheck{Spec: *c, NeedsOtherObjects: template.NeedsOtherObjects}
+	i.Spec.Severity = severity
 	var objectKinds config.ObjectKindsDesc
 	if c.Scope != nil {
 		objectKinds = *c.Scope
@@ -61,10 +98,30 @@ func ValidateAndInstantiate(c *config.Check) (*InstantiatedCheck, error) {
 		return nil, err
 	}
 	i.Matcher = matcher
+	if err := instantiate(i, template, params); err != nil {
+		return nil, fmt.Errorf("instantiating check: %w", err)
+	}
+	return i, nil
+}
+
+// instantiate sets the Func and ContextFunc of the check from the template with the given params, preferring the
+// InstantiateWithContext of the template.
+func instantiate(i *InstantiatedCheck, template check.Template, params interface{}) error {
+	if template.InstantiateWithContext != nil {
+		contextFunc, err := template.InstantiateWithContext(params)
+		if err != nil {
+			return err
+		}
+		i.Func, i.ContextFunc = contextFunc.WithoutContext(), contextFunc
+		return nil
+	}
 	checkFunc, err := template.Instantiate(params)
 	if err != nil {
-		return nil, fmt.Errorf("instantiating check: %w", err)
+		return err
 	}
 	i.Func = checkFunc
-	return i, nil
+	i.ContextFunc = func(_ context.Context, lintCtx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+		return checkFunc(lintCtx, object)
+	}
+	return nil
 }
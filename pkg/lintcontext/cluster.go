@@ -0,0 +1,304 @@
+package lintcontext
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+// ClusterFilePathPrefix is the prefix of the FilePath of objects loaded from a cluster.
+// The full path is <cluster>/<namespace>/<resource>/<name>, with _ as the namespace of cluster-scoped objects.
+const ClusterFilePathPrefix = "<cluster>"
+
+// clusterScopedNamespace stands in for the namespace of cluster-scoped objects in file paths and context keys.
+const clusterScopedNamespace = "_"
+
+// listPageSize is the maximum number of objects requested at once from the API server.
+const listPageSize = 500
+
+// ClusterOptions represent values that can be provided to modify how objects are loaded from a cluster.
+type ClusterOptions struct {
+	Options
+
+	// Namespace restricts the objects to a single namespace. Cluster-scoped objects are only loaded
+	// if it is empty.
+	Namespace string
+	// ContextPerNamespace creates a separate context for each namespace (and one for cluster-scoped objects),
+	// instead of a single context for the whole cluster.
+	ContextPerNamespace bool
+	// Warn, if set, is called with a warning for each resource that is skipped because listing it is forbidden.
+	Warn func(msg string)
+}
+
+// clusterResource describes how to list one kind of resource from the cluster.
+type clusterResource struct {
+	// name is the plural resource name, used in file paths.
+	name       string
+	kind       schema.GroupKind
+	namespaced bool
+	// list returns one page of objects, and the token to continue the listing (empty on the last page).
+	list func(ctx context.Context, client kubernetes.Interface, namespace string, opts metaV1.ListOptions) ([]k8sutil.Object, string, error)
+}
+
+// clusterResources are the resources that are loaded from a cluster.
+// Objects controlled by an object of one of these resources (for example, the ReplicaSets of a Deployment, or the
+// Pods of a Job) are skipped, since they are linted through their controller.
+var clusterResources = []clusterResource{
+	{name: "pods", kind: schema.GroupKind{Kind: "Pod"}, namespaced: true, list: func(ctx context.Context, client kubernetes.Interface, namespace string, opts metaV1.ListOptions) ([]k8sutil.Object, string, error) {
+		l, err := client.CoreV1().Pods(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return toObjects(l.Items), l.Continue, nil
+	}},
+	{name: "services", kind: schema.GroupKind{Kind: "Service"}, namespaced: true, list: func(ctx context.Context, client kubernetes.Interface, namespace string, opts metaV1.ListOptions) ([]k8sutil.Object, string, error) {
+		l, err := client.CoreV1().Services(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return toObjects(l.Items), l.Continue, nil
+	}},
+	{name: "serviceaccounts", kind: schema.GroupKind{Kind: "ServiceAccount"}, namespaced: true, list: func(ctx context.Context, client kubernetes.Interface, namespace string, opts metaV1.ListOptions) ([]k8sutil.Object, string, error) {
+		l, err := client.CoreV1().ServiceAccounts(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return toObjects(l.Items), l.Continue, nil
+	}},
+	{name: "deployments", kind: schema.GroupKind{Group: "apps", Kind: "Deployment"}, namespaced: true, list: func(ctx context.Context, client kubernetes.Interface, namespace string, opts metaV1.ListOptions) ([]k8sutil.Object, string, error) {
+		l, err := client.AppsV1().Deployments(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return toObjects(l.Items), l.Continue, nil
+	}},
+	{name: "statefulsets", kind: schema.GroupKind{Group: "apps", Kind: "StatefulSet"}, namespaced: true, list: func(ctx context.Context, client kubernetes.Interface, namespace string, opts metaV1.ListOptions) ([]k8sutil.Object, string, error) {
+		l, err := client.AppsV1().StatefulSets(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return toObjects(l.Items), l.Continue, nil
+	}},
+	{name: "daemonsets", kind: schema.GroupKind{Group: "apps", Kind: "DaemonSet"}, namespaced: true, list: func(ctx context.Context, client kubernetes.Interface, namespace string, opts metaV1.ListOptions) ([]k8sutil.Object, string, error) {
+		l, err := client.AppsV1().DaemonSets(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return toObjects(l.Items), l.Continue, nil
+	}},
+	{name: "replicasets", kind: schema.GroupKind{Group: "apps", Kind: "ReplicaSet"}, namespaced: true, list: func(ctx context.Context, client kubernetes.Interface, namespace string, opts metaV1.ListOptions) ([]k8sutil.Object, string, error) {
+		l, err := client.AppsV1().ReplicaSets(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return toObjects(l.Items), l.Continue, nil
+	}},
+	{name: "jobs", kind: schema.GroupKind{Group: "batch", Kind: "Job"}, namespaced: true, list: func(ctx context.Context, client kubernetes.Interface, namespace string, opts metaV1.ListOptions) ([]k8sutil.Object, string, error) {
+		l, err := client.BatchV1().Jobs(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return toObjects(l.Items), l.Continue, nil
+	}},
+	{name: "cronjobs", kind: schema.GroupKind{Group: "batch", Kind: "CronJob"}, namespaced: true, list: func(ctx context.Context, client kubernetes.Interface, namespace string, opts metaV1.ListOptions) ([]k8sutil.Object, string, error) {
+		l, err := client.BatchV1().CronJobs(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return toObjects(l.Items), l.Continue, nil
+	}},
+	{name: "horizontalpodautoscalers", kind: schema.GroupKind{Group: "autoscaling", Kind: "HorizontalPodAutoscaler"}, namespaced: true, list: func(ctx context.Context, client kubernetes.Interface, namespace string, opts metaV1.ListOptions) ([]k8sutil.Object, string, error) {
+		l, err := client.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return toObjects(l.Items), l.Continue, nil
+	}},
+	{name: "ingresses", kind: schema.GroupKind{Group: "networking.k8s.io", Kind: "Ingress"}, namespaced: true, list: func(ctx context.Context, client kubernetes.Interface, namespace string, opts metaV1.ListOptions) ([]k8sutil.Object, string, error) {
+		l, err := client.NetworkingV1().Ingresses(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return toObjects(l.Items), l.Continue, nil
+	}},
+	{name: "networkpolicies", kind: schema.GroupKind{Group: "networking.k8s.io", Kind: "NetworkPolicy"}, namespaced: true, list: func(ctx context.Context, client kubernetes.Interface, namespace string, opts metaV1.ListOptions) ([]k8sutil.Object, string, error) {
+		l, err := client.NetworkingV1().NetworkPolicies(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return toObjects(l.Items), l.Continue, nil
+	}},
+	{name: "poddisruptionbudgets", kind: schema.GroupKind{Group: "policy", Kind: "PodDisruptionBudget"}, namespaced: true, list: func(ctx context.Context, client kubernetes.Interface, namespace string, opts metaV1.ListOptions) ([]k8sutil.Object, string, error) {
+		l, err := client.PolicyV1().PodDisruptionBudgets(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return toObjects(l.Items), l.Continue, nil
+	}},
+	{name: "roles", kind: schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "Role"}, namespaced: true, list: func(ctx context.Context, client kubernetes.Interface, namespace string, opts metaV1.ListOptions) ([]k8sutil.Object, string, error) {
+		l, err := client.RbacV1().Roles(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return toObjects(l.Items), l.Continue, nil
+	}},
+	{name: "rolebindings", kind: schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "RoleBinding"}, namespaced: true, list: func(ctx context.Context, client kubernetes.Interface, namespace string, opts metaV1.ListOptions) ([]k8sutil.Object, string, error) {
+		l, err := client.RbacV1().RoleBindings(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return toObjects(l.Items), l.Continue, nil
+	}},
+	{name: "clusterroles", kind: schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}, list: func(ctx context.Context, client kubernetes.Interface, _ string, opts metaV1.ListOptions) ([]k8sutil.Object, string, error) {
+		l, err := client.RbacV1().ClusterRoles().List(ctx, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return toObjects(l.Items), l.Continue, nil
+	}},
+	{name: "clusterrolebindings", kind: schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}, list: func(ctx context.Context, client kubernetes.Interface, _ string, opts metaV1.ListOptions) ([]k8sutil.Object, string, error) {
+		l, err := client.RbacV1().ClusterRoleBindings().List(ctx, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return toObjects(l.Items), l.Continue, nil
+	}},
+}
+
+// toObjects converts the items of a typed list into objects.
+func toObjects[T any, PT interface {
+	*T
+	k8sutil.Object
+}](items []T) []k8sutil.Object {
+	objs := make([]k8sutil.Object, 0, len(items))
+	for i := range items {
+		objs = append(objs, PT(&items[i]))
+	}
+	return objs
+}
+
+// CreateContextsFromCluster creates contexts from the objects that are deployed in a cluster.
+// Objects are linted as they are stored in the cluster, so defaulted fields are set and status is present.
+func CreateContextsFromCluster(ctx context.Context, client kubernetes.Interface, options ClusterOptions) ([]LintContext, error) {
+	defer options.Stats.record(LoaderCluster, time.Now())
+	contextsByKey := make(map[string]*lintContextImpl)
+	contextFor := func(namespace string) *lintContextImpl {
+		key := ""
+		if options.ContextPerNamespace {
+			key = namespace
+		}
+		lintCtx := contextsByKey[key]
+		if lintCtx == nil {
+			lintCtx = newCtx(options.Options)
+			contextsByKey[key] = lintCtx
+		}
+		return lintCtx
+	}
+
+	// All the resources are listed first, so that objects are only skipped if their controller was listed.
+	type listedResource struct {
+		clusterResource
+		objs []k8sutil.Object
+	}
+	var listed []listedResource
+	listedKinds := make(map[schema.GroupKind]bool)
+	for _, resource := range clusterResources {
+		if !resource.namespaced && options.Namespace != "" {
+			continue
+		}
+		objs, err := listAll(ctx, client, resource, options.Namespace)
+		if k8sErrors.IsForbidden(err) {
+			if options.Warn != nil {
+				options.Warn(fmt.Sprintf("skipping %s, which cannot be listed: %v", resource.name, err))
+			}
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("listing %s: %w", resource.name, err)
+		}
+		listed = append(listed, listedResource{clusterResource: resource, objs: objs})
+		listedKinds[resource.kind] = true
+	}
+
+	for _, resource := range listed {
+		for _, obj := range resource.objs {
+			if controller := metaV1.GetControllerOf(obj); controller != nil && listedKinds[controllerKind(controller)] {
+				continue
+			}
+			namespace := obj.GetNamespace()
+			if !resource.namespaced {
+				namespace = clusterScopedNamespace
+			}
+			lintCtx := contextFor(namespace)
+			filePath := path.Join(ClusterFilePathPrefix, namespace, resource.name, obj.GetName())
+			if err := setObjectKind(obj); err != nil {
+				lintCtx.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: filePath}, LoadErr: err})
+				continue
+			}
+			// Managed fields are bookkeeping of the API server, and would only add noise to the rendered object.
+			obj.SetManagedFields(nil)
+			raw, err := yaml.Marshal(obj)
+			if err != nil {
+				lintCtx.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: filePath}, LoadErr: err})
+				continue
+			}
+			lintCtx.addObjects(Object{
+				Metadata:  ObjectMetadata{FilePath: filePath, Raw: raw},
+				K8sObject: obj,
+			})
+		}
+	}
+
+	keys := make([]string, 0, len(contextsByKey))
+	for key := range contextsByKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	contexts := make([]LintContext, 0, len(keys))
+	for _, key := range keys {
+		contexts = append(contexts, contextsByKey[key])
+	}
+	return contexts, nil
+}
+
+// listAll lists all the objects of the given resource, following continuation tokens.
+func listAll(ctx context.Context, client kubernetes.Interface, resource clusterResource, namespace string) ([]k8sutil.Object, error) {
+	var all []k8sutil.Object
+	opts := metaV1.ListOptions{Limit: listPageSize}
+	for {
+		objs, continueToken, err := resource.list(ctx, client, namespace, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, objs...)
+		if continueToken == "" {
+			return all, nil
+		}
+		opts.Continue = continueToken
+	}
+}
+
+// controllerKind returns the group and kind of the given controller.
+func controllerKind(controller *metaV1.OwnerReference) schema.GroupKind {
+	return schema.FromAPIVersionAndKind(controller.APIVersion, controller.Kind).GroupKind()
+}
+
+// setObjectKind sets the GroupVersionKind of a typed object, which the API server clears in list results.
+func setObjectKind(obj k8sutil.Object) error {
+	gvks, _, err := scheme.Scheme.ObjectKinds(obj)
+	if err != nil {
+		return fmt.Errorf("determining kind: %w", err)
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvks[0])
+	return nil
+}
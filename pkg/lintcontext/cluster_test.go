@@ -0,0 +1,115 @@
+package lintcontext
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	rbacV1 "k8s.io/api/rbac/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newFakeClusterClient() *fake.Clientset {
+	isController := true
+	return fake.NewClientset(
+		&appsV1.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "team-a"}},
+		&v1.Service{ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "team-a"}},
+		&v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "standalone", Namespace: "team-b"}},
+		&v1.Pod{ObjectMeta: metaV1.ObjectMeta{
+			Name:      "web-1234",
+			Namespace: "team-a",
+			OwnerReferences: []metaV1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-12", Controller: &isController},
+			},
+		}},
+		&v1.Pod{ObjectMeta: metaV1.ObjectMeta{
+			Name:      "operated",
+			Namespace: "team-b",
+			OwnerReferences: []metaV1.OwnerReference{
+				{APIVersion: "example.com/v1", Kind: "Database", Name: "db", Controller: &isController},
+			},
+		}},
+		&rbacV1.ClusterRole{ObjectMeta: metaV1.ObjectMeta{Name: "admin"}},
+	)
+}
+
+func objectPaths(lintCtx LintContext) []string {
+	var paths []string
+	for _, obj := range lintCtx.Objects() {
+		paths = append(paths, obj.Metadata.FilePath)
+	}
+	return paths
+}
+
+func TestCreateContextsFromCluster(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("whole cluster", func(t *testing.T) {
+		lintCtxs, err := CreateContextsFromCluster(ctx, newFakeClusterClient(), ClusterOptions{})
+		require.NoError(t, err)
+		require.Len(t, lintCtxs, 1)
+		assert.ElementsMatch(t, []string{
+			"<cluster>/team-a/deployments/web",
+			"<cluster>/team-a/services/web",
+			"<cluster>/team-b/pods/standalone",
+			"<cluster>/team-b/pods/operated",
+			"<cluster>/_/clusterroles/admin",
+		}, objectPaths(lintCtxs[0]))
+
+		for _, obj := range lintCtxs[0].Objects() {
+			assert.NotEmpty(t, obj.Metadata.Raw)
+			if _, isDeployment := obj.K8sObject.(*appsV1.Deployment); isDeployment {
+				assert.Equal(t, schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, obj.K8sObject.GetObjectKind().GroupVersionKind())
+			}
+		}
+	})
+
+	t.Run("context per namespace", func(t *testing.T) {
+		lintCtxs, err := CreateContextsFromCluster(ctx, newFakeClusterClient(), ClusterOptions{ContextPerNamespace: true})
+		require.NoError(t, err)
+		require.Len(t, lintCtxs, 3)
+		assert.Equal(t, []string{"<cluster>/_/clusterroles/admin"}, objectPaths(lintCtxs[0]))
+		assert.ElementsMatch(t, []string{"<cluster>/team-a/deployments/web", "<cluster>/team-a/services/web"}, objectPaths(lintCtxs[1]))
+		assert.ElementsMatch(t, []string{"<cluster>/team-b/pods/standalone", "<cluster>/team-b/pods/operated"}, objectPaths(lintCtxs[2]))
+	})
+
+	t.Run("single namespace", func(t *testing.T) {
+		lintCtxs, err := CreateContextsFromCluster(ctx, newFakeClusterClient(), ClusterOptions{Namespace: "team-b"})
+		require.NoError(t, err)
+		require.Len(t, lintCtxs, 1)
+		assert.ElementsMatch(t, []string{"<cluster>/team-b/pods/standalone", "<cluster>/team-b/pods/operated"}, objectPaths(lintCtxs[0]))
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		client := newFakeClusterClient()
+		client.PrependReactor("list", "services", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, assert.AnError
+		})
+		_, err := CreateContextsFromCluster(ctx, client, ClusterOptions{})
+		assert.ErrorContains(t, err, "listing services")
+	})
+
+	t.Run("forbidden resource", func(t *testing.T) {
+		client := newFakeClusterClient()
+		client.PrependReactor("list", "replicasets", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, k8sErrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "replicasets"}, "", assert.AnError)
+		})
+		var warnings []string
+		lintCtxs, err := CreateContextsFromCluster(ctx, client, ClusterOptions{Warn: func(msg string) {
+			warnings = append(warnings, msg)
+		}})
+		require.NoError(t, err)
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "skipping replicasets")
+		// The ReplicaSet that controls the pod was not listed, so the pod is linted on its own.
+		assert.Contains(t, objectPaths(lintCtxs[0]), "<cluster>/team-a/pods/web-1234")
+	})
+}
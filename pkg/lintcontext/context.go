@@ -13,6 +13,15 @@ import (
 type ObjectMetadata struct {
 	FilePath string
 	Raw      []byte `json:"-"`
+	// LineOffset is the number of lines in the source file that precede Raw.
+	// It is used to translate positions within Raw into positions within the file.
+	LineOffset int `json:"-"`
+	// SourceMap, if set, translates positions within the file into positions within the template that the file
+	// was rendered from, which is the file at FilePath.
+	SourceMap *SourceMap `json:"-"`
+	// Variant is the name of the Helm values variant that the object was rendered with, if any.
+	// If the same finding is reported for several variants, their names are joined with ", ".
+	Variant string `json:",omitempty"`
 }
 
 // An Object references an object that is loaded from a YAML file.
@@ -78,6 +87,9 @@ type lintContextImpl struct {
 	invalidObjects []InvalidObject
 
 	customDecoder runtime.Decoder
+	helm          HelmOptions
+	helmCharts    map[string]HelmOptions
+	helmVariant   string
 }
 
 // Objects returns the (valid) objects loaded from this LintContext.
@@ -100,9 +112,18 @@ func (l *lintContextImpl) addInvalidObjects(objs ...InvalidObject) {
 	l.invalidObjects = append(l.invalidObjects, objs...)
 }
 
+// newHelmCtx returns a ready-to-use, empty, lintContextImpl for rendering the given Helm variant.
+func newHelmCtx(options Options, variant string) *lintContextImpl {
+	ctx := newCtx(options)
+	ctx.helmVariant = variant
+	return ctx
+}
+
 // new returns a ready-to-use, empty, lintContextImpl.
 func newCtx(options Options) *lintContextImpl {
 	return &lintContextImpl{
 		customDecoder: options.CustomDecoder,
+		helm:          options.Helm,
+		helmCharts:    options.HelmCharts,
 	}
 }
@@ -0,0 +1,64 @@
+package lintcontext
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+const groupContextKeyPrefix = "group:"
+
+// ContextGroup is a named set of paths whose objects are linted as a single context, so that checks that
+// relate objects to each other (such as dangling-service) see all of them.
+type ContextGroup struct {
+	Name string
+	// Paths are glob patterns (which support **) of files and directories, matched against absolute paths.
+	// A pattern that matches a directory matches all the files in it.
+	Paths []string
+}
+
+// matches returns whether the given path, or one of its parent directories, matches a path of the group.
+func (g ContextGroup) matches(path string) (bool, error) {
+	for _, pattern := range g.Paths {
+		for candidate := path; ; candidate = filepath.Dir(candidate) {
+			match, err := doublestar.PathMatch(pattern, candidate)
+			if err != nil {
+				return false, fmt.Errorf("could not match pattern %s: %w", pattern, err)
+			}
+			if match {
+				return true, nil
+			}
+			if filepath.Dir(candidate) == candidate {
+				break
+			}
+		}
+	}
+	return false, nil
+}
+
+// contextKey returns the key of the context that the objects loaded from the given path for the given Helm variant
+// are added to. This is defaultKey, unless all objects are linted as a single context or the path belongs to
+// a context group, in which case each Helm variant is linted as a separate context.
+func (o Options) contextKey(path, defaultKey, variant string) (string, error) {
+	if o.SingleContext {
+		return helmContextKey(groupContextKeyPrefix, variant), nil
+	}
+	absPath := path
+	if path != ReadFromStdin {
+		var err error
+		if absPath, err = filepath.Abs(path); err != nil {
+			return "", fmt.Errorf("could not get absolute path for %s: %w", path, err)
+		}
+	}
+	for _, group := range o.ContextGroups {
+		match, err := group.matches(absPath)
+		if err != nil {
+			return "", err
+		}
+		if match {
+			return helmContextKey(groupContextKeyPrefix+group.Name, variant), nil
+		}
+	}
+	return defaultKey, nil
+}
@@ -1,12 +1,14 @@
 package lintcontext
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"golang.stackrox.io/kube-linter/pkg/pathutil"
 
@@ -29,31 +31,72 @@ type Options struct {
 	// CustomDecoder allows users to supply a non-default decoder to parse k8s objects. This can be used
 	// to allow the linter to create contexts for k8s custom resources
 	CustomDecoder runtime.Decoder
+	// Helm configures how Helm charts are rendered.
+	Helm HelmOptions
+	// HelmCharts overrides Helm for the charts at the given paths, which must be absolute.
+	HelmCharts map[string]HelmOptions
+	// ContextGroups lint the objects of the files that match the paths of a group as a single context,
+	// regardless of the directories that they are in.
+	ContextGroups []ContextGroup
+	// SingleContext, if set, lints all the objects as a single context. It takes precedence over ContextGroups.
+	SingleContext bool
+	// Stats, if set, records the time spent loading objects, by loader.
+	Stats *LoadStats
 }
 
 // CreateContexts creates a context. Each context contains a set of files that should be linted
 // as a group.
-// Currently, each directory of Kube YAML files (or Helm charts) are treated as a separate context.
-// TODO: Figure out if it's useful to allow people to specify that files spanning different directories
-// should be treated as being in the same context.
+// By default, each directory of Kube YAML files (or Helm charts, or Kustomize directories) is treated as
+// a separate context. Use CreateContextsWithOptions with ContextGroups or SingleContext to lint files that span
+// different directories as a single context.
 func CreateContexts(ignorePaths []string, filesOrDirs ...string) ([]LintContext, error) {
 	return CreateContextsWithOptions(Options{}, ignorePaths, filesOrDirs...)
 }
 
 // CreateContextsWithOptions creates a context with additional Options
 func CreateContextsWithOptions(options Options, ignorePaths []string, filesOrDirs ...string) ([]LintContext, error) {
+	return CreateContextsWithContext(context.Background(), options, ignorePaths, filesOrDirs...)
+}
+
+// CreateContextsWithContext is like CreateContextsWithOptions, but stops loading files, charts and Kustomize
+// directories when ctx is done, in which case it returns the error of ctx.
+func CreateContextsWithContext(ctx context.Context, options Options, ignorePaths []string, filesOrDirs ...string) ([]LintContext, error) {
 	contextsByDir := make(map[string]*lintContextImpl)
+	// contextFor returns the context that the objects loaded from the given path are added to, which is the one
+	// with the given key unless the path belongs to a context group.
+	contextFor := func(path, key, variant string) (*lintContextImpl, error) {
+		key, err := options.contextKey(path, key, variant)
+		if err != nil {
+			return nil, err
+		}
+		lintCtx := contextsByDir[key]
+		if lintCtx == nil {
+			lintCtx = newHelmCtx(options, variant)
+			contextsByDir[key] = lintCtx
+		}
+		return lintCtx, nil
+	}
+	// loadedDirs are the Helm charts, Helm chart archives and Kustomize directories that have been loaded.
+	loadedDirs := set.NewStringSet()
 fileOrDirsLoop:
 	for _, fileOrDir := range filesOrDirs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if fileOrDir == ReadFromStdin {
-			if _, alreadyExists := contextsByDir[ReadFromStdin]; alreadyExists {
+			if !loadedDirs.Add(ReadFromStdin) {
 				continue
 			}
-			ctx := newCtx(options)
-			if err := ctx.loadObjectsFromReader("<standard input>", os.Stdin); err != nil {
+			lintCtx, err := contextFor(ReadFromStdin, ReadFromStdin, "")
+			if err != nil {
+				return nil, err
+			}
+			start := time.Now()
+			err = lintCtx.loadObjectsFromReader("<standard input>", os.Stdin)
+			options.Stats.record(LoaderFiles, start)
+			if err != nil {
 				return nil, err
 			}
-			contextsByDir[ReadFromStdin] = ctx
 			continue
 		}
 
@@ -73,7 +116,17 @@ fileOrDirsLoop:
 			if walkErr != nil {
 				return walkErr
 			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 
+			// Path has already been loaded, possibly through another argument. Skip.
+			if loadedDirs.Contains(currentPath) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
 			if _, exists := contextsByDir[currentPath]; exists {
 				return nil
 			}
@@ -94,49 +147,66 @@ fileOrDirsLoop:
 
 			if !info.IsDir() {
 				if strings.HasSuffix(strings.ToLower(currentPath), ".tgz") {
-					ctx := newCtx(options)
-					if err := ctx.loadObjectsFromTgzHelmChart(currentPath, ignorePaths); err != nil {
-						return fmt.Errorf("loading helm chart %s: %w", currentPath, err)
+					loadedDirs.Add(currentPath)
+					for _, variant := range options.helmVariants(currentPath) {
+						lintCtx, err := contextFor(currentPath, helmContextKey(currentPath, variant), variant)
+						if err != nil {
+							return err
+						}
+						start := time.Now()
+						err = lintCtx.loadObjectsFromTgzHelmChart(ctx, currentPath, ignorePaths)
+						options.Stats.record(LoaderHelm, start)
+						if err != nil {
+							return fmt.Errorf("loading helm chart %s: %w", currentPath, err)
+						}
 					}
-
-					contextsByDir[currentPath] = ctx
 					return nil
 				}
 
 				dirName := filepath.Dir(currentPath)
 				// Load a file only if it ends in .yaml, OR it was explicitly passed by the user.
 				if knownYAMLExtensions.Contains(strings.ToLower(filepath.Ext(currentPath))) || fileOrDir == currentPath {
-					ctx := contextsByDir[dirName]
-					if ctx == nil {
-						ctx = newCtx(options)
-						contextsByDir[dirName] = ctx
+					lintCtx, err := contextFor(currentPath, dirName, "")
+					if err != nil {
+						return err
 					}
-					if err := ctx.loadObjectsFromYAMLFile(currentPath, info); err != nil {
+					start := time.Now()
+					err = lintCtx.loadObjectsFromYAMLFile(currentPath, info)
+					options.Stats.record(LoaderFiles, start)
+					if err != nil {
 						return err
 					}
 				}
 				return nil
 			}
 			if isHelm, _ := chartutil.IsChartDir(currentPath); isHelm {
-				// Path has already been loaded, possibly through another argument. Skip.
-				if _, alreadyExists := contextsByDir[currentPath]; alreadyExists {
-					return nil
-				}
-				ctx := newCtx(options)
-				contextsByDir[currentPath] = ctx
-				if err := ctx.loadObjectsFromHelmChart(currentPath, ignorePaths); err != nil {
-					return fmt.Errorf("loading helm chart: %w", err)
+				loadedDirs.Add(currentPath)
+				for _, variant := range options.helmVariants(currentPath) {
+					lintCtx, err := contextFor(currentPath, helmContextKey(currentPath, variant), variant)
+					if err != nil {
+						return err
+					}
+					start := time.Now()
+					err = lintCtx.loadObjectsFromHelmChart(ctx, currentPath, ignorePaths)
+					options.Stats.record(LoaderHelm, start)
+					if err != nil {
+						return fmt.Errorf("loading helm chart: %w", err)
+					}
 				}
 				return filepath.SkipDir
 			}
 			if isKustomizeDir(currentPath) {
-				// Path has already been loaded, possibly through another argument. Skip.
-				if _, alreadyExists := contextsByDir[currentPath]; alreadyExists {
-					return nil
+				loadedDirs.Add(currentPath)
+				lintCtx, err := contextFor(currentPath, currentPath, "")
+				if err != nil {
+					return err
+				}
+				start := time.Now()
+				err = lintCtx.loadObjectsFromKustomize(ctx, currentPath)
+				options.Stats.record(LoaderKustomize, start)
+				if err != nil {
+					return err
 				}
-				ctx := newCtx(options)
-				contextsByDir[currentPath] = ctx
-				ctx.loadObjectsFromKustomize(currentPath)
 				return filepath.SkipDir
 			}
 			return nil
@@ -162,13 +232,24 @@ fileOrDirsLoop:
 // See https://github.com/stackrox/kube-linter/pull/173
 func CreateContextsFromHelmArchive(ignorePaths []string, fileName string, tgzReader io.Reader) ([]LintContext, error) {
 	ctx := newCtx(Options{})
-	if err := ctx.readObjectsFromTgzHelmChart(fileName, tgzReader, ignorePaths); err != nil {
+	if err := ctx.readObjectsFromTgzHelmChart(context.Background(), fileName, tgzReader, ignorePaths); err != nil {
 		return nil, err
 	}
 
 	return []LintContext{ctx}, nil
 }
 
+// CreateContextFromReader creates a context from the YAML (or JSON) objects read from the given reader.
+// The fileName is only used to identify the objects in reports.
+func CreateContextFromReader(options Options, fileName string, r io.Reader) (LintContext, error) {
+	defer options.Stats.record(LoaderFiles, time.Now())
+	ctx := newCtx(options)
+	if err := ctx.loadObjectsFromReader(fileName, r); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
 // isKustomizeDir checks if the given directory contains a kustomization.yaml or kustomization.yml file.
 func isKustomizeDir(dirName string) bool {
 	for _, fileName := range kustomizationFileNames {
@@ -1,10 +1,14 @@
 package lintcontext
 
 import (
+	"context"
 	"fmt"
+	"maps"
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"golang.stackrox.io/kube-linter/pkg/pathutil"
@@ -313,3 +317,68 @@ func TestHelmChartWithMalformedDependencies(t *testing.T) {
 	require.Len(t, lintCtx.InvalidObjects(), 1)
 	assert.Contains(t, lintCtx.InvalidObjects()[0].LoadErr.Error(), "processing dependencies")
 }
+
+func TestCreateContextsWithGroups(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"frontend/service.yaml", "frontend/deployment.yaml", "backend/service.yaml", "shared/deployment.yaml", "other/service.yaml"} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		objectName := strings.ReplaceAll(strings.TrimSuffix(name, ".yaml"), "/", "-")
+		require.NoError(t, os.WriteFile(path, []byte(fmt.Sprintf("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: %s\n", objectName)), 0o600))
+	}
+
+	objectNames := func(lintCtxs []LintContext) [][]string {
+		var names [][]string
+		for _, lintCtx := range lintCtxs {
+			var ctxNames []string
+			for _, object := range lintCtx.Objects() {
+				ctxNames = append(ctxNames, object.K8sObject.GetName())
+			}
+			names = append(names, ctxNames)
+		}
+		return names
+	}
+
+	lintCtxs, err := CreateContextsWithOptions(Options{ContextGroups: []ContextGroup{
+		{Name: "app", Paths: []string{filepath.Join(dir, "frontend"), filepath.Join(dir, "backend"), filepath.Join(dir, "**/deployment.yaml")}},
+	}}, nil, dir)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"other-service"},
+		{"backend-service", "frontend-deployment", "frontend-service", "shared-deployment"},
+	}, objectNames(lintCtxs))
+
+	lintCtxs, err = CreateContextsWithOptions(Options{SingleContext: true}, nil, filepath.Join(dir, "frontend"), filepath.Join(dir, "other"))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"frontend-deployment", "frontend-service", "other-service"}}, objectNames(lintCtxs))
+
+	_, err = CreateContextsWithOptions(Options{ContextGroups: []ContextGroup{{Name: "invalid", Paths: []string{"["}}}}, nil, dir)
+	assert.ErrorContains(t, err, "could not match pattern")
+}
+
+func TestCreateContextsLoadsChartsOnce(t *testing.T) {
+	for _, chart := range []string{chartDirectory, chartTarball} {
+		t.Run(chart, func(t *testing.T) {
+			lintCtxs, err := CreateContexts(nil, chart, chart)
+			require.NoError(t, err)
+			checkObjectPaths(t, verifyAndGetContext(t, lintCtxs).Objects(), map[string]string{chartDirectory: chart, chartTarball: path.Join(chart, "mychart")}[chart])
+		})
+	}
+}
+
+func TestCreateContextsWithCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := CreateContextsWithContext(ctx, Options{}, nil, chartDirectory)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCreateContextsRecordsLoadStats(t *testing.T) {
+	stats := &LoadStats{}
+	_, err := CreateContextsWithOptions(Options{Stats: stats}, nil, chartDirectory, chartTarball, "../../tests/testdata/splunk.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, []string{LoaderFiles, LoaderHelm}, slices.Sorted(maps.Keys(stats.Durations)))
+	for loader, duration := range stats.Durations {
+		assert.Positive(t, duration, loader)
+	}
+}
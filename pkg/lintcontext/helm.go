@@ -0,0 +1,162 @@
+package lintcontext
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli/values"
+)
+
+const (
+	defaultHelmReleaseName = "test-release"
+	defaultHelmNamespace   = "default"
+)
+
+// HelmOptions configure how Helm charts are rendered.
+type HelmOptions struct {
+	// ValuesFiles are merged, in order, over the values.yaml of the chart.
+	// Relative paths are resolved against the directory of the chart.
+	ValuesFiles []string
+	// SetValues are values in the format of helm --set (e.g. a.b=c), applied after ValuesFiles.
+	SetValues []string
+	// ReleaseName is the name of the release. Defaults to "test-release".
+	ReleaseName string
+	// Namespace is the namespace of the release. Defaults to "default".
+	Namespace string
+	// KubeVersion is the Kubernetes version reported to the templates, e.g. "1.29.0".
+	// Defaults to the version that Helm was built against.
+	KubeVersion string
+	// APIVersions are additional API versions reported to the templates through .Capabilities.APIVersions.
+	APIVersions []string
+	// Variants, if set, render the chart once per entry, each merged over the options above.
+	// Each variant is loaded into its own context, and its name is recorded in ObjectMetadata.Variant.
+	// Variants cannot themselves have variants.
+	Variants map[string]HelmOptions
+}
+
+// MergedWith returns the options overridden by the given ones. Values files, set values and API versions
+// are appended, so that they take precedence, and the other fields are replaced if they are set.
+func (o HelmOptions) MergedWith(override HelmOptions) HelmOptions {
+	merged := HelmOptions{
+		ValuesFiles: append(append([]string{}, o.ValuesFiles...), override.ValuesFiles...),
+		SetValues:   append(append([]string{}, o.SetValues...), override.SetValues...),
+		ReleaseName: o.ReleaseName,
+		Namespace:   o.Namespace,
+		KubeVersion: o.KubeVersion,
+		APIVersions: append(append([]string{}, o.APIVersions...), override.APIVersions...),
+		Variants:    o.Variants,
+	}
+	if len(override.Variants) > 0 {
+		merged.Variants = override.Variants
+	}
+	if override.ReleaseName != "" {
+		merged.ReleaseName = override.ReleaseName
+	}
+	if override.Namespace != "" {
+		merged.Namespace = override.Namespace
+	}
+	if override.KubeVersion != "" {
+		merged.KubeVersion = override.KubeVersion
+	}
+	return merged
+}
+
+// chartHelmOptions returns the options for the chart at the given path, before any variant is applied.
+func chartHelmOptions(global HelmOptions, charts map[string]HelmOptions, chartPath string) HelmOptions {
+	if absPath, err := filepath.Abs(chartPath); err == nil {
+		chartPath = absPath
+	}
+	if override, ok := charts[chartPath]; ok {
+		return global.MergedWith(override)
+	}
+	return global
+}
+
+// helmVariants returns the sorted names of the variants that the chart at the given path is rendered with.
+// It returns a single empty name if the chart is rendered only once.
+func (o Options) helmVariants(chartPath string) []string {
+	variants := chartHelmOptions(o.Helm, o.HelmCharts, chartPath).Variants
+	if len(variants) == 0 {
+		return []string{""}
+	}
+	names := make([]string, 0, len(variants))
+	for name := range variants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// helmContextKey identifies the context of a variant of the chart at the given path.
+func helmContextKey(chartPath, variant string) string {
+	if variant == "" {
+		return chartPath
+	}
+	return chartPath + "@" + variant
+}
+
+// helmOptionsFor returns the options to render the chart at the given path with, for the variant of this context.
+func (l *lintContextImpl) helmOptionsFor(chartPath string) HelmOptions {
+	options := chartHelmOptions(l.helm, l.helmCharts, chartPath)
+	if l.helmVariant != "" {
+		options = options.MergedWith(options.Variants[l.helmVariant])
+	}
+	options.Variants = nil
+	return options
+}
+
+// helmMetadata returns the metadata of objects rendered from a chart in this context.
+func (l *lintContextImpl) helmMetadata(filePath string) ObjectMetadata {
+	return ObjectMetadata{FilePath: filePath, Variant: l.helmVariant}
+}
+
+// mergeValues merges the user-supplied values over the given chart values.
+func (o HelmOptions) mergeValues(chartValues map[string]interface{}, chartDir string) (map[string]interface{}, error) {
+	if len(o.ValuesFiles) == 0 && len(o.SetValues) == 0 {
+		return chartValues, nil
+	}
+	valuesFiles := make([]string, 0, len(o.ValuesFiles))
+	for _, valuesFile := range o.ValuesFiles {
+		if !filepath.IsAbs(valuesFile) {
+			valuesFile = filepath.Join(chartDir, valuesFile)
+		}
+		valuesFiles = append(valuesFiles, valuesFile)
+	}
+	valOpts := &values.Options{ValueFiles: valuesFiles, Values: o.SetValues}
+	userValues, err := valOpts.MergeValues(nil)
+	if err != nil {
+		return nil, fmt.Errorf("loading values: %w", err)
+	}
+	return chartutil.CoalesceTables(userValues, chartValues), nil
+}
+
+func (o HelmOptions) releaseOptions() chartutil.ReleaseOptions {
+	releaseOptions := chartutil.ReleaseOptions{Name: o.ReleaseName, Namespace: o.Namespace}
+	if releaseOptions.Name == "" {
+		releaseOptions.Name = defaultHelmReleaseName
+	}
+	if releaseOptions.Namespace == "" {
+		releaseOptions.Namespace = defaultHelmNamespace
+	}
+	return releaseOptions
+}
+
+// capabilities returns the capabilities to render charts with, or nil to use Helm's defaults.
+func (o HelmOptions) capabilities() (*chartutil.Capabilities, error) {
+	if o.KubeVersion == "" && len(o.APIVersions) == 0 {
+		return nil, nil
+	}
+	caps := chartutil.DefaultCapabilities.Copy()
+	if o.KubeVersion != "" {
+		kubeVersion, err := chartutil.ParseKubeVersion(o.KubeVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kube version %q: %w", o.KubeVersion, err)
+		}
+		caps.KubeVersion = *kubeVersion
+	}
+	// Copy does not copy the API versions, so build a new slice rather than appending to the shared defaults.
+	caps.APIVersions = append(append(chartutil.VersionSet{}, caps.APIVersions...), o.APIVersions...)
+	return caps, nil
+}
@@ -0,0 +1,110 @@
+package lintcontext
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsV1 "k8s.io/api/apps/v1"
+)
+
+const valuesChartDir = "../../tests/testdata/values-chart"
+
+func renderValuesChart(t *testing.T, options Options) *appsV1.Deployment {
+	lintCtxs, err := CreateContextsWithOptions(options, nil, valuesChartDir)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 1)
+	require.Empty(t, lintCtxs[0].InvalidObjects())
+	require.Len(t, lintCtxs[0].Objects(), 1)
+	deployment, ok := lintCtxs[0].Objects()[0].K8sObject.(*appsV1.Deployment)
+	require.True(t, ok)
+	return deployment
+}
+
+func TestHelmOptions(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		deployment := renderValuesChart(t, Options{})
+		assert.Equal(t, "test-release-app", deployment.Name)
+		assert.Equal(t, "default", deployment.Namespace)
+		assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+		assert.NotContains(t, deployment.Labels, "example-api")
+	})
+
+	t.Run("custom options", func(t *testing.T) {
+		deployment := renderValuesChart(t, Options{Helm: HelmOptions{
+			ValuesFiles: []string{"values-prod.yaml"},
+			SetValues:   []string{"image=nginx:1.27"},
+			ReleaseName: "prod",
+			Namespace:   "payments",
+			KubeVersion: "1.29.3",
+			APIVersions: []string{"example.com/v1"},
+		}})
+		assert.Equal(t, "prod-app", deployment.Name)
+		assert.Equal(t, "payments", deployment.Namespace)
+		assert.Equal(t, int32(3), *deployment.Spec.Replicas)
+		assert.Equal(t, "nginx:1.27", deployment.Spec.Template.Spec.Containers[0].Image)
+		assert.Equal(t, "v1.29.3", deployment.Labels["kube-version"])
+		assert.Equal(t, "true", deployment.Labels["example-api"])
+	})
+
+	t.Run("per-chart options override global ones", func(t *testing.T) {
+		absChartDir, err := filepath.Abs(valuesChartDir)
+		require.NoError(t, err)
+		deployment := renderValuesChart(t, Options{
+			Helm: HelmOptions{ReleaseName: "global", Namespace: "global"},
+			HelmCharts: map[string]HelmOptions{
+				absChartDir: {ReleaseName: "chart", SetValues: []string{"replicas=5"}},
+			},
+		})
+		assert.Equal(t, "chart-app", deployment.Name)
+		assert.Equal(t, "global", deployment.Namespace)
+		assert.Equal(t, int32(5), *deployment.Spec.Replicas)
+	})
+
+	t.Run("invalid kube version", func(t *testing.T) {
+		lintCtxs, err := CreateContextsWithOptions(Options{Helm: HelmOptions{KubeVersion: "not-a-version"}}, nil, valuesChartDir)
+		require.NoError(t, err)
+		require.Len(t, lintCtxs[0].InvalidObjects(), 1)
+		assert.Contains(t, lintCtxs[0].InvalidObjects()[0].LoadErr.Error(), "invalid kube version")
+	})
+}
+
+func TestHelmVariants(t *testing.T) {
+	absChartDir, err := filepath.Abs(valuesChartDir)
+	require.NoError(t, err)
+	options := Options{HelmCharts: map[string]HelmOptions{
+		absChartDir: {
+			ReleaseName: "app",
+			Variants: map[string]HelmOptions{
+				"prod":    {ValuesFiles: []string{"values-prod.yaml"}, Namespace: "production"},
+				"dev":     {ValuesFiles: []string{"values-dev.yaml"}},
+				"staging": {ValuesFiles: []string{"values-staging.yaml"}},
+			},
+		},
+	}}
+
+	lintCtxs, err := CreateContextsWithOptions(options, nil, valuesChartDir)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 3)
+
+	for i, expected := range []struct {
+		variant   string
+		namespace string
+		replicas  int32
+	}{
+		{variant: "dev", namespace: "default", replicas: 1},
+		{variant: "prod", namespace: "production", replicas: 3},
+		{variant: "staging", namespace: "default", replicas: 2},
+	} {
+		require.Empty(t, lintCtxs[i].InvalidObjects())
+		require.Len(t, lintCtxs[i].Objects(), 1)
+		obj := lintCtxs[i].Objects()[0]
+		assert.Equal(t, expected.variant, obj.Metadata.Variant)
+		deployment, ok := obj.K8sObject.(*appsV1.Deployment)
+		require.True(t, ok)
+		assert.Equal(t, "app-app", deployment.Name)
+		assert.Equal(t, expected.namespace, deployment.Namespace)
+		assert.Equal(t, expected.replicas, *deployment.Spec.Replicas)
+	}
+}
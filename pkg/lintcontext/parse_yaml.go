@@ -9,6 +9,7 @@ import (
 	"io"
 	"log"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -105,79 +106,120 @@ func (w nopWriter) Write(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
-func (l *lintContextImpl) renderHelmChart(dir string) (map[string]string, error) {
+func (l *lintContextImpl) renderHelmChart(dir string) (renderedChart, error) {
 	// Helm doesn't have great logging behaviour, and can spam stderr, so silence their logging.
 	// TODO: capture these logs.
 	log.SetOutput(nopWriter{})
 	defer log.SetOutput(os.Stderr)
 	chrt, err := loader.Load(dir)
 	if err != nil {
-		return nil, err
+		return renderedChart{}, err
 	}
 	if err := chrt.Validate(); err != nil {
-		return nil, err
+		return renderedChart{}, err
 	}
 	valOpts := &values.Options{ValueFiles: []string{filepath.Join(dir, "values.yaml")}}
 	values, err := valOpts.MergeValues(nil)
 	if err != nil {
-		return nil, fmt.Errorf("loading values.yaml file: %w", err)
+		return renderedChart{}, fmt.Errorf("loading values.yaml file: %w", err)
 	}
-	return l.renderValues(chrt, values)
+	return l.renderValues(chrt, values, dir, l.helmOptionsFor(dir))
 }
 
-func (l *lintContextImpl) renderValues(chrt *chart.Chart, values map[string]interface{}) (map[string]string, error) {
+// renderedChart is the output of rendering a Helm chart.
+type renderedChart struct {
+	// files maps the path of each template to its rendered contents.
+	files map[string]string
+	// sources maps the path of each template to its source.
+	sources map[string]string
+}
+
+// renderValues renders the chart with the given chart values, customized by the given options.
+// Relative values files in the options are resolved against chartDir.
+func (l *lintContextImpl) renderValues(chrt *chart.Chart, chartValues map[string]interface{}, chartDir string, options HelmOptions) (renderedChart, error) {
+	values, err := options.mergeValues(chartValues, chartDir)
+	if err != nil {
+		return renderedChart{}, err
+	}
+
 	// Process chart dependencies to handle import-values
 	if err := chartutil.ProcessDependencies(chrt, values); err != nil {
-		return nil, fmt.Errorf("processing dependencies: %w", err)
+		return renderedChart{}, fmt.Errorf("processing dependencies: %w", err)
 	}
 
-	valuesToRender, err := chartutil.ToRenderValues(chrt, values, chartutil.ReleaseOptions{Name: "test-release", Namespace: "default"}, nil)
+	caps, err := options.capabilities()
+	if err != nil {
+		return renderedChart{}, err
+	}
+	valuesToRender, err := chartutil.ToRenderValues(chrt, values, options.releaseOptions(), caps)
 	if err != nil {
-		return nil, err
+		return renderedChart{}, err
 	}
 
 	e := helmEngine.Engine{LintMode: true}
 	rendered, err := e.Render(chrt, valuesToRender)
 	if err != nil {
-		return nil, fmt.Errorf("failed to render: %w", err)
+		return renderedChart{}, fmt.Errorf("failed to render: %w", err)
 	}
 
-	return rendered, nil
+	return renderedChart{files: rendered, sources: templateSources(chrt)}, nil
 }
 
-func (l *lintContextImpl) loadObjectsFromHelmChart(dir string, ignorePaths []string) error {
+// templateSources returns the source of the templates of the chart and its dependencies, keyed like the output of the
+// Helm engine.
+func templateSources(chrt *chart.Chart) map[string]string {
+	sources := make(map[string]string)
+	var addTemplates func(c *chart.Chart)
+	addTemplates = func(c *chart.Chart) {
+		for _, t := range c.Templates {
+			if t != nil {
+				sources[path.Join(c.ChartFullPath(), t.Name)] = string(t.Data)
+			}
+		}
+		for _, dependency := range c.Dependencies() {
+			addTemplates(dependency)
+		}
+	}
+	addTemplates(chrt)
+	return sources
+}
+
+func (l *lintContextImpl) loadObjectsFromHelmChart(ctx context.Context, dir string, ignorePaths []string) error {
 	renderedFiles, err := l.renderHelmChart(dir)
 	if err != nil {
-		l.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: dir}, LoadErr: err})
+		l.addInvalidObjects(InvalidObject{Metadata: l.helmMetadata(dir), LoadErr: err})
 		return nil
 	}
 
 	// Paths returned by helm include redundant directory in front, therefore we strip it out.
-	return l.loadHelmRenderedTemplates(dir, normalizeDirectoryPaths(renderedFiles), ignorePaths)
+	return l.loadHelmRenderedTemplates(ctx, dir, renderedChart{
+		files:   normalizeDirectoryPaths(renderedFiles.files),
+		sources: normalizeDirectoryPaths(renderedFiles.sources),
+	}, ignorePaths)
 }
 
-func (l *lintContextImpl) loadObjectsFromTgzHelmChart(tgzFile string, ignorePaths []string) error {
+func (l *lintContextImpl) loadObjectsFromTgzHelmChart(ctx context.Context, tgzFile string, ignorePaths []string) error {
 	renderedFiles, err := l.renderTgzHelmChart(tgzFile)
 	if err != nil {
-		l.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: tgzFile}, LoadErr: err})
+		l.addInvalidObjects(InvalidObject{Metadata: l.helmMetadata(tgzFile), LoadErr: err})
 		return nil
 	}
-	return l.loadHelmRenderedTemplates(tgzFile, renderedFiles, ignorePaths)
+	return l.loadHelmRenderedTemplates(ctx, tgzFile, renderedFiles, ignorePaths)
 }
 
-func (l *lintContextImpl) renderTgzHelmChart(tgzFile string) (map[string]string, error) {
+func (l *lintContextImpl) renderTgzHelmChart(tgzFile string) (renderedChart, error) {
 	log.SetOutput(nopWriter{})
 	defer log.SetOutput(os.Stderr)
 
 	chrt, err := loader.LoadFile(tgzFile)
 	if err != nil {
-		return nil, err
+		return renderedChart{}, err
 	}
 
 	return l.renderChart(tgzFile, chrt)
 }
 
-func (l *lintContextImpl) loadObjectFromYAMLReader(filePath string, r *yaml.YAMLReader) error {
+func (l *lintContextImpl) loadObjectFromYAMLReader(filePath string, r *yaml.YAMLReader, locator *documentLocator) error {
 	doc, err := r.Read()
 	if err != nil {
 		return err
@@ -188,8 +230,11 @@ func (l *lintContextImpl) loadObjectFromYAMLReader(filePath string, r *yaml.YAML
 	}
 
 	metadata := ObjectMetadata{
-		FilePath: filePath,
-		Raw:      doc,
+		FilePath:   filePath,
+		Raw:        doc,
+		LineOffset: locator.lineOffset(doc),
+		SourceMap:  locator.sourceMap,
+		Variant:    l.helmVariant,
 	}
 
 	objs, err := parseObjects(doc, l.customDecoder)
@@ -209,6 +254,32 @@ func (l *lintContextImpl) loadObjectFromYAMLReader(filePath string, r *yaml.YAML
 	return nil
 }
 
+// documentLocator finds the documents returned by a YAMLReader in the original data, so that
+// positions within a document can be translated into positions within the whole file.
+type documentLocator struct {
+	data []byte
+	// cursor is the offset in data right after the last located document.
+	cursor int
+	// linesBeforeCursor is the number of newlines in data[:cursor].
+	linesBeforeCursor int
+	// sourceMap maps the lines of data to the template it was rendered from, if any.
+	sourceMap *SourceMap
+}
+
+// lineOffset returns the number of lines preceding the given document.
+// Documents must be passed in the order in which they appear in the data.
+func (d *documentLocator) lineOffset(doc []byte) int {
+	idx := bytes.Index(d.data[d.cursor:], doc)
+	if idx < 0 {
+		return 0
+	}
+	start := d.cursor + idx
+	offset := d.linesBeforeCursor + bytes.Count(d.data[d.cursor:start], []byte("\n"))
+	d.cursor = start + len(doc)
+	d.linesBeforeCursor = offset + bytes.Count(doc, []byte("\n"))
+	return offset
+}
+
 func (l *lintContextImpl) loadObjectsFromYAMLFile(filePath string, info os.FileInfo) error {
 	if info.Size() > maxFileSizeBytes {
 		return nil
@@ -225,9 +296,20 @@ func (l *lintContextImpl) loadObjectsFromYAMLFile(filePath string, info os.FileI
 }
 
 func (l *lintContextImpl) loadObjectsFromReader(filePath string, reader io.Reader) error {
-	yamlReader := yaml.NewYAMLReader(bufio.NewReader(reader))
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", filePath, err)
+	}
+	return l.loadObjectsFromData(filePath, data, nil)
+}
+
+// loadObjectsFromData loads the objects in the given YAML data. If the data was rendered from a template,
+// sourceMap maps its lines to the lines of the template.
+func (l *lintContextImpl) loadObjectsFromData(filePath string, data []byte, sourceMap *SourceMap) error {
+	yamlReader := yaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	locator := &documentLocator{data: data, sourceMap: sourceMap}
 	for {
-		if err := l.loadObjectFromYAMLReader(filePath, yamlReader); err != nil {
+		if err := l.loadObjectFromYAMLReader(filePath, yamlReader, locator); err != nil {
 			if errors.Is(err, io.EOF) {
 				return nil
 			}
@@ -236,9 +318,9 @@ func (l *lintContextImpl) loadObjectsFromReader(filePath string, reader io.Reade
 	}
 }
 
-func (l *lintContextImpl) renderChart(fileName string, chart *chart.Chart) (map[string]string, error) {
+func (l *lintContextImpl) renderChart(fileName string, chart *chart.Chart) (renderedChart, error) {
 	if err := chart.Validate(); err != nil {
-		return nil, err
+		return renderedChart{}, err
 	}
 
 	valuesIndex := -1
@@ -251,42 +333,47 @@ func (l *lintContextImpl) renderChart(fileName string, chart *chart.Chart) (map[
 
 	indexName := filepath.Join(fileName, "values.yaml")
 	if valuesIndex == -1 {
-		return nil, fmt.Errorf("%s not found", indexName)
+		return renderedChart{}, fmt.Errorf("%s not found", indexName)
 	}
 
 	values := map[string]interface{}{}
 	if err := y.Unmarshal(chart.Raw[valuesIndex].Data, &values); err != nil {
-		return nil, fmt.Errorf("failed to parse values file %s: %w", indexName, err)
+		return renderedChart{}, fmt.Errorf("failed to parse values file %s: %w", indexName, err)
 	}
 
-	return l.renderValues(chart, values)
+	return l.renderValues(chart, values, filepath.Dir(fileName), l.helmOptionsFor(fileName))
 }
 
-func (l *lintContextImpl) renderTgzHelmChartReader(fileName string, tgzReader io.Reader) (map[string]string, error) {
+func (l *lintContextImpl) renderTgzHelmChartReader(fileName string, tgzReader io.Reader) (renderedChart, error) {
 	// Helm doesn't have great logging behaviour, and can spam stderr, so silence their logging.
 	log.SetOutput(nopWriter{})
 	defer log.SetOutput(os.Stderr)
 
 	chrt, err := loader.LoadArchive(tgzReader)
 	if err != nil {
-		return nil, err
+		return renderedChart{}, err
 	}
 
 	return l.renderChart(fileName, chrt)
 }
 
-func (l *lintContextImpl) readObjectsFromTgzHelmChart(fileName string, tgzReader io.Reader, ignoredPaths []string) error {
+func (l *lintContextImpl) readObjectsFromTgzHelmChart(ctx context.Context, fileName string, tgzReader io.Reader, ignoredPaths []string) error {
 	renderedFiles, err := l.renderTgzHelmChartReader(fileName, tgzReader)
 	if err != nil {
 		l.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: fileName}, LoadErr: err})
 		return nil
 	}
-	return l.loadHelmRenderedTemplates(fileName, renderedFiles, ignoredPaths)
+	return l.loadHelmRenderedTemplates(ctx, fileName, renderedFiles, ignoredPaths)
 }
 
-func (l *lintContextImpl) loadHelmRenderedTemplates(chartPath string, renderedFiles map[string]string, ignorePaths []string) error {
+// loadHelmRenderedTemplates loads the objects of the rendered templates of a chart. Helm cannot be interrupted while it
+// renders a chart, but loading the templates stops when ctx is done, in which case it returns the error of ctx.
+func (l *lintContextImpl) loadHelmRenderedTemplates(ctx context.Context, chartPath string, renderedFiles renderedChart, ignorePaths []string) error {
 nextFile:
-	for path, contents := range renderedFiles {
+	for path, contents := range renderedFiles.files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		pathToTemplate := filepath.Join(chartPath, path)
 
 		for _, path := range ignorePaths {
@@ -304,9 +391,10 @@ nextFile:
 			continue
 		}
 
-		if err := l.loadObjectsFromReader(pathToTemplate, strings.NewReader(contents)); err != nil {
+		sourceMap := NewSourceMap(renderedFiles.sources[path], contents)
+		if err := l.loadObjectsFromData(pathToTemplate, []byte(contents), sourceMap); err != nil {
 			loadErr := fmt.Errorf("loading object %s from rendered helm chart %s: %w", pathToTemplate, chartPath, err)
-			l.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: pathToTemplate}, LoadErr: loadErr})
+			l.addInvalidObjects(InvalidObject{Metadata: l.helmMetadata(pathToTemplate), LoadErr: loadErr})
 		}
 	}
 
@@ -332,7 +420,9 @@ func normalizeDirectoryPaths(renderedFiles map[string]string) map[string]string
 	return normalizedFiles
 }
 
-func (l *lintContextImpl) loadObjectsFromKustomize(dir string) {
+// loadObjectsFromKustomize loads the objects built from a Kustomize directory. Build errors are recorded as invalid
+// objects, unless ctx is done, in which case the error of ctx is returned.
+func (l *lintContextImpl) loadObjectsFromKustomize(ctx context.Context, dir string) error {
 	// Create a kustomize engine with source annotations enabled
 	kustomizeSource := kustomize.Source{Path: dir}
 	ignoreWarnings := func(warnings []string) error {
@@ -346,15 +436,17 @@ func (l *lintContextImpl) loadObjectsFromKustomize(dir string) {
 	)
 	if err != nil {
 		l.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: dir}, LoadErr: err})
-		return
+		return nil
 	}
 
 	// Render the kustomize manifests
-	ctx := context.Background()
 	objects, err := e.Render(ctx)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
 	if err != nil {
 		l.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: dir}, LoadErr: err})
-		return
+		return nil
 	}
 
 	// Convert each object to YAML and load it
@@ -386,4 +478,5 @@ func (l *lintContextImpl) loadObjectsFromKustomize(dir string) {
 			l.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: filePath}, LoadErr: loadErr})
 		}
 	}
+	return nil
 }
@@ -0,0 +1,218 @@
+package lintcontext
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/goccy/go-yaml/token"
+)
+
+// Locate resolves the given field path against the raw YAML of the object, and returns the line and column
+// (both 1-based) of the corresponding node in the source file.
+// The field path uses dot notation with indices for list elements, e.g. spec.template.spec.containers[1].securityContext.
+// Keys that contain dots can be quoted, e.g. metadata.annotations["example.com/key"].
+// An empty path refers to the root of the object.
+// If the path cannot be fully resolved, the position of the deepest node that could be resolved is returned.
+// For objects rendered from a Helm chart, the position is that of the template line that produced the node.
+// If the raw YAML is not available or cannot be parsed, the returned line and column are both zero.
+// Locate parses the raw YAML on each call: use a Locator to locate several paths in the same object.
+func (m ObjectMetadata) Locate(fieldPath string) (line, column int) {
+	return m.NewLocator().Locate(fieldPath)
+}
+
+// A Locator locates field paths in the raw YAML of an object, like ObjectMetadata.Locate, but parses the raw YAML
+// only once, the first time that it is needed. It is not safe for concurrent use.
+type Locator struct {
+	metadata ObjectMetadata
+	parsed   bool
+	root     ast.Node
+}
+
+// NewLocator returns a Locator for the object.
+func (m ObjectMetadata) NewLocator() *Locator {
+	return &Locator{metadata: m}
+}
+
+// Raw returns the raw YAML of the object.
+func (l *Locator) Raw() []byte {
+	return l.metadata.Raw
+}
+
+// Root returns the root node of the raw YAML of the object, or nil if it is not available or cannot be parsed.
+func (l *Locator) Root() ast.Node {
+	if !l.parsed {
+		l.parsed = true
+		if len(l.metadata.Raw) == 0 {
+			return nil
+		}
+		file, err := parser.ParseBytes(l.metadata.Raw, 0)
+		if err == nil && len(file.Docs) > 0 && file.Docs[0] != nil {
+			l.root = file.Docs[0].Body
+		}
+	}
+	return l.root
+}
+
+// Locate resolves the given field path, like ObjectMetadata.Locate.
+func (l *Locator) Locate(fieldPath string) (line, column int) {
+	segments, err := ParseFieldPath(fieldPath)
+	if err != nil {
+		return 0, 0
+	}
+	root := l.Root()
+	if root == nil {
+		return 0, 0
+	}
+	pos := locateNode(root, segments)
+	if pos == nil {
+		return 0, 0
+	}
+	return l.metadata.Position(pos.Line, pos.Column)
+}
+
+// Position translates a position within the raw YAML of the object into a position in the source file, like Locate.
+func (m ObjectMetadata) Position(line, column int) (int, int) {
+	if m.SourceMap != nil {
+		return m.SourceMap.Translate(line+m.LineOffset, column)
+	}
+	return line + m.LineOffset, column
+}
+
+// A FieldPathSegment is a single element of a field path: either a map key, or an index into a list.
+type FieldPathSegment struct {
+	Key     string
+	Index   int
+	IsIndex bool
+}
+
+// ParseFieldPath splits a field path such as spec.containers[0].ports[1] into its segments.
+func ParseFieldPath(fieldPath string) ([]FieldPathSegment, error) {
+	var segments []FieldPathSegment
+	var key strings.Builder
+	flushKey := func() {
+		if key.Len() > 0 {
+			segments = append(segments, FieldPathSegment{Key: key.String()})
+			key.Reset()
+		}
+	}
+	for i := 0; i < len(fieldPath); i++ {
+		switch c := fieldPath[i]; c {
+		case '.':
+			flushKey()
+		case '[':
+			flushKey()
+			end := strings.IndexByte(fieldPath[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated bracket in field path %q", fieldPath)
+			}
+			inner := fieldPath[i+1 : i+end]
+			i += end
+			if unquoted, err := strconv.Unquote(inner); err == nil {
+				segments = append(segments, FieldPathSegment{Key: unquoted})
+				continue
+			}
+			idx, err := strconv.Atoi(inner)
+			if err != nil {
+				return nil, fmt.Errorf("invalid index %q in field path %q", inner, fieldPath)
+			}
+			segments = append(segments, FieldPathSegment{Index: idx, IsIndex: true})
+		default:
+			key.WriteByte(c)
+		}
+	}
+	flushKey()
+	return segments, nil
+}
+
+func locateNode(node ast.Node, segments []FieldPathSegment) *token.Position {
+	pos := nodePosition(node)
+	for _, segment := range segments {
+		node = UnwrapNode(node)
+		var next ast.Node
+		var nextPos *token.Position
+		if segment.IsIndex {
+			seq, ok := node.(*ast.SequenceNode)
+			if !ok || segment.Index < 0 || segment.Index >= len(seq.Values) {
+				return pos
+			}
+			next = seq.Values[segment.Index]
+			nextPos = nodePosition(next)
+			if segment.Index < len(seq.Entries) && seq.Entries[segment.Index].Start != nil {
+				nextPos = seq.Entries[segment.Index].Start.Position
+			}
+		} else {
+			mappingValue := FindMappingValue(node, segment.Key)
+			if mappingValue == nil {
+				return pos
+			}
+			next = mappingValue.Value
+			nextPos = nodePosition(mappingValue.Key)
+		}
+		node, pos = next, nextPos
+	}
+	return pos
+}
+
+// FindMappingValue returns the entry of the given mapping node with the given key, or nil if the node is not a
+// mapping or has no such entry.
+func FindMappingValue(node ast.Node, key string) *ast.MappingValueNode {
+	var values []*ast.MappingValueNode
+	switch n := node.(type) {
+	case *ast.MappingNode:
+		values = n.Values
+	case *ast.MappingValueNode:
+		values = []*ast.MappingValueNode{n}
+	}
+	for _, value := range values {
+		if value.Key != nil && KeyString(value.Key) == key {
+			return value
+		}
+	}
+	return nil
+}
+
+// KeyString returns the text of the given mapping key, as it appears in field paths.
+func KeyString(node ast.Node) string {
+	if s, ok := UnwrapNode(node).(*ast.StringNode); ok {
+		return s.Value
+	}
+	if tok := node.GetToken(); tok != nil {
+		return tok.Value
+	}
+	return ""
+}
+
+// UnwrapNode strips anchors and tags, which do not correspond to a field path segment.
+func UnwrapNode(node ast.Node) ast.Node {
+	for {
+		switch n := node.(type) {
+		case *ast.AnchorNode:
+			node = n.Value
+		case *ast.TagNode:
+			node = n.Value
+		default:
+			return node
+		}
+	}
+}
+
+func nodePosition(node ast.Node) *token.Position {
+	if node == nil {
+		return nil
+	}
+	switch n := UnwrapNode(node).(type) {
+	case *ast.MappingNode:
+		if len(n.Values) > 0 && !n.IsFlowStyle {
+			return nodePosition(n.Values[0].Key)
+		}
+	case *ast.MappingValueNode:
+		return nodePosition(n.Key)
+	}
+	if tok := node.GetToken(); tok != nil {
+		return tok.Position
+	}
+	return nil
+}
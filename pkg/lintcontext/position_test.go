@@ -0,0 +1,107 @@
+package lintcontext
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const multiDocYAML = `# leading comment
+apiVersion: v1
+kind: Service
+metadata:
+  name: svc
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+  annotations:
+    example.com/key: value
+spec:
+  template:
+    spec:
+      containers:
+      - name: first
+        image: nginx
+      - name: second
+        image: nginx
+        securityContext:
+          privileged: true
+`
+
+func TestLocate(t *testing.T) {
+	ctx := newCtx(Options{})
+	require.NoError(t, ctx.loadObjectsFromReader("test.yaml", strings.NewReader(multiDocYAML)))
+	require.Len(t, ctx.Objects(), 2)
+
+	service, deployment := ctx.Objects()[0].Metadata, ctx.Objects()[1].Metadata
+	assert.Equal(t, 0, service.LineOffset)
+	assert.Equal(t, 6, deployment.LineOffset)
+
+	for _, tc := range []struct {
+		name         string
+		metadata     ObjectMetadata
+		path         string
+		line, column int
+	}{
+		{name: "object root", metadata: service, path: "", line: 2, column: 1},
+		{name: "object root in second document", metadata: deployment, path: "", line: 7, column: 1},
+		{name: "nested key", metadata: service, path: "metadata.name", line: 5, column: 3},
+		{name: "list element", metadata: deployment, path: "spec.template.spec.containers[1]", line: 19, column: 7},
+		{name: "field in list element", metadata: deployment, path: "spec.template.spec.containers[1].securityContext.privileged", line: 22, column: 11},
+		{name: "quoted key", metadata: deployment, path: `metadata.annotations["example.com/key"]`, line: 12, column: 5},
+		{name: "missing field falls back to closest ancestor", metadata: deployment, path: "spec.template.spec.containers[0].securityContext", line: 17, column: 7},
+		{name: "out of range index falls back to list", metadata: deployment, path: "spec.template.spec.containers[5]", line: 16, column: 7},
+		{name: "no raw data", metadata: ObjectMetadata{}, path: "spec", line: 0, column: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			line, column := tc.metadata.Locate(tc.path)
+			assert.Equal(t, tc.line, line, "line")
+			assert.Equal(t, tc.column, column, "column")
+		})
+	}
+}
+
+func TestLocator(t *testing.T) {
+	ctx := newCtx(Options{})
+	require.NoError(t, ctx.loadObjectsFromReader("test.yaml", strings.NewReader(multiDocYAML)))
+	locator := ctx.Objects()[1].Metadata.NewLocator()
+
+	root := locator.Root()
+	require.NotNil(t, root)
+	assert.Same(t, root, locator.Root(), "the raw YAML is only parsed once")
+
+	line, column := locator.Locate("spec.template.spec.containers[1].securityContext.privileged")
+	assert.Equal(t, 22, line)
+	assert.Equal(t, 11, column)
+	line, column = locator.Locate("metadata.name")
+	assert.Equal(t, 10, line)
+	assert.Equal(t, 3, column)
+
+	empty := ObjectMetadata{}.NewLocator()
+	assert.Nil(t, empty.Root())
+	line, column = empty.Locate("spec")
+	assert.Zero(t, line)
+	assert.Zero(t, column)
+}
+
+func TestParseFieldPath(t *testing.T) {
+	segments, err := ParseFieldPath(`spec.containers[1].env[0]["a.b"]`)
+	require.NoError(t, err)
+	assert.Equal(t, []FieldPathSegment{
+		{Key: "spec"},
+		{Key: "containers"},
+		{Index: 1, IsIndex: true},
+		{Key: "env"},
+		{Index: 0, IsIndex: true},
+		{Key: "a.b"},
+	}, segments)
+
+	_, err = ParseFieldPath("spec.containers[x]")
+	assert.Error(t, err)
+	_, err = ParseFieldPath("spec.containers[1")
+	assert.Error(t, err)
+}
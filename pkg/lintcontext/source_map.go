@@ -0,0 +1,355 @@
+package lintcontext
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// maxAlignmentCells bounds the size of the table used to align the lines between two anchors (see alignLines),
+	// so that huge templates do not make loading slow. Larger gaps are aligned greedily.
+	maxAlignmentCells = 250_000
+	// maxGreedyLookahead is the number of rendered lines that a template line is looked for in, when aligning greedily.
+	maxGreedyLookahead = 64
+)
+
+var controlActionRegex = regexp.MustCompile(`^(if|else|end|with|range|define|block|break|continue)\b|^/\*|^\$[\w.]*\s*:?=`)
+
+// A SourceMap maps the lines of a file rendered from a template to the lines of the template that produced them.
+//
+// Helm does not keep track of where rendered lines come from, so the map is built by aligning the lines of the
+// template with the rendered lines: template lines without actions are matched verbatim, and lines with actions
+// are matched against a pattern in which every action can expand to any text. Rendered lines that are not matched,
+// such as the output of include or toYaml, are attributed to the template line that produced them.
+type SourceMap struct {
+	// positions[i] is the position of the template line that produced line i+1 of the rendered file.
+	positions []sourcePosition
+}
+
+type sourcePosition struct {
+	// line is the 1-based line in the template, or zero if it is unknown.
+	line int
+	// sameColumns is the number of leading characters that the rendered line and the template line have in common.
+	// Columns within them are the same in both files; other columns are mapped to the indentation of the template line.
+	sameColumns int
+	indent      int
+}
+
+// templateLine is a line of a template, split around its actions.
+type templateLine struct {
+	text string
+	// pattern matches the rendered line if the template line has actions, and nil if it has none.
+	pattern *regexp.Regexp
+	// literalPrefix is the number of characters before the first action.
+	literalPrefix int
+	indent        int
+	// matchable is false for lines that cannot be recognized in the output, such as blank lines and lines with only actions.
+	matchable bool
+	// expands is true for lines whose actions may produce output, such as include or toYaml.
+	expands bool
+}
+
+// NewSourceMap returns the map of the lines of rendered to the lines of the template that it was rendered from.
+// It returns nil if the template is empty.
+func NewSourceMap(template, rendered string) *SourceMap {
+	if template == "" {
+		return nil
+	}
+	tmplLines := parseTemplateLines(template)
+	renderedLines := strings.Split(rendered, "\n")
+	for i, line := range renderedLines {
+		renderedLines[i] = strings.TrimRight(line, " \t\r")
+	}
+
+	matches := alignLines(tmplLines, renderedLines)
+	positions := make([]sourcePosition, len(renderedLines))
+	prevTmpl := -1
+	// gapTmpl is the template line that the current run of unmatched lines is attributed to, or -1 outside of a run.
+	gapTmpl := -1
+	for r := 0; r < len(renderedLines); r++ {
+		if t := matches[r]; t >= 0 {
+			positions[r] = sourcePosition{line: t + 1, indent: tmplLines[t].indent, sameColumns: tmplLines[t].literalPrefix}
+			prevTmpl, gapTmpl = t, -1
+			continue
+		}
+		if gapTmpl < 0 {
+			// Attribute unmatched lines to the first template line between the surrounding matches that can produce output.
+			nextTmpl := len(tmplLines)
+			for next := r + 1; next < len(renderedLines); next++ {
+				if matches[next] >= 0 {
+					nextTmpl = matches[next]
+					break
+				}
+			}
+			gapTmpl = prevTmpl
+			for candidate := prevTmpl + 1; candidate < nextTmpl; candidate++ {
+				if tmplLines[candidate].expands {
+					gapTmpl = candidate
+					break
+				}
+			}
+			if gapTmpl < 0 {
+				gapTmpl = min(nextTmpl, len(tmplLines)-1)
+			}
+		}
+		positions[r] = sourcePosition{line: gapTmpl + 1, indent: tmplLines[gapTmpl].indent}
+	}
+	return &SourceMap{positions: positions}
+}
+
+// Translate returns the position in the template of the given position (both 1-based) in the rendered file.
+func (s *SourceMap) Translate(line, column int) (int, int) {
+	if s == nil || line < 1 || line > len(s.positions) {
+		return line, column
+	}
+	pos := s.positions[line-1]
+	if pos.line == 0 {
+		return 0, 0
+	}
+	if column > pos.sameColumns {
+		column = pos.indent + 1
+	}
+	return pos.line, column
+}
+
+// alignLines returns, for each rendered line, the index of the template line it is matched with, or -1.
+// Lines without actions that appear exactly once in both the template and the rendered file are matched first, as
+// anchors. The lines between consecutive anchors are then aligned with alignWindow.
+func alignLines(tmplLines []templateLine, renderedLines []string) []int {
+	matches := make([]int, len(renderedLines))
+	for j := range matches {
+		matches[j] = -1
+	}
+	prev := linePair{tmpl: -1, rendered: -1}
+	for _, anchor := range append(findAnchors(tmplLines, renderedLines), linePair{tmpl: len(tmplLines), rendered: len(renderedLines)}) {
+		alignWindow(tmplLines[prev.tmpl+1:anchor.tmpl], renderedLines[prev.rendered+1:anchor.rendered], prev.tmpl+1, matches[prev.rendered+1:anchor.rendered])
+		if anchor.rendered < len(renderedLines) {
+			matches[anchor.rendered] = anchor.tmpl
+		}
+		prev = anchor
+	}
+	return matches
+}
+
+// linePair is a template line and a rendered line, by index.
+type linePair struct {
+	tmpl, rendered int
+}
+
+// findAnchors returns the pairs of lines without actions that appear exactly once in both the template and the
+// rendered file, restricted to the longest sequence of them that is increasing in both, in the order of the
+// rendered lines.
+func findAnchors(tmplLines []templateLine, renderedLines []string) []linePair {
+	tmplIndices := make(map[string]int)
+	for i, line := range tmplLines {
+		if !line.matchable || line.pattern != nil {
+			continue
+		}
+		if _, seen := tmplIndices[line.text]; seen {
+			tmplIndices[line.text] = -1
+		} else {
+			tmplIndices[line.text] = i
+		}
+	}
+	renderedIndices := make(map[string]int)
+	for j, line := range renderedLines {
+		if i, ok := tmplIndices[line]; !ok || i < 0 {
+			continue
+		}
+		if _, seen := renderedIndices[line]; seen {
+			renderedIndices[line] = -1
+		} else {
+			renderedIndices[line] = j
+		}
+	}
+	var pairs []linePair
+	for j, line := range renderedLines {
+		if r, ok := renderedIndices[line]; ok && r == j {
+			pairs = append(pairs, linePair{tmpl: tmplIndices[line], rendered: j})
+		}
+	}
+
+	// Keep the longest sequence of pairs that is increasing in template lines, with patience sorting: tails[k] is
+	// the index of the pair that ends the best sequence of length k+1 found so far.
+	var tails []int
+	prevs := make([]int, len(pairs))
+	for p, pair := range pairs {
+		k := sort.Search(len(tails), func(k int) bool { return pairs[tails[k]].tmpl >= pair.tmpl })
+		prevs[p] = -1
+		if k > 0 {
+			prevs[p] = tails[k-1]
+		}
+		if k == len(tails) {
+			tails = append(tails, p)
+		} else {
+			tails[k] = p
+		}
+	}
+	if len(tails) == 0 {
+		return nil
+	}
+	anchors := make([]linePair, len(tails))
+	for k, p := len(tails)-1, tails[len(tails)-1]; k >= 0; k, p = k-1, prevs[p] {
+		anchors[k] = pairs[p]
+	}
+	return anchors
+}
+
+// alignWindow matches the given template lines with the given rendered lines, which lie between two anchors, and
+// records the matches, offset by tmplOffset, in matches, which has one entry per rendered line.
+// The matches are the longest sequence of matching pairs that is increasing in both template and rendered lines.
+// Windows that are too large for that are aligned greedily instead.
+func alignWindow(tmplLines []templateLine, renderedLines []string, tmplOffset int, matches []int) {
+	n, m := len(tmplLines), len(renderedLines)
+	if n == 0 || m == 0 {
+		return
+	}
+	if n*m > maxAlignmentCells {
+		alignGreedily(tmplLines, renderedLines, tmplOffset, matches)
+		return
+	}
+	// lengths[i][j] is the length of the longest alignment of tmplLines[i:] with renderedLines[j:].
+	lengths := make([][]int32, n+1)
+	for i := range lengths {
+		lengths[i] = make([]int32, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case tmplLines[i].matches(renderedLines[j]):
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			case lengths[i+1][j] >= lengths[i][j+1]:
+				lengths[i][j] = lengths[i+1][j]
+			default:
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case lengths[i][j] == lengths[i+1][j+1]+1 && tmplLines[i].matches(renderedLines[j]):
+			matches[j] = tmplOffset + i
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+}
+
+// alignGreedily matches each template line, in order, with the first rendered line that it matches among the next
+// maxGreedyLookahead ones, like alignWindow.
+func alignGreedily(tmplLines []templateLine, renderedLines []string, tmplOffset int, matches []int) {
+	j := 0
+	for i := 0; i < len(tmplLines) && j < len(renderedLines); i++ {
+		for k := j; k < len(renderedLines) && k < j+maxGreedyLookahead; k++ {
+			if tmplLines[i].matches(renderedLines[k]) {
+				matches[k] = tmplOffset + i
+				j = k + 1
+				break
+			}
+		}
+	}
+}
+
+func (t templateLine) matches(rendered string) bool {
+	if !t.matchable {
+		return false
+	}
+	if t.pattern == nil {
+		return t.text == rendered
+	}
+	// The text before the first action is checked first, as it rules out most lines without running the pattern.
+	return strings.HasPrefix(rendered, t.text[:t.literalPrefix]) && t.pattern.MatchString(rendered)
+}
+
+// parseTemplateLines splits a template into lines, keeping track of actions that span several lines.
+func parseTemplateLines(template string) []templateLine {
+	rawLines := strings.Split(template, "\n")
+	lines := make([]templateLine, 0, len(rawLines))
+	inAction := false
+	// action is the text of the current action, and actionLine the index of the line that it starts on.
+	var action strings.Builder
+	actionLine := 0
+	// Templates often repeat lines, which share their pattern.
+	patterns := make(map[string]*regexp.Regexp)
+	for _, raw := range rawLines {
+		raw = strings.TrimRight(raw, " \t\r")
+		line := templateLine{
+			text:          raw,
+			indent:        len(raw) - len(strings.TrimLeft(raw, " ")),
+			literalPrefix: -1,
+		}
+		var pattern, literal strings.Builder
+		hasActions := inAction
+		if inAction {
+			line.literalPrefix = 0
+		}
+		for rest := raw; rest != ""; {
+			if inAction {
+				end := strings.Index(rest, "}}")
+				if end < 0 {
+					action.WriteString(rest)
+					break
+				}
+				action.WriteString(rest[:end])
+				if !isControlAction(action.String()) {
+					if actionLine == len(lines) {
+						line.expands = true
+					} else {
+						lines[actionLine].expands = true
+					}
+				}
+				action.Reset()
+				rest = rest[end+2:]
+				inAction = false
+				continue
+			}
+			start := strings.Index(rest, "{{")
+			if start < 0 {
+				pattern.WriteString(regexp.QuoteMeta(rest))
+				literal.WriteString(rest)
+				break
+			}
+			pattern.WriteString(regexp.QuoteMeta(rest[:start]))
+			literal.WriteString(rest[:start])
+			if !hasActions {
+				line.literalPrefix = len(raw) - len(rest) + start
+			}
+			pattern.WriteString(".*")
+			hasActions = true
+			inAction = true
+			actionLine = len(lines)
+			rest = rest[start+2:]
+		}
+
+		line.matchable = strings.TrimSpace(literal.String()) != ""
+		if hasActions {
+			if line.matchable {
+				expr := "^" + pattern.String() + "$"
+				if patterns[expr] == nil {
+					patterns[expr] = regexp.MustCompile(expr)
+				}
+				line.pattern = patterns[expr]
+			}
+		} else {
+			line.literalPrefix = len(raw)
+		}
+		if line.literalPrefix < 0 {
+			line.literalPrefix = 0
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// isControlAction returns whether the given action (without its delimiters) only controls the flow of the template,
+// and produces no output by itself.
+func isControlAction(action string) bool {
+	action = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(action, "-"), "-"))
+	return controlActionRegex.MatchString(action)
+}
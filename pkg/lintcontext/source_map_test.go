@@ -0,0 +1,147 @@
+package lintcontext
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const podTemplate = `apiVersion: v1
+kind: Pod
+metadata:
+  name: {{ .Release.Name }}
+  labels:
+    {{- include "labels" . | nindent 4 }}
+spec:
+  {{- if .Values.hostNetwork }}
+  hostNetwork: true
+  {{- end }}
+  containers:
+    - name: app
+      securityContext:
+        {{- toYaml .Values.securityContext | nindent 8 }}
+      image: "{{ .Values.image }}"
+`
+
+const renderedPod = `apiVersion: v1
+kind: Pod
+metadata:
+  name: release
+  labels:
+    app: example
+    tier: web
+spec:
+  containers:
+    - name: app
+      securityContext:
+        privileged: true
+      image: "nginx"
+`
+
+func TestSourceMap(t *testing.T) {
+	sourceMap := NewSourceMap(podTemplate, renderedPod)
+	require.NotNil(t, sourceMap)
+
+	for _, tc := range []struct {
+		name                         string
+		line, column                 int
+		expectedLine, expectedColumn int
+	}{
+		{name: "verbatim line", line: 2, column: 1, expectedLine: 2, expectedColumn: 1},
+		{name: "line with an action", line: 4, column: 3, expectedLine: 4, expectedColumn: 3},
+		{name: "output of include", line: 7, column: 5, expectedLine: 6, expectedColumn: 5},
+		{name: "line after a skipped conditional", line: 9, column: 3, expectedLine: 11, expectedColumn: 3},
+		{name: "output of toYaml", line: 12, column: 9, expectedLine: 14, expectedColumn: 9},
+		{name: "column before the first action", line: 13, column: 7, expectedLine: 15, expectedColumn: 7},
+		{name: "column after the first action", line: 13, column: 15, expectedLine: 15, expectedColumn: 7},
+		{name: "line out of range", line: 42, column: 1, expectedLine: 42, expectedColumn: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			line, column := sourceMap.Translate(tc.line, tc.column)
+			assert.Equal(t, tc.expectedLine, line, "line")
+			assert.Equal(t, tc.expectedColumn, column, "column")
+		})
+	}
+
+	assert.Nil(t, NewSourceMap("", renderedPod))
+}
+
+// largeTemplate returns a template of a pod with the given number of containers, and its rendered output, in which
+// each include expands to two lines. If anchored is set, the names of the containers are literal, so that the lines
+// that declare them can be used as anchors.
+func largeTemplate(containers int, anchored bool) (string, string) {
+	var template, rendered strings.Builder
+	template.WriteString("apiVersion: v1\nkind: Pod\nmetadata:\n  name: {{ .Release.Name }}\nspec:\n  containers:\n")
+	rendered.WriteString("apiVersion: v1\nkind: Pod\nmetadata:\n  name: release\nspec:\n  containers:\n")
+	for i := 0; i < containers; i++ {
+		if anchored {
+			fmt.Fprintf(&template, "    - name: container-%d\n", i)
+			fmt.Fprintf(&rendered, "    - name: container-%d\n", i)
+		} else {
+			fmt.Fprintf(&template, "    - name: {{ .Release.Name }}-%d\n", i)
+			fmt.Fprintf(&rendered, "    - name: release-%d\n", i)
+		}
+		template.WriteString("      image: {{ .Values.image }}\n      env:\n        {{- include \"env\" . | nindent 8 }}\n")
+		rendered.WriteString("      image: nginx\n      env:\n        - name: A\n          value: a\n")
+	}
+	return template.String(), rendered.String()
+}
+
+func TestSourceMapOfLargeTemplate(t *testing.T) {
+	for _, anchored := range []bool{true, false} {
+		t.Run(fmt.Sprintf("anchored=%t", anchored), func(t *testing.T) {
+			sourceMap := NewSourceMap(largeTemplate(2000, anchored))
+			require.NotNil(t, sourceMap)
+			// The lines of the 1500th container start at line 7+4*1500 in the template, and 7+5*1500 in the output.
+			line, column := sourceMap.Translate(7+5*1500, 5)
+			assert.Equal(t, 7+4*1500, line, "name")
+			assert.Equal(t, 5, column, "name")
+			line, column = sourceMap.Translate(8+5*1500, 14)
+			assert.Equal(t, 8+4*1500, line, "image")
+			assert.Equal(t, 7, column, "image")
+			line, column = sourceMap.Translate(11+5*1500, 11)
+			assert.Equal(t, 10+4*1500, line, "output of include")
+			assert.Equal(t, 9, column, "output of include")
+		})
+	}
+}
+
+func BenchmarkNewSourceMap(b *testing.B) {
+	for _, anchored := range []bool{true, false} {
+		template, rendered := largeTemplate(2000, anchored)
+		b.Run(fmt.Sprintf("anchored=%t", anchored), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				NewSourceMap(template, rendered)
+			}
+		})
+	}
+}
+
+func TestLocateInHelmTemplate(t *testing.T) {
+	lintCtxs, err := CreateContextsWithOptions(Options{Helm: HelmOptions{APIVersions: []string{"example.com/v1"}}}, nil, valuesChartDir)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 1)
+	require.Len(t, lintCtxs[0].Objects(), 1)
+	metadata := lintCtxs[0].Objects()[0].Metadata
+	assert.Equal(t, filepath.Join(valuesChartDir, "templates", "deployment.yaml"), metadata.FilePath)
+
+	for _, tc := range []struct {
+		path         string
+		line, column int
+	}{
+		{path: "metadata.namespace", line: 5, column: 3},
+		{path: `metadata.labels["example-api"]`, line: 9, column: 5},
+		{path: "spec.replicas", line: 12, column: 3},
+		{path: "spec.template.spec.containers[0].image", line: 23, column: 11},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			line, column := metadata.Locate(tc.path)
+			assert.Equal(t, tc.line, line, "line")
+			assert.Equal(t, tc.column, column, "column")
+		})
+	}
+}
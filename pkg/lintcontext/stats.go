@@ -0,0 +1,35 @@
+package lintcontext
+
+import (
+	"time"
+)
+
+// The loaders by which LoadStats break down the time spent loading objects.
+const (
+	// LoaderFiles parses YAML and JSON files, and standard input.
+	LoaderFiles = "files"
+	// LoaderHelm renders Helm charts, from directories and archives.
+	LoaderHelm = "helm"
+	// LoaderKustomize builds Kustomize directories.
+	LoaderKustomize = "kustomize"
+	// LoaderCluster lists the objects deployed in a cluster.
+	LoaderCluster = "cluster"
+)
+
+// LoadStats record how long loading objects into lint contexts took, by loader.
+type LoadStats struct {
+	// Durations is the time spent in each loader, keyed by the Loader constants.
+	Durations map[string]time.Duration
+}
+
+// record adds the time elapsed since start to the duration of the given loader. It does nothing on nil stats, so that
+// loaders can call it regardless of whether stats are collected.
+func (s *LoadStats) record(loader string, start time.Time) {
+	if s == nil {
+		return
+	}
+	if s.Durations == nil {
+		s.Durations = make(map[string]time.Duration)
+	}
+	s.Durations[loader] += time.Since(start)
+}
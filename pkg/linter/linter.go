@@ -0,0 +1,283 @@
+// Package linter is the stable entry point for embedding KubeLinter in other programs.
+//
+// A Linter is created once, with the config and extensions to run the checks with, and can then lint any number
+// of sources, concurrently:
+//
+//	l, err := linter.New(linter.WithConfigFile(".kube-linter.yaml"))
+//	if err != nil {
+//		return err
+//	}
+//	result, err := l.Lint(ctx, linter.Paths("manifests/", "charts/app"))
+//
+// The built-in templates and checks are always available. The other packages of this module are used by
+// this one, but their APIs may change between releases.
+package linter
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"golang.stackrox.io/kube-linter/pkg/baseline"
+	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/checkregistry"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/configresolver"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/instantiatedcheck"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/pathutil"
+	"golang.stackrox.io/kube-linter/pkg/run"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	// Register the built-in templates.
+	_ "golang.stackrox.io/kube-linter/pkg/templates/all"
+)
+
+// A Linter runs checks against the objects of sources. It is safe for concurrent use.
+type Linter struct {
+	registry      checkregistry.CheckRegistry
+	checks        []string
+	sourceOptions SourceOptions
+	runOptions    run.Options
+	sources       []Source
+
+	config               config.Config
+	templates            []check.Template
+	reportInvalidObjects bool
+	nestedConfigsDir     string
+	baselinePath         string
+	updateBaseline       bool
+}
+
+// Result is the outcome of linting sources.
+type Result struct {
+	run.Result
+	// InvalidObjects are reports for the objects that could not be loaded from the sources.
+	InvalidObjects []diagnostic.WithContext
+	// Objects is the number of objects that were loaded from the sources.
+	Objects int
+	// Baselined is the number of findings that were removed because they are recorded in the baseline.
+	Baselined int
+}
+
+// New creates a Linter with the given options.
+func New(opts ...Option) (*Linter, error) {
+	var o options
+	for _, opt := range opts {
+		if err := opt(&o); err != nil {
+			return nil, err
+		}
+	}
+	cfg := o.config
+
+	// The templates declared in the config are added to the ones given as options.
+	configTemplates, err := configresolver.GetTemplates(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	templates := append(configTemplates, o.templates...)
+	registry := checkregistry.NewWithOptions(instantiatedcheck.Options{Templates: instantiatedcheck.TemplatesByKey(templates...)})
+	if err := builtinchecks.LoadInto(registry); err != nil {
+		return nil, err
+	}
+	if err := configresolver.LoadCustomChecksInto(&cfg, registry); err != nil {
+		return nil, err
+	}
+	checks, err := configresolver.GetEnabledChecksAndValidate(&cfg, registry)
+	if err != nil {
+		return nil, err
+	}
+	if o.skipCrossObject {
+		checks = slices.DeleteFunc(checks, func(name string) bool {
+			return registry.Load(name).NeedsOtherObjects
+		})
+	}
+	if err := configresolver.ApplySeverityOverrides(&cfg, registry); err != nil {
+		return nil, err
+	}
+	ignorePaths, err := configresolver.GetIgnorePaths(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	contextOptions, err := configresolver.GetContextOptions(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	contextOptions.CustomDecoder = o.customDecoder
+	checkTimeout, err := configresolver.GetCheckTimeout(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	suppressions, err := configresolver.GetSuppressions(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	exclusions, err := configresolver.GetExclusions(&cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Linter{
+		registry:             registry,
+		checks:               checks,
+		sourceOptions:        SourceOptions{Context: contextOptions, IgnorePaths: ignorePaths},
+		runOptions:           run.Options{Parallelism: o.parallelism, CheckTimeout: checkTimeout, Suppressions: suppressions, Exclusions: exclusions, CollectStats: o.stats, FailOn: o.failOn},
+		sources:              o.sources,
+		config:               cfg,
+		templates:            templates,
+		reportInvalidObjects: o.reportInvalidObjects,
+		nestedConfigsDir:     o.nestedConfigsDir,
+		baselinePath:         o.baselinePath,
+		updateBaseline:       o.updateBaseline,
+	}, nil
+}
+
+// Checks returns the names of the checks that the Linter runs, sorted.
+func (l *Linter) Checks() []string {
+	return append([]string(nil), l.checks...)
+}
+
+// Lint loads the objects of the sources that the Linter was created with and of the given sources, and runs
+// the checks against them. It stops, and returns the error of ctx, when ctx is done.
+func (l *Linter) Lint(ctx context.Context, sources ...Source) (Result, error) {
+	sources = append(append([]Source(nil), l.sources...), sources...)
+	// Each call records its own load stats and nested configs, so that concurrent calls do not share them.
+	sourceOptions := l.sourceOptions
+	if l.runOptions.CollectStats {
+		sourceOptions.Context.Stats = &lintcontext.LoadStats{}
+	}
+	runOptions := l.runOptions
+	if l.nestedConfigsDir != "" {
+		overrides, err := l.nestedConfigOverrides(sources)
+		if err != nil {
+			return Result{}, err
+		}
+		runOptions.Overrides = overrides
+	}
+
+	var lintCtxs []lintcontext.LintContext
+	for _, source := range sources {
+		if err := ctx.Err(); err != nil {
+			return Result{}, err
+		}
+		sourceCtxs, err := source.LintContexts(ctx, sourceOptions)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return Result{}, ctxErr
+			}
+			return Result{}, fmt.Errorf("loading objects: %w", err)
+		}
+		lintCtxs = append(lintCtxs, sourceCtxs...)
+	}
+	runResult, err := run.RunWithContext(ctx, runOptions, lintCtxs, l.registry, l.checks)
+	if err != nil {
+		return Result{}, err
+	}
+	result := Result{Result: runResult, InvalidObjects: InvalidObjectReports(lintCtxs)}
+	for _, lintCtx := range lintCtxs {
+		result.Objects += len(lintCtx.Objects())
+	}
+	if l.reportInvalidObjects {
+		result.Reports = append(result.Reports, result.InvalidObjects...)
+	}
+	if l.baselinePath != "" {
+		if result.Baselined, err = l.applyBaseline(&result.Result); err != nil {
+			return Result{}, err
+		}
+	}
+
+	// Invalid objects and the baseline change the findings, and therefore the status of the run.
+	result.Summary.ChecksStatus = run.Status(result.Reports, runOptions.FailOn)
+	if result.Summary.Stats != nil {
+		result.Summary.Stats.CountFindings(result.Reports)
+		result.Summary.Stats.LoadDurations = sourceOptions.Context.Stats.Durations
+	}
+	return result, nil
+}
+
+// nestedConfigOverrides loads the nested configs that apply to the paths of the Paths sources, and returns the
+// overrides that apply them.
+func (l *Linter) nestedConfigOverrides(sources []Source) ([]run.Override, error) {
+	var paths []string
+	for _, source := range sources {
+		pathsSource, ok := source.(pathsSource)
+		if !ok {
+			continue
+		}
+		absPaths, err := pathsSource.absPaths()
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range absPaths {
+			if path != lintcontext.ReadFromStdin {
+				paths = append(paths, path)
+			}
+		}
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	nested, err := config.LoadNested(l.config, l.nestedConfigsDir, paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load nested config: %w", err)
+	}
+	return configresolver.GetOverrides(nested, l.templates)
+}
+
+// applyBaseline removes the reports recorded in the baseline from the result, and returns how many it removed.
+// If the baseline is to be updated, it is first rewritten to record all the current reports.
+func (l *Linter) applyBaseline(result *run.Result) (int, error) {
+	absPath, err := pathutil.GetAbsolutPath(l.baselinePath)
+	if err != nil {
+		return 0, err
+	}
+	// Paths are recorded relative to the baseline, so that it matches regardless of the working directory.
+	baseDir := filepath.Dir(absPath)
+
+	if l.updateBaseline {
+		if err := baseline.New(result.Reports, baseDir).Write(absPath); err != nil {
+			return 0, err
+		}
+	}
+	b, err := baseline.Load(absPath)
+	if err != nil {
+		return 0, err
+	}
+	var suppressed int
+	result.Reports, suppressed = b.Filter(result.Reports, baseDir)
+	return suppressed, nil
+}
+
+// InvalidObjectReports returns a report, for the failed-to-load-object check, for each object that could not be
+// loaded into the given contexts.
+func InvalidObjectReports(lintCtxs []lintcontext.LintContext) []diagnostic.WithContext {
+	var reports []diagnostic.WithContext
+	for _, lintCtx := range lintCtxs {
+		for _, invalidObj := range lintCtx.InvalidObjects() {
+			reports = append(reports, diagnostic.WithContext{
+				Diagnostic:  invalidObjectDiagnostic(invalidObj),
+				Check:       "failed-to-load-object",
+				Severity:    config.SeverityError,
+				Remediation: "Confirm that the file is accessible and is valid k8s yaml.",
+				Object: lintcontext.Object{
+					Metadata:  invalidObj.Metadata,
+					K8sObject: &unstructured.Unstructured{},
+				},
+			})
+		}
+	}
+	return reports
+}
+
+// invalidObjectDiagnostic describes why the object could not be loaded.
+// Invalid objects cannot be resolved field by field, so the diagnostic points at the start of the document.
+func invalidObjectDiagnostic(invalidObj lintcontext.InvalidObject) diagnostic.Diagnostic {
+	d := diagnostic.Diagnostic{Message: invalidObj.LoadErr.Error()}
+	if len(invalidObj.Metadata.Raw) > 0 {
+		d.Line, d.Column = invalidObj.Metadata.LineOffset+1, 1
+	}
+	return d
+}
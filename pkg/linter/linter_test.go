@@ -0,0 +1,201 @@
+package linter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+const pod = `apiVersion: v1
+kind: Pod
+metadata:
+  name: app
+spec:
+  containers:
+  - name: app
+    image: nginx:1.25
+`
+
+// noLabels reports objects that have no labels.
+var noLabels = check.Template{
+	Key:                  "no-labels",
+	SupportedObjectKinds: config.ObjectKindsDesc{ObjectKinds: []string{objectkinds.Any}},
+	ParseAndValidateParams: func(map[string]interface{}) (interface{}, error) {
+		return nil, nil
+	},
+	Instantiate: func(interface{}) (check.Func, error) {
+		return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+			if len(object.K8sObject.GetLabels()) > 0 {
+				return nil
+			}
+			return []diagnostic.Diagnostic{{Message: "object has no labels"}}
+		}, nil
+	},
+}
+
+func TestLint(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pod.yaml"), []byte(pod), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.yaml"), []byte("kind: Pod\nmetadata: [\n"), 0o600))
+
+	l, err := New(
+		WithConfig(config.Config{
+			Checks:       config.ChecksConfig{DoNotAutoAddDefaults: true, Include: []string{"latest-tag"}},
+			CustomChecks: []config.Check{{Name: "labels", Template: "no-labels"}},
+		}),
+		WithTemplates(noLabels),
+		WithSources(Paths(dir)),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"labels", "latest-tag"}, l.Checks())
+
+	result, err := l.Lint(context.Background(), Reader("stdin", strings.NewReader(strings.Replace(pod, "nginx:1.25", "nginx", 1))))
+	require.NoError(t, err)
+	var findings []string
+	for _, report := range result.Reports {
+		findings = append(findings, filepath.Base(report.Object.Metadata.FilePath)+": "+report.Check)
+	}
+	assert.Equal(t, []string{"pod.yaml: labels", "stdin: labels", "stdin: latest-tag"}, findings)
+	require.Len(t, result.InvalidObjects, 1)
+	assert.Equal(t, filepath.Join(dir, "invalid.yaml"), result.InvalidObjects[0].Object.Metadata.FilePath)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = l.Lint(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestLintWithStats(t *testing.T) {
+	l, err := New(
+		WithConfig(config.Config{Checks: config.ChecksConfig{DoNotAutoAddDefaults: true, Include: []string{"latest-tag"}}}),
+		WithStats(),
+	)
+	require.NoError(t, err)
+
+	result, err := l.Lint(context.Background(), Reader("stdin", strings.NewReader(strings.Replace(pod, "nginx:1.25", "nginx", 1))))
+	require.NoError(t, err)
+	stats := result.Summary.Stats
+	require.NotNil(t, stats)
+	assert.Equal(t, 1, stats.Objects)
+	assert.Equal(t, map[string]int{"latest-tag": 1}, stats.FindingsByCheck)
+	assert.Contains(t, stats.LoadDurations, lintcontext.LoaderFiles)
+}
+
+func TestReaderSource(t *testing.T) {
+	l, err := New(
+		WithConfig(config.Config{Checks: config.ChecksConfig{DoNotAutoAddDefaults: true, Include: []string{"latest-tag"}}}),
+		WithSources(Reader("stdin", strings.NewReader(strings.Replace(pod, "nginx:1.25", "nginx", 1)))),
+	)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		result, err := l.Lint(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, result.Reports, 1, "lint %d", i+1)
+	}
+
+	_, err = l.Lint(context.Background(), Reader("broken", iotest.ErrReader(assert.AnError)))
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.ErrorContains(t, err, "reading broken")
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(WithConfig(config.Config{CustomChecks: []config.Check{{Name: "labels", Template: "no-labels"}}}))
+	assert.ErrorContains(t, err, `template "no-labels" not found`)
+
+	_, err = New(WithConfig(config.Config{Checks: config.ChecksConfig{Include: []string{"unknown"}}}))
+	assert.ErrorContains(t, err, `check "unknown" not found`)
+
+	_, err = New(WithConfigFile(filepath.Join(t.TempDir(), "missing.yaml")))
+	assert.ErrorContains(t, err, "failed to load config")
+
+	_, err = New(WithFailOn("fatal"))
+	assert.ErrorContains(t, err, `invalid severity "fatal"`)
+}
+
+func TestLintWithFailOnAndBaseline(t *testing.T) {
+	latest := Reader("stdin", strings.NewReader(strings.Replace(pod, "nginx:1.25", "nginx", 1)))
+	cfg := WithConfig(config.Config{Checks: config.ChecksConfig{DoNotAutoAddDefaults: true, Include: []string{"latest-tag"}}})
+	baselinePath := filepath.Join(t.TempDir(), "baseline.yaml")
+
+	l, err := New(cfg, WithFailOn(config.SeverityInfo), WithInvalidObjectReports())
+	require.NoError(t, err)
+	result, err := l.Lint(context.Background(), latest, Reader("invalid", strings.NewReader("kind: Pod\nmetadata: [\n")))
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Objects)
+	var checks []string
+	for _, report := range result.Reports {
+		checks = append(checks, report.Check)
+	}
+	assert.Equal(t, []string{"latest-tag", "failed-to-load-object"}, checks)
+	assert.Equal(t, run.ChecksFailed, result.Summary.ChecksStatus)
+
+	l, err = New(cfg, WithFailOn(config.SeverityInfo), WithBaseline(baselinePath, true))
+	require.NoError(t, err)
+	result, err = l.Lint(context.Background(), latest)
+	require.NoError(t, err)
+	assert.Empty(t, result.Reports)
+	assert.Equal(t, 1, result.Baselined)
+	assert.Equal(t, run.ChecksPassed, result.Summary.ChecksStatus)
+
+	// The updated baseline is used by later runs.
+	l, err = New(cfg, WithBaseline(baselinePath, false))
+	require.NoError(t, err)
+	result, err = l.Lint(context.Background(), latest)
+	require.NoError(t, err)
+	assert.Empty(t, result.Reports)
+	assert.Equal(t, 1, result.Baselined)
+}
+
+func TestLintWithNestedConfigs(t *testing.T) {
+	dir := t.TempDir()
+	latest := strings.Replace(pod, "nginx:1.25", "nginx", 1)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "team"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pod.yaml"), []byte(latest), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "team", "pod.yaml"), []byte(latest), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "team", ".kube-linter.yaml"), []byte("checks:\n  include: [latest-tag]\n"), 0o600))
+
+	cfg := WithConfig(config.Config{
+		Checks:       config.ChecksConfig{DoNotAutoAddDefaults: true},
+		CustomChecks: []config.Check{{Name: "labels", Template: "no-labels"}},
+	})
+	for _, tc := range []struct {
+		name     string
+		opts     []Option
+		expected []string
+	}{
+		{name: "without nested configs", expected: []string{"pod.yaml: labels", "team/pod.yaml: labels"}},
+		{
+			name:     "with nested configs",
+			opts:     []Option{WithNestedConfigs(dir)},
+			expected: []string{"pod.yaml: labels", "team/pod.yaml: labels", "team/pod.yaml: latest-tag"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			l, err := New(append([]Option{cfg, WithTemplates(noLabels)}, tc.opts...)...)
+			require.NoError(t, err)
+			result, err := l.Lint(context.Background(), Paths(dir))
+			require.NoError(t, err)
+			var findings []string
+			for _, report := range result.Reports {
+				rel, err := filepath.Rel(dir, report.Object.Metadata.FilePath)
+				require.NoError(t, err)
+				findings = append(findings, filepath.ToSlash(rel)+": "+report.Check)
+			}
+			sort.Strings(findings)
+			assert.Equal(t, tc.expected, findings)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package linter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/viper"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// An Option configures a Linter.
+type Option func(*options) error
+
+type options struct {
+	config        config.Config
+	templates     []check.Template
+	customDecoder runtime.Decoder
+	parallelism   int
+	stats         bool
+	sources       []Source
+
+	failOn               config.Severity
+	reportInvalidObjects bool
+	nestedConfigsDir     string
+	baselinePath         string
+	updateBaseline       bool
+	skipCrossObject      bool
+}
+
+// WithConfig sets the config that determines which checks are run and how objects are loaded.
+// By default, the default checks are run with the default settings.
+func WithConfig(cfg config.Config) Option {
+	return func(o *options) error {
+		o.config = cfg
+		return nil
+	}
+}
+
+// WithConfigFile loads the config from the file at the given path, in the format of the --config flag of the
+// lint command.
+func WithConfigFile(path string) Option {
+	return func(o *options) error {
+		if path == "" {
+			return errors.New("no config file specified")
+		}
+		cfg, err := config.Load(viper.New(), path)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		o.config = cfg
+		return nil
+	}
+}
+
+// WithTemplates makes the given templates available to the custom checks of the config, in addition to the
+// templates registered with templates.Register. They take precedence over registered templates with the same key.
+func WithTemplates(templates ...check.Template) Option {
+	return func(o *options) error {
+		o.templates = append(o.templates, templates...)
+		return nil
+	}
+}
+
+// WithCustomDecoder sets the decoder that objects are parsed with. This can be used to lint custom resources.
+func WithCustomDecoder(decoder runtime.Decoder) Option {
+	return func(o *options) error {
+		o.customDecoder = decoder
+		return nil
+	}
+}
+
+// WithParallelism sets the number of objects that are checked concurrently.
+// If it is not positive, runtime.GOMAXPROCS(0) is used.
+func WithParallelism(parallelism int) Option {
+	return func(o *options) error {
+		o.parallelism = parallelism
+		return nil
+	}
+}
+
+// WithStats collects statistics about each call to Lint in the Summary.Stats of its result, including the time spent
+// loading the objects of the sources.
+func WithStats() Option {
+	return func(o *options) error {
+		o.stats = true
+		return nil
+	}
+}
+
+// WithSources adds sources that every call to Lint lints, before the sources passed to it.
+func WithSources(sources ...Source) Option {
+	return func(o *options) error {
+		o.sources = append(o.sources, sources...)
+		return nil
+	}
+}
+
+// WithFailOn sets the lowest severity of the findings that fail a run, as reported by the Summary.ChecksStatus of
+// the result. By default, findings of any severity fail it.
+func WithFailOn(severity config.Severity) Option {
+	return func(o *options) error {
+		if severity == "" {
+			o.failOn = ""
+			return nil
+		}
+		parsed, err := config.ParseSeverity(string(severity))
+		if err != nil {
+			return err
+		}
+		o.failOn = parsed
+		return nil
+	}
+}
+
+// WithInvalidObjectReports adds the InvalidObjects reports to the Reports of the result, so that objects that could
+// not be loaded are findings like any other.
+func WithInvalidObjectReports() Option {
+	return func(o *options) error {
+		o.reportInvalidObjects = true
+		return nil
+	}
+}
+
+// WithNestedConfigs runs the checks of the config files in the subdirectories of baseDir on the files beneath them,
+// like the lint command does with the working directory. They only apply to the objects of Paths sources.
+func WithNestedConfigs(baseDir string) Option {
+	return func(o *options) error {
+		o.nestedConfigsDir = baseDir
+		return nil
+	}
+}
+
+// WithBaseline removes the findings recorded in the baseline file at the given path from the result.
+// If update is set, each call to Lint first rewrites the baseline to record all the current findings.
+func WithBaseline(path string, update bool) Option {
+	return func(o *options) error {
+		if path == "" {
+			return errors.New("no baseline file specified")
+		}
+		o.baselinePath = path
+		o.updateBaseline = update
+		return nil
+	}
+}
+
+// WithoutCrossObjectChecks skips the enabled checks that look up other objects of the lint context (see
+// check.Template.NeedsOtherObjects). Use it for sources that only hold some of the objects that are deployed
+// together, like the admission requests of the webhook, for which these checks would report false positives.
+func WithoutCrossObjectChecks() Option {
+	return func(o *options) error {
+		o.skipCrossObject = true
+		return nil
+	}
+}
@@ -0,0 +1,113 @@
+package linter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/pathutil"
+	"k8s.io/client-go/kubernetes"
+)
+
+// SourceOptions are the settings of a Linter that sources load objects with.
+type SourceOptions struct {
+	// Context configures how objects are parsed and grouped into contexts.
+	Context lintcontext.Options
+	// IgnorePaths are the absolute glob patterns of the paths that objects are not loaded from.
+	IgnorePaths []string
+}
+
+// A Source loads the objects to lint, grouped into the contexts that they are linted in.
+type Source interface {
+	LintContexts(ctx context.Context, options SourceOptions) ([]lintcontext.LintContext, error)
+}
+
+// SourceFunc is a function that implements Source.
+type SourceFunc func(ctx context.Context, options SourceOptions) ([]lintcontext.LintContext, error)
+
+// LintContexts implements Source.
+func (f SourceFunc) LintContexts(ctx context.Context, options SourceOptions) ([]lintcontext.LintContext, error) {
+	return f(ctx, options)
+}
+
+// Paths loads the objects from the given files and directories, including Helm charts and Kustomize directories,
+// like the lint command does. lintcontext.ReadFromStdin reads objects from the standard input.
+func Paths(paths ...string) Source {
+	return pathsSource(paths)
+}
+
+// pathsSource is the Source returned by Paths. The Linter lists its paths to find the nested configs that apply.
+type pathsSource []string
+
+// LintContexts implements Source.
+func (p pathsSource) LintContexts(ctx context.Context, options SourceOptions) ([]lintcontext.LintContext, error) {
+	absPaths, err := p.absPaths()
+	if err != nil {
+		return nil, err
+	}
+	return lintcontext.CreateContextsWithContext(ctx, options.Context, options.IgnorePaths, absPaths...)
+}
+
+func (p pathsSource) absPaths() ([]string, error) {
+	absPaths := make([]string, 0, len(p))
+	for _, path := range p {
+		if path == lintcontext.ReadFromStdin {
+			absPaths = append(absPaths, path)
+			continue
+		}
+		absPath, err := pathutil.GetAbsolutPath(path)
+		if err != nil {
+			return nil, err
+		}
+		absPaths = append(absPaths, absPath)
+	}
+	return absPaths, nil
+}
+
+// Reader loads the YAML (or JSON) objects read from r, as a single context. The name is only used to identify
+// the objects in reports. r is read in full when Reader is called, so that the source can be linted several times.
+func Reader(name string, r io.Reader) Source {
+	data, readErr := io.ReadAll(r)
+	return SourceFunc(func(_ context.Context, options SourceOptions) ([]lintcontext.LintContext, error) {
+		if readErr != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, readErr)
+		}
+		lintCtx, err := lintcontext.CreateContextFromReader(options.Context, name, bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return []lintcontext.LintContext{lintCtx}, nil
+	})
+}
+
+// ClusterOptions configure which objects are loaded from a cluster.
+type ClusterOptions struct {
+	// Namespace restricts the objects to a single namespace. Cluster-scoped objects are only loaded
+	// if it is empty.
+	Namespace string
+	// ContextPerNamespace lints each namespace as a separate context, instead of the whole cluster as one.
+	ContextPerNamespace bool
+	// Warn, if set, is called with a warning for each resource that is skipped because listing it is forbidden.
+	Warn func(msg string)
+}
+
+// Cluster loads the objects that are deployed in the cluster that the client is connected to.
+func Cluster(client kubernetes.Interface, clusterOptions ClusterOptions) Source {
+	return SourceFunc(func(ctx context.Context, options SourceOptions) ([]lintcontext.LintContext, error) {
+		return lintcontext.CreateContextsFromCluster(ctx, client, lintcontext.ClusterOptions{
+			Options:             options.Context,
+			Namespace:           clusterOptions.Namespace,
+			ContextPerNamespace: clusterOptions.ContextPerNamespace,
+			Warn:                clusterOptions.Warn,
+		})
+	})
+}
+
+// Contexts lints contexts that were already created.
+func Contexts(lintCtxs ...lintcontext.LintContext) Source {
+	return SourceFunc(func(context.Context, SourceOptions) ([]lintcontext.LintContext, error) {
+		return lintCtxs, nil
+	})
+}
@@ -1,9 +1,13 @@
 package run
 
 import (
+	"context"
 	"fmt"
+	"runtime"
+	"sync"
 	"time"
 
+	"golang.stackrox.io/kube-linter/internal/set"
 	"golang.stackrox.io/kube-linter/internal/version"
 	"golang.stackrox.io/kube-linter/pkg/checkregistry"
 	"golang.stackrox.io/kube-linter/pkg/config"
@@ -35,51 +39,364 @@ type Summary struct {
 	ChecksStatus      CheckStatus
 	CheckEndTime      time.Time
 	KubeLinterVersion string
+	Stats             *Stats `json:",omitempty"`
 }
 
-// Run runs the linter on the given context, with the given config.
-func Run(lintCtxs []lintcontext.LintContext, registry checkregistry.CheckRegistry, checks []string) (Result, error) {
-	var result Result
+// CheckTimeoutName is the name of the check that reports checks that time out.
+const CheckTimeoutName = "check-timeout"
 
-	instantiatedChecks := make([]*instantiatedcheck.InstantiatedCheck, 0, len(checks))
+// Options represent values that can be provided to modify how the linter runs.
+type Options struct {
+	// Parallelism is the number of objects that are checked concurrently.
+	// If it is not positive, runtime.GOMAXPROCS(0) is used.
+	Parallelism int
+	// CheckTimeout is the longest that a check can take on a single object. A check that takes longer is
+	// abandoned, and reported with a CheckTimeoutName finding for the object. If it is not positive,
+	// checks do not time out.
+	CheckTimeout time.Duration
+	// Suppressions ignore the findings of checks for the objects that they match, in addition to the ones that are
+	// declared by annotations and comments.
+	Suppressions []ignore.Suppression
+	// Exclusions exclude checks for the objects that match their scope.
+	Exclusions []*ignore.Exclusion
+	// Now is the time against which the expiry of suppressions is checked. If it is zero, the current time is used.
+	Now time.Time
+	// Overrides run different checks on the objects that they match. The first override that matches an object
+	// applies to it, and the other objects are checked with the checks passed to the run, and the suppressions and
+	// exclusions of the Options.
+	Overrides []Override
+	// CollectStats, if set, collects statistics about the run in Summary.Stats.
+	CollectStats bool
+	// FailOn is the lowest severity of the findings that fail the run, as reported by Summary.ChecksStatus (see
+	// CountFailing). If it is empty, findings of any severity fail the run.
+	FailOn config.Severity
+}
+
+// An Override runs different checks, with different suppressions and exclusions, on the objects that it matches.
+// Overrides apply the configs of subdirectories to the files beneath them.
+type Override struct {
+	// Matches returns whether the override applies to the object.
+	Matches      func(obj lintcontext.Object) bool
+	Registry     checkregistry.CheckRegistry
+	Checks       []string
+	Suppressions []ignore.Suppression
+	Exclusions   []*ignore.Exclusion
+}
+
+// checkSet is the checks that run on some objects, along with the options that apply to them.
+type checkSet struct {
+	checks  []*instantiatedcheck.InstantiatedCheck
+	options Options
+}
+
+// newCheckSet loads the given checks from the registry.
+func newCheckSet(options Options, registry checkregistry.CheckRegistry, checks []string) (*checkSet, error) {
+	cs := &checkSet{options: options}
 	for _, checkName := range checks {
 		instantiatedCheck := registry.Load(checkName)
 		if instantiatedCheck == nil {
-			return Result{}, fmt.Errorf("check %q not found", checkName)
+			return nil, fmt.Errorf("check %q not found", checkName)
 		}
-		instantiatedChecks = append(instantiatedChecks, instantiatedCheck)
-		result.Checks = append(result.Checks, instantiatedCheck.Spec)
+		cs.checks = append(cs.checks, instantiatedCheck)
 	}
+	return cs, nil
+}
 
+// Run runs the linter on the given context, with the given config.
+func Run(lintCtxs []lintcontext.LintContext, registry checkregistry.CheckRegistry, checks []string) (Result, error) {
+	return RunWithOptions(Options{}, lintCtxs, registry, checks)
+}
+
+// RunWithOptions runs the linter on the given context, with the given config and additional Options.
+// Objects are checked concurrently (see check.Func for the contract that checks must satisfy), but the
+// reports are always returned in the same order: by context, then by object, then by check.
+func RunWithOptions(options Options, lintCtxs []lintcontext.LintContext, registry checkregistry.CheckRegistry, checks []string) (Result, error) {
+	return RunWithContext(context.Background(), options, lintCtxs, registry, checks)
+}
+
+// RunWithContext is like RunWithOptions, but stops when ctx is done, in which case it returns the error of ctx.
+// The context is also passed to the checks, along with their timeout (see check.ContextFunc).
+func RunWithContext(ctx context.Context, options Options, lintCtxs []lintcontext.LintContext, registry checkregistry.CheckRegistry, checks []string) (Result, error) {
+	var result Result
+	start := time.Now()
+	if options.Now.IsZero() {
+		options.Now = time.Now()
+	}
+
+	defaultChecks, err := newCheckSet(options, registry, checks)
+	if err != nil {
+		return Result{}, err
+	}
+	checkSets := []*checkSet{defaultChecks}
+	for _, override := range options.Overrides {
+		overrideOptions := options
+		overrideOptions.Suppressions, overrideOptions.Exclusions = override.Suppressions, override.Exclusions
+		cs, err := newCheckSet(overrideOptions, override.Registry, override.Checks)
+		if err != nil {
+			return Result{}, err
+		}
+		checkSets = append(checkSets, cs)
+	}
+	specNames := set.NewStringSet()
+	for _, cs := range checkSets {
+		for _, check := range cs.checks {
+			if specNames.Add(check.Spec.Name) {
+				result.Checks = append(result.Checks, check.Spec)
+			}
+		}
+	}
+
+	type job struct {
+		lintCtx  lintcontext.LintContext
+		obj      lintcontext.Object
+		checkSet *checkSet
+		// locator is shared by all the uses of the raw YAML of the object, so that it is parsed at most once.
+		locator *lintcontext.Locator
+	}
+	var jobs []job
 	for _, lintCtx := range lintCtxs {
 		for _, obj := range lintCtx.Objects() {
-			for _, check := range instantiatedChecks {
-				if !check.Matcher.Matches(obj.K8sObject.GetObjectKind().GroupVersionKind()) {
-					continue
-				}
-				if ignore.ObjectForCheck(obj.K8sObject.GetAnnotations(), check.Spec.Name) {
-					continue
+			j := job{lintCtx: lintCtx, obj: obj, checkSet: defaultChecks, locator: obj.Metadata.NewLocator()}
+			for i, override := range options.Overrides {
+				if override.Matches(obj) {
+					j.checkSet = checkSets[i+1]
+					break
 				}
-				diagnostics := check.Func(lintCtx, obj)
-				for _, d := range diagnostics {
-					result.Reports = append(result.Reports, diagnostic.WithContext{
-						Diagnostic:  d,
-						Check:       check.Spec.Name,
-						Remediation: check.Spec.Remediation,
-						Object:      obj,
-					})
+			}
+			jobs = append(jobs, j)
+		}
+	}
+
+	parallelism := options.Parallelism
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	if parallelism > len(jobs) {
+		parallelism = len(jobs)
+	}
+
+	// Each job writes its reports, and the durations of its checks, to its own slot, so that the final order does not
+	// depend on scheduling.
+	reportsByJob := make([][]diagnostic.WithContext, len(jobs))
+	ranByJob := make([]set.StringSet, len(jobs))
+	var checkDurationsByJob []map[string]time.Duration
+	if options.CollectStats {
+		checkDurationsByJob = make([]map[string]time.Duration, len(jobs))
+	}
+	jobIndices := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobIndices {
+				cs := jobs[idx].checkSet
+				var checkDurations map[string]time.Duration
+				if checkDurationsByJob != nil {
+					checkDurations = make(map[string]time.Duration)
+					checkDurationsByJob[idx] = checkDurations
 				}
+				reportsByJob[idx], ranByJob[idx] = checkObject(ctx, cs.options, jobs[idx].lintCtx, jobs[idx].obj, jobs[idx].locator, cs.checks, checkDurations)
 			}
+		}()
+	}
+dispatch:
+	for idx := range jobs {
+		select {
+		case jobIndices <- idx:
+		case <-ctx.Done():
+			break dispatch
 		}
 	}
+	close(jobIndices)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
 
-	if len(result.Reports) > 0 {
-		result.Summary.ChecksStatus = ChecksFailed
-	} else {
-		result.Summary.ChecksStatus = ChecksPassed
+	objects := make([]lintcontext.Object, 0, len(jobs))
+	locators := make([]*lintcontext.Locator, 0, len(jobs))
+	for _, j := range jobs {
+		objects = append(objects, j.obj)
+		locators = append(locators, j.locator)
+	}
+	applySuppressionComments(objects, locators, reportsByJob, ranByJob, options.Now)
+	for _, reports := range reportsByJob {
+		result.Reports = append(result.Reports, reports...)
 	}
+	result.Reports = mergeVariantReports(result.Reports)
+
+	result.Summary.ChecksStatus = Status(result.Reports, options.FailOn)
 	result.Summary.CheckEndTime = time.Now().UTC()
 	result.Summary.KubeLinterVersion = version.Get()
 
+	if options.CollectStats {
+		stats := newStats(lintCtxs)
+		for _, checkDurations := range checkDurationsByJob {
+			for check, duration := range checkDurations {
+				stats.CheckDurations[check] += duration
+			}
+		}
+		stats.CountFindings(result.Reports)
+		stats.Duration = time.Since(start)
+		result.Summary.Stats = stats
+	}
+
 	return result, nil
 }
+
+// CountFailing counts the reports that fail a run with the given FailOn threshold: the ones whose severity is at least
+// the threshold, or all of them if it is empty. Reports about suppressions, such as UnusedSuppressionName, point out
+// stale or malformed suppressions, and never fail the run.
+func CountFailing(reports []diagnostic.WithContext, failOn config.Severity) int {
+	var count int
+	for _, report := range reports {
+		if Fails(report, failOn) {
+			count++
+		}
+	}
+	return count
+}
+
+// Fails returns whether the report fails a run with the given FailOn threshold (see CountFailing).
+func Fails(report diagnostic.WithContext, failOn config.Severity) bool {
+	if failOn == "" {
+		failOn = config.SeverityInfo
+	}
+	return report.Severity.AtLeast(failOn) && !isSuppressionCheck(report.Check)
+}
+
+// Status returns ChecksFailed if any of the reports fails a run with the given FailOn threshold, and ChecksPassed
+// otherwise. Callers that add or remove reports after the run use it to update Summary.ChecksStatus.
+func Status(reports []diagnostic.WithContext, failOn config.Severity) CheckStatus {
+	if CountFailing(reports, failOn) > 0 {
+		return ChecksFailed
+	}
+	return ChecksPassed
+}
+
+// mergeVariantReports merges the reports of the same finding in several Helm variants of a chart into the first one,
+// whose Object.Metadata.Variant then lists all of the variants. Findings that only occur in some variants are
+// therefore reported once, tagged with those variants.
+func mergeVariantReports(reports []diagnostic.WithContext) []diagnostic.WithContext {
+	if len(reports) == 0 {
+		return reports
+	}
+	type findingKey struct {
+		check, message, path, filePath string
+		object                         lintcontext.K8sObjectInfo
+	}
+	indices := make(map[findingKey]int)
+	merged := make([]diagnostic.WithContext, 0, len(reports))
+	for _, report := range reports {
+		if report.Object.Metadata.Variant == "" {
+			merged = append(merged, report)
+			continue
+		}
+		key := findingKey{
+			check:    report.Check,
+			message:  report.Diagnostic.Message,
+			path:     report.Diagnostic.Path,
+			filePath: report.Object.Metadata.FilePath,
+			object:   report.Object.GetK8sObjectName(),
+		}
+		if idx, ok := indices[key]; ok {
+			merged[idx].Object.Metadata.Variant += ", " + report.Object.Metadata.Variant
+			continue
+		}
+		indices[key] = len(merged)
+		merged = append(merged, report)
+	}
+	return merged
+}
+
+// checkObject runs all the given checks against a single object, and locates their diagnostics with the locator of
+// the object. It also returns the names of the checks that ran to completion on the object, which excludes the
+// checks that do not apply to its kind, and the ones that are excluded, suppressed or timed out. If checkDurations is
+// not nil, the time that each check takes is added to it.
+func checkObject(ctx context.Context, options Options, lintCtx lintcontext.LintContext, obj lintcontext.Object, locator *lintcontext.Locator, checks []*instantiatedcheck.InstantiatedCheck, checkDurations map[string]time.Duration) ([]diagnostic.WithContext, set.StringSet) {
+	var reports []diagnostic.WithContext
+	ran := set.NewStringSet()
+	suppressions := newObjectSuppressions(options, obj)
+	for _, check := range checks {
+		if ctx.Err() != nil {
+			return nil, nil
+		}
+		if !check.Matcher.Matches(obj.K8sObject.GetObjectKind().GroupVersionKind()) {
+			continue
+		}
+		if isExcluded(options.Exclusions, obj, check.Spec.Name) || suppressions.suppresses(check.Spec.Name) {
+			continue
+		}
+		checkStart := time.Now()
+		diagnostics, timedOut := runCheckWithTimeout(ctx, options.CheckTimeout, check, lintCtx, obj)
+		if checkDurations != nil {
+			checkDurations[check.Spec.Name] += time.Since(checkStart)
+		}
+		if timedOut {
+			reports = append(reports, diagnostic.WithContext{
+				Diagnostic:  diagnostic.Diagnostic{Message: fmt.Sprintf("check %s timed out after %s", check.Spec.Name, options.CheckTimeout), Code: CheckTimeoutName},
+				Check:       CheckTimeoutName,
+				Severity:    config.SeverityError,
+				Remediation: "Increase the check timeout, or exclude the check for this object.",
+				Object:      obj,
+			})
+			continue
+		}
+		ran.Add(check.Spec.Name)
+		for _, d := range diagnostics {
+			d.LocateWith(locator)
+			if d.Code == "" {
+				d.Code = check.Spec.Template
+			}
+			reports = append(reports, diagnostic.WithContext{
+				Diagnostic:  d,
+				Check:       check.Spec.Name,
+				Severity:    check.Spec.Severity.OrDefault(),
+				Remediation: check.Spec.Remediation,
+				Object:      obj,
+			})
+		}
+	}
+	return append(reports, suppressions.reports...), ran
+}
+
+// runCheckWithTimeout runs a single check, and abandons it if it takes longer than the timeout (if it is positive)
+// or ctx is done, in which case it returns true. An abandoned check keeps running in the background until it
+// notices that its context is done.
+func runCheckWithTimeout(ctx context.Context, timeout time.Duration, check *instantiatedcheck.InstantiatedCheck, lintCtx lintcontext.LintContext, obj lintcontext.Object) ([]diagnostic.Diagnostic, bool) {
+	if timeout <= 0 && ctx.Done() == nil {
+		return runCheck(ctx, check, lintCtx, obj), false
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	done := make(chan []diagnostic.Diagnostic, 1)
+	go func() {
+		done <- runCheck(ctx, check, lintCtx, obj)
+	}()
+	select {
+	case diagnostics := <-done:
+		// A check that returns because its context is done may not have completed.
+		if ctx.Err() == nil {
+			return diagnostics, false
+		}
+	case <-ctx.Done():
+	}
+	return nil, true
+}
+
+// runCheck runs a single check, converting a panic in the check into a diagnostic so that
+// a misbehaving check does not bring down the whole run.
+func runCheck(ctx context.Context, check *instantiatedcheck.InstantiatedCheck, lintCtx lintcontext.LintContext, obj lintcontext.Object) (diagnostics []diagnostic.Diagnostic) {
+	defer func() {
+		if r := recover(); r != nil {
+			diagnostics = []diagnostic.Diagnostic{{
+				Message: fmt.Sprintf("check panicked while processing object: %v", r),
+			}}
+		}
+	}()
+	return check.ContextFunc(ctx, lintCtx, obj)
+}
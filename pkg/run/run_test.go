@@ -0,0 +1,477 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/checkregistry"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/ignore"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	_ "golang.stackrox.io/kube-linter/pkg/templates/hostnetwork"
+	_ "golang.stackrox.io/kube-linter/pkg/templates/hostpid"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	echoTemplateKey  = "run-test-echo"
+	panicTemplateKey = "run-test-panic"
+	slowTemplateKey  = "run-test-slow"
+)
+
+func init() {
+	for key, f := range map[string]check.Func{
+		echoTemplateKey: func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+			return []diagnostic.Diagnostic{{Message: object.K8sObject.GetName()}}
+		},
+		panicTemplateKey: func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+			if object.K8sObject.GetName() == "pod-3" {
+				panic("boom")
+			}
+			return nil
+		},
+	} {
+		templates.Register(check.Template{
+			Key:                  key,
+			SupportedObjectKinds: config.ObjectKindsDesc{ObjectKinds: []string{objectkinds.Any}},
+			ParseAndValidateParams: func(map[string]interface{}) (interface{}, error) {
+				return nil, nil
+			},
+			Instantiate: func(interface{}) (check.Func, error) {
+				return f, nil
+			},
+		})
+	}
+
+	// The slow check blocks on pod-1 until its context is done.
+	slow := check.ContextFunc(func(ctx context.Context, _ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+		if object.K8sObject.GetName() == "pod-1" {
+			<-ctx.Done()
+		}
+		return []diagnostic.Diagnostic{{Message: object.K8sObject.GetName()}}
+	})
+	templates.Register(check.Template{
+		Key:                  slowTemplateKey,
+		SupportedObjectKinds: config.ObjectKindsDesc{ObjectKinds: []string{objectkinds.Any}},
+		ParseAndValidateParams: func(map[string]interface{}) (interface{}, error) {
+			return nil, nil
+		},
+		Instantiate: func(interface{}) (check.Func, error) {
+			return slow.WithoutContext(), nil
+		},
+		InstantiateWithContext: func(interface{}) (check.ContextFunc, error) {
+			return slow, nil
+		},
+	})
+}
+
+type fakeContext []lintcontext.Object
+
+func (f fakeContext) Objects() []lintcontext.Object {
+	return f
+}
+
+func (f fakeContext) InvalidObjects() []lintcontext.InvalidObject {
+	return nil
+}
+
+func newRegistry(t *testing.T) checkregistry.CheckRegistry {
+	registry := checkregistry.New()
+	require.NoError(t, registry.Register(
+		&config.Check{Name: "echo", Template: echoTemplateKey},
+		&config.Check{Name: "panic", Template: panicTemplateKey},
+		&config.Check{Name: "slow", Template: slowTemplateKey},
+	))
+	return registry
+}
+
+func newContexts(numContexts, objectsPerContext int) []lintcontext.LintContext {
+	var lintCtxs []lintcontext.LintContext
+	for i := 0; i < numContexts; i++ {
+		var ctx fakeContext
+		for j := 0; j < objectsPerContext; j++ {
+			ctx = append(ctx, lintcontext.Object{K8sObject: &v1.Pod{
+				TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				ObjectMeta: metaV1.ObjectMeta{Name: fmt.Sprintf("pod-%d", i*objectsPerContext+j)},
+			}})
+		}
+		lintCtxs = append(lintCtxs, ctx)
+	}
+	return lintCtxs
+}
+
+func TestRunIsDeterministic(t *testing.T) {
+	registry := newRegistry(t)
+	lintCtxs := newContexts(3, 50)
+
+	sequential, err := RunWithOptions(Options{Parallelism: 1}, lintCtxs, registry, []string{"echo"})
+	require.NoError(t, err)
+	require.Len(t, sequential.Reports, 150)
+	for i, report := range sequential.Reports {
+		assert.Equal(t, fmt.Sprintf("pod-%d", i), report.Diagnostic.Message)
+	}
+
+	for _, parallelism := range []int{0, 4, 200} {
+		parallel, err := RunWithOptions(Options{Parallelism: parallelism}, lintCtxs, registry, []string{"echo"})
+		require.NoError(t, err)
+		assert.Equal(t, sequential.Reports, parallel.Reports, "parallelism %d", parallelism)
+	}
+}
+
+func TestRunIsolatesPanickingChecks(t *testing.T) {
+	result, err := RunWithOptions(Options{Parallelism: 4}, newContexts(1, 5), newRegistry(t), []string{"echo", "panic"})
+	require.NoError(t, err)
+
+	require.Len(t, result.Reports, 6)
+	panicReport := result.Reports[4]
+	assert.Equal(t, "panic", panicReport.Check)
+	assert.Equal(t, "pod-3", panicReport.Object.K8sObject.GetName())
+	assert.Contains(t, panicReport.Diagnostic.Message, "boom")
+	assert.Equal(t, ChecksFailed, result.Summary.ChecksStatus)
+}
+
+func TestRunMergesVariantReports(t *testing.T) {
+	variantContext := func(variant string, names ...string) fakeContext {
+		var ctx fakeContext
+		for _, name := range names {
+			ctx = append(ctx, lintcontext.Object{
+				Metadata: lintcontext.ObjectMetadata{FilePath: "chart/templates/pod.yaml", Variant: variant},
+				K8sObject: &v1.Pod{
+					TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+					ObjectMeta: metaV1.ObjectMeta{Name: name},
+				},
+			})
+		}
+		return ctx
+	}
+	lintCtxs := []lintcontext.LintContext{
+		variantContext("dev", "pod-a"),
+		variantContext("prod", "pod-a", "pod-b"),
+		variantContext("staging", "pod-a"),
+	}
+
+	result, err := Run(lintCtxs, newRegistry(t), []string{"echo"})
+	require.NoError(t, err)
+	require.Len(t, result.Reports, 2)
+	assert.Equal(t, "pod-a", result.Reports[0].Diagnostic.Message)
+	assert.Equal(t, "dev, prod, staging", result.Reports[0].Object.Metadata.Variant)
+	assert.Equal(t, "pod-b", result.Reports[1].Diagnostic.Message)
+	assert.Equal(t, "prod", result.Reports[1].Object.Metadata.Variant)
+}
+
+func TestRunTimesOutChecks(t *testing.T) {
+	result, err := RunWithOptions(Options{Parallelism: 2, CheckTimeout: 50 * time.Millisecond}, newContexts(1, 3), newRegistry(t), []string{"echo", "slow"})
+	require.NoError(t, err)
+
+	var reports []string
+	for _, report := range result.Reports {
+		reports = append(reports, report.Check+": "+report.Diagnostic.Message)
+	}
+	assert.Equal(t, []string{
+		"echo: pod-0",
+		"slow: pod-0",
+		"echo: pod-1",
+		"check-timeout: check slow timed out after 50ms",
+		"echo: pod-2",
+		"slow: pod-2",
+	}, reports)
+	assert.Equal(t, "pod-1", result.Reports[3].Object.K8sObject.GetName())
+
+	// Diagnostics without a code get the key of the template of their check.
+	assert.Equal(t, echoTemplateKey, result.Reports[0].Diagnostic.Code)
+	assert.Equal(t, CheckTimeoutName, result.Reports[3].Diagnostic.Code)
+}
+
+func TestInstantiatedChecksHaveBothFuncs(t *testing.T) {
+	registry := newRegistry(t)
+	obj := newContexts(1, 1)[0].Objects()[0]
+	for _, name := range []string{"echo", "slow"} {
+		instantiated := registry.Load(name)
+		require.NotNil(t, instantiated)
+		expected := []diagnostic.Diagnostic{{Message: "pod-0"}}
+		assert.Equal(t, expected, instantiated.Func(nil, obj), name)
+		assert.Equal(t, expected, instantiated.ContextFunc(context.Background(), nil, obj), name)
+	}
+}
+
+func TestRunWithCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+	_, err := RunWithContext(ctx, Options{Parallelism: 1}, newContexts(1, 3), newRegistry(t), []string{"slow"})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRunAppliesSuppressionComments(t *testing.T) {
+	pod := func(name, filePath string, lineOffset int, raw string) lintcontext.Object {
+		return lintcontext.Object{
+			Metadata: lintcontext.ObjectMetadata{FilePath: filePath, LineOffset: lineOffset, Raw: []byte(raw)},
+			K8sObject: &v1.Pod{
+				TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				ObjectMeta: metaV1.ObjectMeta{Name: name},
+			},
+		}
+	}
+	lintCtxs := []lintcontext.LintContext{fakeContext{
+		pod("pod-a", "a.yaml", 0, "# kube-linter:ignore-file echo -- Echoes are expected here\nkind: Pod\n"),
+		pod("pod-b", "a.yaml", 3, "kind: Pod\n"),
+		pod("pod-c", "c.yaml", 0, "kind: Pod\nmetadata: # kube-linter:ignore-document echo\n  name: pod-c\n"),
+		pod("pod-d", "d.yaml", 0, "kind: Pod\n# kube-linter:ignore echo -- Not reported on the kind\nkind2: Pod\n"),
+		pod("pod-e", "e.yaml", 0, "kind: Pod\n# kube-linter:ignore panic -- The panic check does not run\nmetadata: {}\n"),
+	}}
+
+	result, err := Run(lintCtxs, newRegistry(t), []string{"echo"})
+	require.NoError(t, err)
+
+	var reports []string
+	for _, report := range result.Reports {
+		reports = append(reports, fmt.Sprintf("%s: %s (%s:%d:%d)", report.Check, report.Diagnostic.Message,
+			report.Object.Metadata.FilePath, report.Diagnostic.Line, report.Diagnostic.Column))
+	}
+	assert.Equal(t, []string{
+		"suppression-without-reason: suppression of echo does not give a reason (c.yaml:2:11)",
+		"echo: pod-d (d.yaml:1:1)",
+		"unused-suppression: suppression of echo does not match any finding (d.yaml:2:1)",
+		"echo: pod-e (e.yaml:1:1)",
+	}, reports)
+}
+
+func TestRunOnlyReportsUnusedSuppressionsOfChecksThatRan(t *testing.T) {
+	pod := func(name string, labels, annotations map[string]string) lintcontext.Object {
+		return lintcontext.Object{
+			Metadata: lintcontext.ObjectMetadata{FilePath: name + ".yaml", Raw: []byte("kind: Pod\n# kube-linter:ignore echo -- Not reported on the kind\nmetadata: {}\n")},
+			K8sObject: &v1.Pod{
+				TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				ObjectMeta: metaV1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations},
+			},
+		}
+	}
+	lintCtxs := []lintcontext.LintContext{fakeContext{
+		pod("excluded", map[string]string{"team": "legacy"}, nil),
+		pod("annotated", nil, map[string]string{"ignore-check.kube-linter.io/echo": "Echoes are expected"}),
+		pod("checked", nil, nil),
+	}}
+	exclusion, err := ignore.NewExclusion([]string{"echo"}, "", map[string]string{"team": "legacy"}, nil)
+	require.NoError(t, err)
+
+	result, err := RunWithOptions(Options{Exclusions: []*ignore.Exclusion{exclusion}}, lintCtxs, newRegistry(t), []string{"echo"})
+	require.NoError(t, err)
+
+	var reports []string
+	for _, report := range result.Reports {
+		reports = append(reports, fmt.Sprintf("%s: %s (%s)", report.Check, report.Diagnostic.Message, report.Object.Metadata.FilePath))
+	}
+	// The comments of the objects on which echo did not run are not reported as unused.
+	assert.Equal(t, []string{
+		"echo: checked (checked.yaml)",
+		"unused-suppression: suppression of echo does not match any finding (checked.yaml)",
+	}, reports)
+}
+
+func TestRunStatusFollowsFailOn(t *testing.T) {
+	registry := newRegistry(t)
+	require.NoError(t, registry.Register(&config.Check{Name: "warn", Template: echoTemplateKey, Severity: config.SeverityWarning}))
+	withFinding := newContexts(1, 1)
+	withUnusedSuppression := []lintcontext.LintContext{fakeContext{{
+		Metadata: lintcontext.ObjectMetadata{FilePath: "pod.yaml", Raw: []byte("kind: Pod\n# kube-linter:ignore panic -- Does not panic\nmetadata: {}\n")},
+		K8sObject: &v1.Pod{
+			TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metaV1.ObjectMeta{Name: "pod-0"},
+		},
+	}}}
+
+	for _, tc := range []struct {
+		name     string
+		lintCtxs []lintcontext.LintContext
+		check    string
+		failOn   config.Severity
+		status   CheckStatus
+	}{
+		{name: "warning without threshold", lintCtxs: withFinding, check: "warn", status: ChecksFailed},
+		{name: "warning failing on info", lintCtxs: withFinding, check: "warn", failOn: config.SeverityInfo, status: ChecksFailed},
+		{name: "warning failing on warning", lintCtxs: withFinding, check: "warn", failOn: config.SeverityWarning, status: ChecksFailed},
+		{name: "warning failing on error", lintCtxs: withFinding, check: "warn", failOn: config.SeverityError, status: ChecksPassed},
+		{name: "unused suppression", lintCtxs: withUnusedSuppression, check: "panic", failOn: config.SeverityInfo, status: ChecksPassed},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := RunWithOptions(Options{FailOn: tc.failOn}, tc.lintCtxs, registry, []string{tc.check})
+			require.NoError(t, err)
+			require.NotEmpty(t, result.Reports)
+			assert.Equal(t, tc.status, result.Summary.ChecksStatus)
+			// The status agrees with the count of failing reports, from which kube-linter lint derives its exit code.
+			assert.Equal(t, tc.status == ChecksFailed, CountFailing(result.Reports, tc.failOn) > 0)
+		})
+	}
+}
+
+func TestRunAppliesSuppressionCommentsToTemplateFindings(t *testing.T) {
+	const deployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: agent
+spec:
+  template:
+    spec:
+      # kube-linter:ignore host-network -- The agent monitors the host network
+      hostNetwork: true
+      hostPID: true # kube-linter:ignore host-pid -- The agent inspects host processes
+      containers:
+      - name: agent
+        image: agent:1.0
+`
+	lintCtx, err := lintcontext.CreateContextFromReader(lintcontext.Options{}, "deployment.yaml", strings.NewReader(deployment))
+	require.NoError(t, err)
+	registry := checkregistry.New()
+	require.NoError(t, registry.Register(
+		&config.Check{Name: "host-network", Template: "host-network"},
+		&config.Check{Name: "host-pid", Template: "host-pid"},
+	))
+
+	result, err := Run([]lintcontext.LintContext{lintCtx}, registry, []string{"host-network", "host-pid"})
+	require.NoError(t, err)
+	assert.Empty(t, result.Reports)
+}
+
+func TestRunExpiresSuppressions(t *testing.T) {
+	pod := func(name string, annotations map[string]string, raw string) lintcontext.Object {
+		return lintcontext.Object{
+			Metadata: lintcontext.ObjectMetadata{FilePath: name + ".yaml", Raw: []byte(raw)},
+			K8sObject: &v1.Pod{
+				TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				ObjectMeta: metaV1.ObjectMeta{Name: name, Annotations: annotations},
+			},
+		}
+	}
+	lintCtxs := []lintcontext.LintContext{fakeContext{
+		pod("pod-a", map[string]string{"ignore-check.kube-linter.io/echo": "until=2026-06-30; reason=Active"}, ""),
+		pod("pod-b", map[string]string{"ignore-check.kube-linter.io/echo": "until=2026-05-31; reason=Expired"}, ""),
+		pod("pod-c", map[string]string{"kube-linter.io/ignore-all": "until=2026-13-01"}, ""),
+		pod("pod-d", nil, "# kube-linter:ignore-document echo -- until=2026-01-01; reason=Expired\nkind: Pod\n"),
+		pod("pod-e", nil, ""),
+		pod("pod-f", nil, ""),
+	}}
+	options := Options{
+		Now: time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+		Suppressions: []ignore.Suppression{
+			{Checks: []string{"echo"}, Name: "pod-e", Justification: ignore.Justification{Until: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)}},
+			{Checks: []string{"echo"}, Name: "pod-f", Justification: ignore.Justification{Until: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)}},
+		},
+	}
+
+	result, err := RunWithOptions(options, lintCtxs, newRegistry(t), []string{"echo"})
+	require.NoError(t, err)
+
+	var reports []string
+	for _, report := range result.Reports {
+		reports = append(reports, fmt.Sprintf("%s: %s", report.Check, report.Diagnostic.Message))
+	}
+	assert.Equal(t, []string{
+		"echo: pod-b",
+		"expired-suppression: annotation ignore-check.kube-linter.io/echo expired on 2026-05-31",
+		"echo: pod-c",
+		`invalid-suppression: annotation kube-linter.io/ignore-all does not apply: invalid expiry date "2026-13-01", must be in the format YYYY-MM-DD`,
+		"echo: pod-d",
+		"expired-suppression: suppression of echo expired on 2026-01-01",
+		"echo: pod-f",
+		"expired-suppression: suppression of echo in the config expired on 2026-05-01",
+	}, reports)
+}
+
+func TestRunAppliesExclusions(t *testing.T) {
+	exclusion, err := ignore.NewExclusion([]string{"echo"}, "^kube-system$", nil, nil)
+	require.NoError(t, err)
+	lintCtxs := []lintcontext.LintContext{fakeContext{
+		{K8sObject: &v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "pod-a", Namespace: "kube-system"}}},
+		{K8sObject: &v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "pod-b", Namespace: "default"}}},
+	}}
+
+	result, err := RunWithOptions(Options{Exclusions: []*ignore.Exclusion{exclusion}}, lintCtxs, newRegistry(t), []string{"echo"})
+	require.NoError(t, err)
+	require.Len(t, result.Reports, 1)
+	assert.Equal(t, "pod-b", result.Reports[0].Diagnostic.Message)
+}
+
+func TestRunAppliesOverrides(t *testing.T) {
+	registry := newRegistry(t)
+	exclusion, err := ignore.NewExclusion([]string{"echo"}, "^kube-system$", nil, nil)
+	require.NoError(t, err)
+	lintCtxs := []lintcontext.LintContext{fakeContext{
+		{K8sObject: &v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "pod-a", Namespace: "kube-system"}}, Metadata: lintcontext.ObjectMetadata{FilePath: "/repo/a.yaml"}},
+		{K8sObject: &v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "pod-b", Namespace: "kube-system"}}, Metadata: lintcontext.ObjectMetadata{FilePath: "/repo/team/b.yaml"}},
+		{K8sObject: &v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "pod-3"}}, Metadata: lintcontext.ObjectMetadata{FilePath: "/repo/other/c.yaml"}},
+		{K8sObject: &v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "pod-3"}}, Metadata: lintcontext.ObjectMetadata{FilePath: "/repo/team/d.yaml"}},
+	}}
+	inDir := func(dir string) func(lintcontext.Object) bool {
+		return func(obj lintcontext.Object) bool {
+			return strings.HasPrefix(obj.Metadata.FilePath, dir)
+		}
+	}
+
+	result, err := RunWithOptions(Options{
+		Exclusions: []*ignore.Exclusion{exclusion},
+		Overrides: []Override{
+			{Matches: inDir("/repo/team/"), Registry: registry, Checks: []string{"echo"}},
+			{Matches: inDir("/repo/"), Registry: registry, Checks: []string{"panic"}},
+		},
+	}, lintCtxs, registry, []string{"echo"})
+	require.NoError(t, err)
+
+	var reports []string
+	for _, report := range result.Reports {
+		reports = append(reports, report.Check+": "+report.Diagnostic.Message)
+	}
+	// The exclusions of the options do not apply to the objects of overrides, and only the first matching override
+	// applies.
+	assert.Equal(t, []string{
+		"echo: pod-b",
+		"panic: check panicked while processing object: boom",
+		"echo: pod-3",
+	}, reports)
+	var checks []string
+	for _, check := range result.Checks {
+		checks = append(checks, check.Name)
+	}
+	assert.Equal(t, []string{"echo", "panic"}, checks)
+}
+
+func TestRunCollectsStats(t *testing.T) {
+	lintCtxs := newContexts(2, 3)
+	lintCtxs[1] = append(lintCtxs[1].(fakeContext), lintcontext.Object{K8sObject: &v1.Service{
+		TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metaV1.ObjectMeta{Name: "svc", Namespace: "default"},
+	}})
+
+	result, err := RunWithOptions(Options{}, lintCtxs, newRegistry(t), []string{"echo"})
+	require.NoError(t, err)
+	assert.Nil(t, result.Summary.Stats)
+
+	result, err = RunWithOptions(Options{Parallelism: 4, CollectStats: true}, lintCtxs, newRegistry(t), []string{"echo", "panic"})
+	require.NoError(t, err)
+	stats := result.Summary.Stats
+	require.NotNil(t, stats)
+	assert.Equal(t, 2, stats.Contexts)
+	assert.Equal(t, 7, stats.Objects)
+	assert.Equal(t, map[string]int{"echo": 7, "panic": 1}, stats.FindingsByCheck)
+	assert.Equal(t, map[string]int{"Pod": 7, "Service": 1}, stats.FindingsByKind)
+	assert.Equal(t, map[string]int{"": 7, "default": 1}, stats.FindingsByNamespace)
+	assert.ElementsMatch(t, []string{"echo", "panic"}, slices.Collect(maps.Keys(stats.CheckDurations)))
+	assert.Positive(t, stats.Duration)
+
+	// Counting findings again replaces the previous counts.
+	stats.CountFindings(result.Reports[:1])
+	assert.Equal(t, map[string]int{"echo": 1}, stats.FindingsByCheck)
+}
@@ -0,0 +1,59 @@
+package run
+
+import (
+	"time"
+
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+)
+
+// Stats holds statistics about a linter run. They are only collected if Options.CollectStats is set.
+// Durations are in nanoseconds in JSON.
+type Stats struct {
+	// Contexts, Objects and InvalidObjects count the lint contexts, and the objects that were loaded into them.
+	Contexts       int
+	Objects        int
+	InvalidObjects int
+
+	// FindingsByCheck, FindingsByKind and FindingsByNamespace count the findings by check, and by kind and namespace
+	// of their object. Findings on objects without a namespace are counted under the empty namespace.
+	FindingsByCheck     map[string]int
+	FindingsByKind      map[string]int
+	FindingsByNamespace map[string]int
+
+	// Duration is the wall time of the run of the checks.
+	Duration time.Duration
+	// CheckDurations is the time that each check took, summed over the objects that it checked. As objects are
+	// checked concurrently, the sum over all checks can exceed Duration.
+	CheckDurations map[string]time.Duration
+	// LoadDurations is the time spent in each loader (see lintcontext.LoadStats). The run does not load the
+	// contexts, so it is left to the caller to set it.
+	LoadDurations map[string]time.Duration `json:",omitempty"`
+}
+
+// newStats returns the stats of the objects of the given contexts.
+func newStats(lintCtxs []lintcontext.LintContext) *Stats {
+	stats := &Stats{
+		Contexts:       len(lintCtxs),
+		CheckDurations: make(map[string]time.Duration),
+	}
+	for _, lintCtx := range lintCtxs {
+		stats.Objects += len(lintCtx.Objects())
+		stats.InvalidObjects += len(lintCtx.InvalidObjects())
+	}
+	return stats
+}
+
+// CountFindings counts the given reports, replacing the previous counts. Callers that add or remove reports after
+// the run, for example with a baseline, call it again so that the counts match the reports.
+func (s *Stats) CountFindings(reports []diagnostic.WithContext) {
+	s.FindingsByCheck = make(map[string]int)
+	s.FindingsByKind = make(map[string]int)
+	s.FindingsByNamespace = make(map[string]int)
+	for _, report := range reports {
+		object := report.Object.GetK8sObjectName()
+		s.FindingsByCheck[report.Check]++
+		s.FindingsByKind[object.GroupVersionKind.Kind]++
+		s.FindingsByNamespace[object.Namespace]++
+	}
+}
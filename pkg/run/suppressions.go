@@ -0,0 +1,276 @@
+package run
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/internal/stringutils"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/ignore"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+)
+
+const (
+	// UnusedSuppressionName is the name of the check that reports suppression comments that do not match any finding
+	// of the checks that they name.
+	UnusedSuppressionName = "unused-suppression"
+	// SuppressionWithoutReasonName is the name of the check that reports suppression comments that do not give a
+	// reason.
+	SuppressionWithoutReasonName = "suppression-without-reason"
+	// ExpiredSuppressionName is the name of the check that reports suppressions whose expiry date has passed, and
+	// which no longer apply.
+	ExpiredSuppressionName = "expired-suppression"
+	// InvalidSuppressionName is the name of the check that reports suppressions whose justification cannot be
+	// parsed, and which do not apply.
+	InvalidSuppressionName = "invalid-suppression"
+)
+
+// isSuppressionCheck returns whether the given check is one of the checks that report problems with suppressions.
+func isSuppressionCheck(check string) bool {
+	switch check {
+	case UnusedSuppressionName, SuppressionWithoutReasonName, ExpiredSuppressionName, InvalidSuppressionName:
+		return true
+	}
+	return false
+}
+
+// objectSuppressions are the suppressions that apply to an object, declared by its annotations or in the config.
+type objectSuppressions struct {
+	obj lintcontext.Object
+	now time.Time
+	// allChecks is set if the object is annotated to ignore all checks.
+	allChecks bool
+	checks    set.StringSet
+	config    []ignore.Suppression
+	// expiredConfig holds the indices of the expired suppressions of the config that have been reported for the
+	// object, so that they are only reported once.
+	expiredConfig map[int]bool
+	reports       []diagnostic.WithContext
+}
+
+// newObjectSuppressions parses the suppression annotations of the object. Invalid and expired annotations are
+// reported, and do not apply.
+func newObjectSuppressions(options Options, obj lintcontext.Object) *objectSuppressions {
+	s := &objectSuppressions{obj: obj, now: options.Now, checks: set.NewStringSet(), config: options.Suppressions, expiredConfig: make(map[int]bool)}
+	annotations := obj.K8sObject.GetAnnotations()
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		checkName := key
+		if key != ignore.AllAnnotationKey && !stringutils.ConsumePrefix(&checkName, ignore.AnnotationKeyPrefix) {
+			continue
+		}
+		j, err := ignore.ParseJustification(annotations[key])
+		switch {
+		case err != nil:
+			s.reports = append(s.reports, suppressionReport(obj, diagnostic.Diagnostic{
+				Message: fmt.Sprintf("annotation %s does not apply: %v", key, err),
+			}, InvalidSuppressionName, invalidRemediation))
+		case j.Expired(s.now):
+			s.reports = append(s.reports, suppressionReport(obj, diagnostic.Diagnostic{
+				Message: fmt.Sprintf("annotation %s expired on %s", key, j.UntilString()),
+			}, ExpiredSuppressionName, expiredRemediation))
+		case key == ignore.AllAnnotationKey:
+			s.allChecks = true
+		default:
+			s.checks.Add(checkName)
+		}
+	}
+	return s
+}
+
+const (
+	expiredRemediation = "Fix the findings that the suppression ignores, or extend the suppression if they are still justified."
+	invalidRemediation = "Fix the justification of the suppression, for example: until=2026-12-31; reason=<reason>"
+)
+
+// suppresses returns whether the findings of the given check are suppressed for the object. Expired suppressions
+// of the config that would apply are reported.
+func (s *objectSuppressions) suppresses(checkName string) bool {
+	suppressed := s.allChecks || s.checks.Contains(checkName)
+	for i := range s.config {
+		suppression := &s.config[i]
+		if !suppression.Matches(s.obj.K8sObject, checkName) {
+			continue
+		}
+		if !suppression.Expired(s.now) {
+			suppressed = true
+			continue
+		}
+		if !s.expiredConfig[i] {
+			s.expiredConfig[i] = true
+			s.reports = append(s.reports, suppressionReport(s.obj, diagnostic.Diagnostic{
+				Message: fmt.Sprintf("suppression of %s in the config expired on %s", strings.Join(suppression.Checks, ", "), suppression.UntilString()),
+			}, ExpiredSuppressionName, expiredRemediation))
+		}
+	}
+	return suppressed
+}
+
+// suppression is a suppression comment, along with the object whose raw YAML it is in.
+type suppression struct {
+	ignore.Comment
+	justification ignore.Justification
+	// justificationErr is the error from parsing the reason of the comment as a justification, if any.
+	justificationErr error
+	object           lintcontext.Object
+	// active is set if the justification of the comment is valid and has not expired.
+	active bool
+	used   bool
+}
+
+// documentKey identifies a YAML document, which can contain several objects if it is a list.
+type documentKey struct {
+	filePath, variant string
+	lineOffset        int
+}
+
+type fileKey struct {
+	filePath, variant string
+}
+
+// applySuppressionComments removes the reports that are suppressed by the comments in the files of the objects, and
+// adds reports for the comments that do not give a reason, or that do not match any finding of the checks that they
+// name. A comment is only reported as unused if all the checks that it names ran on the objects that it applies to,
+// according to ranByObject: a check that was excluded or suppressed by other means did not have a chance to match.
+// Comments whose reason is an invalid or expired justification are reported, and do not apply. locators and
+// reportsByObject hold the locator and the reports of each object, and reportsByObject is modified in place.
+func applySuppressionComments(objects []lintcontext.Object, locators []*lintcontext.Locator, reportsByObject [][]diagnostic.WithContext, ranByObject []set.StringSet, now time.Time) {
+	suppressionsByDocument := make(map[documentKey][]*suppression)
+	fileSuppressions := make(map[fileKey][]*suppression)
+	// The checks that ran on any of the objects of each document, and of each file.
+	ranByDocument := make(map[documentKey]set.StringSet)
+	ranByFile := make(map[fileKey]set.StringSet)
+	for i, obj := range objects {
+		key := documentKey{filePath: obj.Metadata.FilePath, variant: obj.Metadata.Variant, lineOffset: obj.Metadata.LineOffset}
+		fileKey := fileKey{filePath: key.filePath, variant: key.variant}
+		ranByDocument[key] = ranByDocument[key].Union(ranByObject[i])
+		ranByFile[fileKey] = ranByFile[fileKey].Union(ranByObject[i])
+	}
+	// The index of the first object of each document, which the reports about its comments are added to.
+	firstObjectOfDocument := make(map[documentKey]int)
+	var documents []documentKey
+	for i, obj := range objects {
+		key := documentKey{filePath: obj.Metadata.FilePath, variant: obj.Metadata.Variant, lineOffset: obj.Metadata.LineOffset}
+		if _, parsed := firstObjectOfDocument[key]; parsed {
+			continue
+		}
+		firstObjectOfDocument[key] = i
+		documents = append(documents, key)
+		for _, comment := range ignore.ParseObjectComments(locators[i]) {
+			s := &suppression{Comment: comment, object: obj}
+			s.justification, s.justificationErr = ignore.ParseJustification(comment.Reason)
+			s.active = s.justificationErr == nil && !s.justification.Expired(now)
+			if comment.Scope == ignore.ScopeFile {
+				fileKey := fileKey{filePath: key.filePath, variant: key.variant}
+				fileSuppressions[fileKey] = append(fileSuppressions[fileKey], s)
+			}
+			suppressionsByDocument[key] = append(suppressionsByDocument[key], s)
+		}
+	}
+	if len(suppressionsByDocument) == 0 {
+		return
+	}
+
+	for i, obj := range objects {
+		documentSuppressions := suppressionsByDocument[documentKey{filePath: obj.Metadata.FilePath, variant: obj.Metadata.Variant, lineOffset: obj.Metadata.LineOffset}]
+		suppressions := append(append([]*suppression(nil), documentSuppressions...), fileSuppressions[fileKey{filePath: obj.Metadata.FilePath, variant: obj.Metadata.Variant}]...)
+		if len(suppressions) == 0 {
+			continue
+		}
+		kept := reportsByObject[i][:0]
+		for _, report := range reportsByObject[i] {
+			if !suppress(suppressions, report) {
+				kept = append(kept, report)
+			}
+		}
+		reportsByObject[i] = kept
+	}
+
+	for _, key := range documents {
+		idx := firstObjectOfDocument[key]
+		for _, s := range suppressionsByDocument[key] {
+			checkNames := strings.Join(s.Checks, ", ")
+			if s.justificationErr != nil {
+				reportsByObject[idx] = append(reportsByObject[idx], s.report(InvalidSuppressionName,
+					fmt.Sprintf("suppression of %s does not apply: %v", checkNames, s.justificationErr), invalidRemediation))
+				continue
+			}
+			if !s.active {
+				reportsByObject[idx] = append(reportsByObject[idx], s.report(ExpiredSuppressionName,
+					fmt.Sprintf("suppression of %s expired on %s", checkNames, s.justification.UntilString()), expiredRemediation))
+				continue
+			}
+			if s.justification.Reason == "" {
+				reportsByObject[idx] = append(reportsByObject[idx], s.report(SuppressionWithoutReasonName,
+					fmt.Sprintf("suppression of %s does not give a reason", checkNames),
+					`Explain why the findings are suppressed after "--", for example: # kube-linter:ignore <check> -- <reason>`))
+			}
+			ran := ranByDocument[key]
+			if s.Scope == ignore.ScopeFile {
+				ran = ranByFile[fileKey{filePath: key.filePath, variant: key.variant}]
+			}
+			if !s.used && allRan(ran, s.Checks) {
+				reportsByObject[idx] = append(reportsByObject[idx], s.report(UnusedSuppressionName,
+					fmt.Sprintf("suppression of %s does not match any finding", checkNames),
+					"Remove the suppression comment, or move it to the part of the file that the findings are reported for."))
+			}
+		}
+	}
+}
+
+// suppress returns whether one of the suppressions applies to the report. All of the suppressions that apply are
+// marked as used.
+func suppress(suppressions []*suppression, report diagnostic.WithContext) bool {
+	suppressed := false
+	for _, s := range suppressions {
+		if s.active && s.AppliesTo(report.Check, report.Diagnostic.Path) {
+			s.used = true
+			suppressed = true
+		}
+	}
+	return suppressed
+}
+
+// isExcluded returns whether one of the exclusions excludes the given check for the object.
+func isExcluded(exclusions []*ignore.Exclusion, obj lintcontext.Object, checkName string) bool {
+	for _, exclusion := range exclusions {
+		if exclusion.Matches(obj, checkName) {
+			return true
+		}
+	}
+	return false
+}
+
+// allRan returns whether all the named checks are in the set of checks that ran.
+func allRan(checks set.StringSet, names []string) bool {
+	for _, name := range names {
+		if !checks.Contains(name) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *suppression) report(check, message, remediation string) diagnostic.WithContext {
+	line, column := s.object.Metadata.Position(s.Line, s.Column)
+	return suppressionReport(s.object, diagnostic.Diagnostic{Message: message, Line: line, Column: column}, check, remediation)
+}
+
+func suppressionReport(obj lintcontext.Object, d diagnostic.Diagnostic, check, remediation string) diagnostic.WithContext {
+	d.Code = check
+	return diagnostic.WithContext{
+		Diagnostic:  d,
+		Check:       check,
+		Severity:    config.SeverityWarning,
+		Remediation: remediation,
+		Object:      obj,
+	}
+}
@@ -34,6 +34,7 @@ func init() {
 				objectkinds.ClusterRoleBinding,
 				objectkinds.RoleBinding},
 		},
+		NeedsOtherObjects:      true,
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
@@ -57,11 +58,11 @@ func init() {
 				rbinding, ok := object.K8sObject.(*rbacV1.RoleBinding)
 				if ok {
 					namespace := stringutils.OrDefault(rbinding.Namespace, "default")
-					return findRole(rbinding.RoleRef.Name, namespace, lintCtx, resourceRegexes, verbRegexes, p.FlagRolesNotFound)
+					return atRoleRef(findRole(rbinding.RoleRef.Name, namespace, lintCtx, resourceRegexes, verbRegexes, p.FlagRolesNotFound))
 				}
 				crbinding, ok := object.K8sObject.(*rbacV1.ClusterRoleBinding)
 				if ok {
-					return findClusterRole(crbinding.RoleRef.Name, lintCtx, resourceRegexes, verbRegexes, p.FlagRolesNotFound)
+					return atRoleRef(findClusterRole(crbinding.RoleRef.Name, lintCtx, resourceRegexes, verbRegexes, p.FlagRolesNotFound))
 				}
 				return nil
 			}, nil
@@ -69,6 +70,14 @@ func init() {
 	})
 }
 
+// atRoleRef points the given diagnostics at the roleRef of the binding.
+func atRoleRef(diagnostics []diagnostic.Diagnostic) []diagnostic.Diagnostic {
+	for i := range diagnostics {
+		diagnostics[i].Path = "roleRef"
+	}
+	return diagnostics
+}
+
 // find clusterrole by name, and check if it has access to the specified resource kinds and verbs
 func findClusterRole(name string, lintCtx lintcontext.LintContext, resourceRegexes, verbRegexes []*regexp.Regexp, flag bool) []diagnostic.Diagnostic {
 	var results []diagnostic.Diagnostic
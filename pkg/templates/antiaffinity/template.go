@@ -14,6 +14,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/antiaffinity/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 	coreV1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -62,12 +63,15 @@ func init() {
 					return nil
 				}
 				namespace := object.K8sObject.GetNamespace()
+				podSpecPath, _ := extract.PodSpecPath(object.K8sObject)
+				antiAffinityPath := util.JoinPath(podSpecPath, "affinity.podAntiAffinity")
 				affinity := podTemplateSpec.Spec.Affinity
 				// Short-circuit if no affinity rule is specified within the pod spec.
 				if affinity == nil || affinity.PodAntiAffinity == nil {
 					return []diagnostic.Diagnostic{
 						{Message: fmt.Sprintf("object has %d %s but does not specify inter pod anti-affinity",
-							replicas, stringutils.Ternary(replicas > 1, "replicas", "replica"))},
+							replicas, stringutils.Ternary(replicas > 1, "replicas", "replica")),
+							Path: antiAffinityPath},
 					}
 				}
 				var foundIssues []diagnostic.Diagnostic
@@ -78,11 +82,12 @@ func init() {
 					return []diagnostic.Diagnostic{
 						{Message: fmt.Sprintf("object has %d %s but does not specify preferred or required "+
 							"inter pod anti-affinity during scheduling",
-							replicas, stringutils.Ternary(replicas > 1, "replicas", "replica"))},
+							replicas, stringutils.Ternary(replicas > 1, "replicas", "replica")),
+							Path: antiAffinityPath},
 					}
 				}
 
-				for _, preferred := range preferredAffinity {
+				for i, preferred := range preferredAffinity {
 					err := validateAffinityTermMatchesAgainstNodes(preferred.PodAffinityTerm,
 						namespace, podTemplateSpec.Labels, topologyKeyMatcher)
 					if err == nil {
@@ -90,9 +95,10 @@ func init() {
 					}
 					foundIssues = append(foundIssues, diagnostic.Diagnostic{
 						Message: err.Error(),
+						Path:    util.JoinPath(antiAffinityPath, fmt.Sprintf("preferredDuringSchedulingIgnoredDuringExecution[%d].podAffinityTerm", i)),
 					})
 				}
-				for _, required := range requiredAffinity {
+				for i, required := range requiredAffinity {
 					err := validateAffinityTermMatchesAgainstNodes(required, namespace,
 						podTemplateSpec.Labels, topologyKeyMatcher)
 					if err == nil {
@@ -100,6 +106,7 @@ func init() {
 					}
 					foundIssues = append(foundIssues, diagnostic.Diagnostic{
 						Message: err.Error(),
+						Path:    util.JoinPath(antiAffinityPath, fmt.Sprintf("requiredDuringSchedulingIgnoredDuringExecution[%d]", i)),
 					})
 				}
 				return foundIssues
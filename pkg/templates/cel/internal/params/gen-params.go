@@ -19,7 +19,7 @@ var (
 	checkParamDesc = util.MustParseParameterDesc(`{
 	"Name": "check",
 	"Type": "string",
-	"Description": "Check contains a CEL expression for validation logic. Two predefined variables are available: 'object' (the current Kubernetes object being processed) and 'objects' (all objects being linted).",
+	"Description": "Check contains a CEL expression for validation logic, which returns a message, or a list of messages, for each problem with the object. Empty messages are ignored. Two predefined variables are available: 'object' (the current Kubernetes object being processed) and 'objects' (all objects being linted). The functions quantity(string), isQuantity(string), selectorMatches(selector, labels), podSpec(object) and containers(object) help with Kubernetes objects.",
 	"Examples": null,
 	"Enum": null,
 	"SubParameters": null,
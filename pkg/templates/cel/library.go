@@ -0,0 +1,158 @@
+package cel
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+var (
+	mapType       = cel.MapType(cel.StringType, cel.DynType)
+	jsonValueType = reflect.TypeOf(&structpb.Value{})
+)
+
+// kubernetesFunctions are the functions that expressions can use, in addition to the standard CEL functions:
+//   - quantity(string) returns the value of a Kubernetes quantity, such as "500m" or "1Gi", as a double.
+//   - isQuantity(string) returns whether the string is a valid Kubernetes quantity.
+//   - selectorMatches(selector, labels) returns whether the labels match the label selector, which has the
+//     matchLabels and matchExpressions fields of a Kubernetes label selector.
+//   - podSpec(object) returns the pod spec of the object, or null if it has none.
+//   - containers(object) returns all the containers of the pod spec of the object, including init and
+//     ephemeral containers, or an empty list if it has no pod spec.
+func kubernetesFunctions() []cel.EnvOption {
+	return []cel.EnvOption{
+		cel.Function("quantity",
+			cel.Overload("quantity_string", []*cel.Type{cel.StringType}, cel.DoubleType,
+				cel.UnaryBinding(quantity))),
+		cel.Function("isQuantity",
+			cel.Overload("is_quantity_string", []*cel.Type{cel.StringType}, cel.BoolType,
+				cel.UnaryBinding(isQuantity))),
+		cel.Function("selectorMatches",
+			cel.Overload("selector_matches_map_map", []*cel.Type{mapType, mapType}, cel.BoolType,
+				cel.BinaryBinding(selectorMatches))),
+		cel.Function("podSpec",
+			cel.Overload("pod_spec_map", []*cel.Type{mapType}, cel.DynType,
+				cel.UnaryBinding(podSpec))),
+		cel.Function("containers",
+			cel.Overload("containers_map", []*cel.Type{mapType}, cel.ListType(mapType),
+				cel.UnaryBinding(containers))),
+	}
+}
+
+func quantity(value ref.Val) ref.Val {
+	q, err := resource.ParseQuantity(string(value.(types.String)))
+	if err != nil {
+		return types.NewErr("invalid quantity %q: %v", value, err)
+	}
+	return types.Double(q.AsApproximateFloat64())
+}
+
+func isQuantity(value ref.Val) ref.Val {
+	_, err := resource.ParseQuantity(string(value.(types.String)))
+	return types.Bool(err == nil)
+}
+
+func selectorMatches(selectorVal, labelsVal ref.Val) ref.Val {
+	var labelSelector metaV1.LabelSelector
+	if err := fromCELValue(selectorVal, &labelSelector); err != nil {
+		return types.NewErr("invalid label selector: %v", err)
+	}
+	selector, err := metaV1.LabelSelectorAsSelector(&labelSelector)
+	if err != nil {
+		return types.NewErr("invalid label selector: %v", err)
+	}
+	var labelSet map[string]string
+	if err := fromCELValue(labelsVal, &labelSet); err != nil {
+		return types.NewErr("invalid labels: %v", err)
+	}
+	return types.Bool(selector.Matches(labels.Set(labelSet)))
+}
+
+func podSpec(objectVal ref.Val) ref.Val {
+	object, err := toK8sObject(objectVal)
+	if err != nil {
+		return types.WrapErr(err)
+	}
+	spec, found := extract.PodSpec(object)
+	if !found {
+		return types.NullValue
+	}
+	specMap, err := util.ToMap(spec.PodSpec)
+	if err != nil {
+		return types.WrapErr(err)
+	}
+	return types.DefaultTypeAdapter.NativeToValue(specMap)
+}
+
+func containers(objectVal ref.Val) ref.Val {
+	object, err := toK8sObject(objectVal)
+	if err != nil {
+		return types.WrapErr(err)
+	}
+	containerMaps := []any{}
+	if spec, found := extract.PodSpec(object); found {
+		for _, container := range spec.AllContainers() {
+			containerMap, err := util.ToMap(container)
+			if err != nil {
+				return types.WrapErr(err)
+			}
+			containerMaps = append(containerMaps, containerMap)
+		}
+	}
+	return types.DefaultTypeAdapter.NativeToValue(containerMaps)
+}
+
+// toK8sObject converts the map of an object back to the Go type of its kind, so that the extract package can be used.
+func toK8sObject(objectVal ref.Val) (k8sutil.Object, error) {
+	var objectMap map[string]any
+	if err := fromCELValue(objectVal, &objectMap); err != nil {
+		return nil, err
+	}
+	apiVersion, _ := objectMap["apiVersion"].(string)
+	kind, _ := objectMap["kind"].(string)
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid apiVersion %q: %w", apiVersion, err)
+	}
+	runtimeObject, err := scheme.Scheme.New(gv.WithKind(kind))
+	if err != nil {
+		// Objects of unknown kinds, such as custom resources, do not have a pod spec that can be extracted.
+		return &metaV1.PartialObjectMetadata{}, nil
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(objectMap, runtimeObject); err != nil {
+		return nil, fmt.Errorf("converting %s object: %w", kind, err)
+	}
+	object, ok := runtimeObject.(k8sutil.Object)
+	if !ok {
+		return &metaV1.PartialObjectMetadata{}, nil
+	}
+	return object, nil
+}
+
+// fromCELValue converts a CEL value to the given Go value, as it is decoded from JSON.
+func fromCELValue(val ref.Val, out any) error {
+	jsonValue, err := val.ConvertToNative(jsonValueType)
+	if err != nil {
+		return err
+	}
+	bytes, err := protojson.Marshal(jsonValue.(*structpb.Value))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bytes, out)
+}
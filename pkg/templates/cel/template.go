@@ -1,21 +1,27 @@
 package cel
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
 	"golang.stackrox.io/kube-linter/pkg/check"
 	"golang.stackrox.io/kube-linter/pkg/config"
 	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
 	"golang.stackrox.io/kube-linter/pkg/lintcontext"
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/cel/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
 const (
 	templateKey = "cel-expression"
+
+	interruptCheckFrequency = 100
 )
 
 func init() {
@@ -29,80 +35,149 @@ func init() {
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
-			return func(ctx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
-				msg, err := evaluate(p.Check, object, ctx.Objects())
-				if err != nil {
-					return []diagnostic.Diagnostic{
-						{Message: fmt.Sprintf("error evaluating CEL check expression: %v", err)},
-					}
-				}
-				if msg != "" {
-					return []diagnostic.Diagnostic{
-						{Message: fmt.Sprintf("CEL check expression returned: %v", msg)},
-					}
-				}
-				return nil
-			}, nil
+			checkFunc, err := instantiate(p)
+			if err != nil {
+				return nil, err
+			}
+			return checkFunc.WithoutContext(), nil
 		}),
+		InstantiateWithContext: func(paramsInt any) (check.ContextFunc, error) {
+			return instantiate(paramsInt.(params.Params))
+		},
 	})
 }
 
-func evaluate(check string, object lintcontext.Object, objects []lintcontext.Object) (string, error) {
-	// Convert object to map via JSON marshaling/unmarshaling for CEL compatibility
-	// We need to marshal the underlying K8sObject, not the lintcontext.Object
-	objectMap, err := toMap(object.K8sObject)
+func instantiate(p params.Params) (check.ContextFunc, error) {
+	prg, err := compile(p.Check)
 	if err != nil {
-		return "", fmt.Errorf("failed to convert object to map: %w", err)
+		return nil, err
 	}
+	// The objects of each context are converted once, rather than for every object that is checked.
+	contextObjects := util.NewContextCache(func(lintCtx lintcontext.LintContext) (any, error) {
+		return newObjectMaps(lintCtx)
+	})
 
-	// Convert objects to maps via JSON marshaling/unmarshaling
-	objectsMaps := make([]map[string]any, len(objects))
-	for i, obj := range objects {
-		objMap, err := toMap(obj.K8sObject)
+	return func(ctx context.Context, lintCtx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+		messages, err := evaluateInContext(ctx, prg, contextObjects, lintCtx, object)
 		if err != nil {
-			return "", fmt.Errorf("failed to convert object %s to map: %w", obj.GetK8sObjectName().String(), err)
+			return []diagnostic.Diagnostic{
+				{Message: fmt.Sprintf("error evaluating CEL check expression: %v", err)},
+			}
 		}
-		objectsMaps[i] = objMap
-	}
+		diagnostics := make([]diagnostic.Diagnostic, 0, len(messages))
+		for _, msg := range messages {
+			diagnostics = append(diagnostics, diagnostic.Diagnostic{
+				Message: fmt.Sprintf("CEL check expression returned: %v", msg),
+			})
+		}
+		return diagnostics
+	}, nil
+}
 
-	e, err := cel.NewEnv(
-		cel.Variable("object", cel.MapType(cel.StringType, cel.AnyType)),
-		cel.Variable("objects", cel.ListType(cel.MapType(cel.StringType, cel.AnyType))),
-	)
+// compile compiles the expression, and checks that it returns a string or a list of strings.
+func compile(expression string) (cel.Program, error) {
+	options := append([]cel.EnvOption{
+		cel.Variable("object", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("objects", cel.ListType(cel.MapType(cel.StringType, cel.DynType))),
+	}, kubernetesFunctions()...)
+	e, err := cel.NewEnv(options...)
 	if err != nil {
-		return "", fmt.Errorf("failed to create CEL environment: %w", err)
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
 	}
-	ast, iss := e.Compile(check)
+	ast, iss := e.Compile(expression)
 	if iss.Err() != nil {
-		return "", fmt.Errorf("failed to compile CEL expression: %w", iss.Err())
+		return nil, fmt.Errorf("failed to compile CEL expression: %w", iss.Err())
 	}
-	prg, err := e.Program(ast)
-	if err != nil {
-		return "", fmt.Errorf("failed to create CEL program: %w", err)
+	if !isMessagesType(ast.OutputType()) {
+		return nil, fmt.Errorf("CEL expression must return a string or a list of strings, not %s", ast.OutputType())
 	}
-	out, _, err := prg.Eval(map[string]any{
-		"object":  objectMap,
-		"objects": objectsMaps,
-	})
+	// Check for the cancellation of the context while evaluating comprehensions.
+	prg, err := e.Program(ast, cel.InterruptCheckFrequency(interruptCheckFrequency))
 	if err != nil {
-		return "", fmt.Errorf("failed to evaluate CEL expression: %w", err)
+		return nil, fmt.Errorf("failed to create CEL program: %w", err)
+	}
+	return prg, nil
+}
+
+func isMessagesType(t *cel.Type) bool {
+	switch t.Kind() {
+	case types.StringKind, types.DynKind:
+		return true
+	case types.ListKind:
+		elemKind := t.Parameters()[0].Kind()
+		return elemKind == types.StringKind || elemKind == types.DynKind
+	}
+	return false
+}
+
+// objectMaps are the objects of a context, converted to maps for CEL.
+type objectMaps struct {
+	list     []map[string]any
+	byObject map[k8sutil.Object]map[string]any
+}
+
+func newObjectMaps(lintCtx lintcontext.LintContext) (*objectMaps, error) {
+	objects := lintCtx.Objects()
+	maps := &objectMaps{
+		list:     make([]map[string]any, 0, len(objects)),
+		byObject: make(map[k8sutil.Object]map[string]any, len(objects)),
+	}
+	for _, obj := range objects {
+		objMap, err := util.ToMap(obj.K8sObject)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert object %s to map: %w", obj.GetK8sObjectName().String(), err)
+		}
+		maps.list = append(maps.list, objMap)
+		maps.byObject[obj.K8sObject] = objMap
 	}
+	return maps, nil
+}
 
-	o, ok := out.Value().(string)
-	if !ok {
-		return "", fmt.Errorf("expected string, got %v", out.Value())
+func evaluateInContext(ctx context.Context, prg cel.Program, contextObjects *util.ContextCache, lintCtx lintcontext.LintContext, object lintcontext.Object) ([]string, error) {
+	cached, err := contextObjects.Get(lintCtx)
+	if err != nil {
+		return nil, err
+	}
+	maps := cached.(*objectMaps)
+	objectMap, found := maps.byObject[object.K8sObject]
+	if !found {
+		if objectMap, err = util.ToMap(object.K8sObject); err != nil {
+			return nil, fmt.Errorf("failed to convert object to map: %w", err)
+		}
 	}
-	return o, nil
+	return evaluate(ctx, prg, objectMap, maps.list)
 }
 
-func toMap(obj any) (map[string]any, error) {
-	bytes, err := json.Marshal(obj)
+// evaluate returns the messages that the program returns for the object, ignoring empty messages.
+func evaluate(ctx context.Context, prg cel.Program, object map[string]any, objects []map[string]any) ([]string, error) {
+	out, _, err := prg.ContextEval(ctx, map[string]any{
+		"object":  object,
+		"objects": objects,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal object: %w", err)
+		return nil, fmt.Errorf("failed to evaluate CEL expression: %w", err)
 	}
-	var output map[string]any
-	if err := json.Unmarshal(bytes, &output); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal object: %w", err)
+
+	var results []any
+	switch value := out.Value().(type) {
+	case string:
+		results = []any{value}
+	default:
+		list, err := out.ConvertToNative(reflect.TypeOf([]any{}))
+		if err != nil {
+			return nil, fmt.Errorf("expected string or list of strings, got %v", out.Value())
+		}
+		results = list.([]any)
+	}
+	var messages []string
+	for _, result := range results {
+		msg, ok := result.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %v", result)
+		}
+		if msg != "" {
+			messages = append(messages, msg)
+		}
 	}
-	return output, nil
+	return messages, nil
 }
@@ -1,11 +1,18 @@
 package cel
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates/cel/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -144,15 +151,158 @@ func TestEvaluate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			msg, err := evaluate(tt.check, tt.object, tt.objects)
+			messages, err := evaluateObjects(t, tt.check, tt.object, tt.objects)
 
 			if tt.expectError {
 				assert.Error(t, err)
-				assert.Empty(t, msg)
+				assert.Empty(t, messages)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedMsg, msg)
+				if tt.expectedMsg == "" {
+					assert.Empty(t, messages)
+				} else {
+					assert.Equal(t, []string{tt.expectedMsg}, messages)
+				}
 			}
 		})
 	}
 }
+
+// evaluateObjects compiles the check, and evaluates it for the object. Compilation errors are returned like
+// evaluation errors.
+func evaluateObjects(t *testing.T, check string, object lintcontext.Object, objects []lintcontext.Object) ([]string, error) {
+	prg, err := compile(check)
+	if err != nil {
+		return nil, err
+	}
+	objectMap, err := util.ToMap(object.K8sObject)
+	require.NoError(t, err)
+	objectMaps := make([]map[string]any, 0, len(objects))
+	for _, obj := range objects {
+		objMap, err := util.ToMap(obj.K8sObject)
+		require.NoError(t, err)
+		objectMaps = append(objectMaps, objMap)
+	}
+	return evaluate(context.Background(), prg, objectMap, objectMaps)
+}
+
+func TestEvaluateMessages(t *testing.T) {
+	pod := lintcontext.Object{K8sObject: &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod"},
+	}}
+	messages, err := evaluateObjects(t, `["first", "", "second"]`, pod, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, messages)
+
+	messages, err = evaluateObjects(t, `[object.metadata.name].filter(name, name.startsWith("test"))`, pod, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test-pod"}, messages)
+
+	_, err = evaluateObjects(t, `[1, 2]`, pod, nil)
+	assert.Error(t, err)
+
+	_, err = evaluateObjects(t, `[object.metadata.name, object.metadata]`, pod, nil)
+	assert.Error(t, err)
+}
+
+func TestKubernetesFunctions(t *testing.T) {
+	deployment := lintcontext.Object{K8sObject: &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{"app": "shop", "tier": "web"}},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "shop",
+					InitContainers:     []corev1.Container{{Name: "init"}},
+					Containers: []corev1.Container{{
+						Name: "app",
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+						},
+					}},
+				},
+			},
+		},
+	}}
+	service := lintcontext.Object{K8sObject: &corev1.Service{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{Name: "shop"},
+	}}
+
+	for _, tc := range []struct {
+		check    string
+		object   lintcontext.Object
+		expected []string
+	}{
+		{check: `quantity("500m") == 0.5 && quantity("1Ki") == 1024.0 ? "ok" : ""`, object: service, expected: []string{"ok"}},
+		{check: `isQuantity("2Gi") && !isQuantity("two") ? "ok" : ""`, object: service, expected: []string{"ok"}},
+		{check: `podSpec(object).serviceAccountName`, object: deployment, expected: []string{"shop"}},
+		{check: `podSpec(object) == null ? "no pod spec" : ""`, object: service, expected: []string{"no pod spec"}},
+		{check: `containers(object).map(c, c.name)`, object: deployment, expected: []string{"init", "app"}},
+		{check: `size(containers(object)) == 0 ? "no containers" : ""`, object: service, expected: []string{"no containers"}},
+		{
+			check:    `containers(object).filter(c, has(c.resources.limits) && quantity(c.resources.limits.memory) > quantity("1Gi")).map(c, c.name + " uses too much memory")`,
+			object:   deployment,
+			expected: []string{"app uses too much memory"},
+		},
+		{
+			check: `[
+				selectorMatches({"matchLabels": {"app": "shop"}}, object.metadata.labels) ? "matchLabels" : "",
+				selectorMatches({"matchExpressions": [{"key": "tier", "operator": "In", "values": ["web", "api"]}]}, object.metadata.labels) ? "matchExpressions" : "",
+				selectorMatches({"matchLabels": {"app": "blog"}}, object.metadata.labels) ? "mismatch" : ""
+			]`,
+			object:   deployment,
+			expected: []string{"matchLabels", "matchExpressions"},
+		},
+	} {
+		t.Run(tc.check, func(t *testing.T) {
+			messages, err := evaluateObjects(t, tc.check, tc.object, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, messages)
+		})
+	}
+
+	_, err := evaluateObjects(t, `quantity("two") > 1.0 ? "too much" : ""`, service, nil)
+	assert.ErrorContains(t, err, "invalid quantity")
+}
+
+func TestInstantiate(t *testing.T) {
+	_, err := instantiate(params.Params{Check: `invalid syntax here`})
+	assert.ErrorContains(t, err, "failed to compile CEL expression")
+	_, err = instantiate(params.Params{Check: `123`})
+	assert.ErrorContains(t, err, "must return a string or a list of strings")
+
+	lintCtx := mocks.NewMockContext()
+	lintCtx.AddMockService(t, "first")
+	lintCtx.AddMockService(t, "second")
+	checkFunc, err := instantiate(params.Params{
+		Check: `objects.filter(o, o.metadata.name != object.metadata.name).map(o, object.metadata.name + " is not alone, " + o.metadata.name + " is here")`,
+	})
+	require.NoError(t, err)
+	for _, obj := range lintCtx.Objects() {
+		diagnostics := checkFunc(context.Background(), lintCtx, obj)
+		require.Len(t, diagnostics, 1)
+		assert.Contains(t, diagnostics[0].Message, "CEL check expression returned: "+obj.K8sObject.GetName()+" is not alone")
+	}
+}
+
+func TestEvaluateWithCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	object := lintcontext.Object{K8sObject: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-pod"}}}
+	objects := make([]lintcontext.Object, 2*interruptCheckFrequency)
+	for i := range objects {
+		objects[i] = object
+	}
+	prg, err := compile(`objects.all(o, o.metadata.name != "") ? "" : "unnamed"`)
+	require.NoError(t, err)
+	objectMap, err := util.ToMap(object.K8sObject)
+	require.NoError(t, err)
+	objectMaps := make([]map[string]any, len(objects))
+	for i := range objects {
+		objectMaps[i] = objectMap
+	}
+	_, err = evaluate(ctx, prg, objectMap, objectMaps)
+	assert.ErrorContains(t, err, "operation interrupted")
+}
@@ -33,7 +33,7 @@ func init() {
 				clusterrole := clusterrolebinding.RoleRef
 				if clusterrole.Name == "cluster-admin" && clusterrole.Kind == "ClusterRole" {
 					jsonObj, _ := json.Marshal(clusterrolebinding.Subjects)
-					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("%s role is bound to %v", clusterrole.Name, string(jsonObj))}}
+					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("%s role is bound to %v", clusterrole.Name, string(jsonObj)), Path: "roleRef.name"}}
 				}
 				return nil
 			}, nil
@@ -2,6 +2,7 @@ package containercapabilities
 
 import (
 	"fmt"
+	"regexp"
 
 	"golang.stackrox.io/kube-linter/internal/utils"
 	"golang.stackrox.io/kube-linter/pkg/check"
@@ -22,6 +23,9 @@ const (
 )
 
 var (
+	// literalCapabilityRegex matches capabilities that are names rather than patterns, which can be added to a list by a fix.
+	literalCapabilityRegex = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
 	literalReservedCapabilitiesAllMatcher = func() func(string) bool {
 		m, err := matcher.ForString(matchLiteralReservedCapabilitiesAll)
 		utils.Must(err)
@@ -59,6 +63,8 @@ func checkCapabilityDropList(
 						dropListWithAllDiagMsgFmt,
 						containerName,
 						scCaps.Drop),
+					Path: dropListPath,
+					Fix:  dropFix("ALL"),
 				})
 	}
 
@@ -83,11 +89,30 @@ func checkCapabilityDropList(
 							containerName,
 							scCaps.Drop,
 							paramCap),
+						Path: dropListPath,
+						Fix:  dropFix(paramCap),
 					})
 		}
 	}
 }
 
+// dropListPath is the path of the DROP list of a container.
+const dropListPath = "securityContext.capabilities.drop"
+
+// addPath returns the path of the capability at the given index of the ADD list of a container.
+func addPath(i int) string {
+	return fmt.Sprintf("securityContext.capabilities.add[%d]", i)
+}
+
+// dropFix returns a fix that adds the given capability to the DROP list of a container,
+// or nil if the capability is a pattern.
+func dropFix(capability string) *diagnostic.Fix {
+	if !literalCapabilityRegex.MatchString(capability) {
+		return nil
+	}
+	return diagnostic.AppendFix(fmt.Sprintf("Drop capability %s", capability), "securityContext.capabilities.drop", capability)
+}
+
 func checkCapabilityAddList(
 	containerName string,
 	paramCapMatchers map[string]func(string) bool,
@@ -98,7 +123,7 @@ func checkCapabilityAddList(
 ) {
 	if forbidAll {
 		// User has forbidden all capabilities
-		for _, scCap := range scCaps.Add {
+		for i, scCap := range scCaps.Add {
 			var excluded bool
 			for _, exceptionCapMatcher := range exceptionCapMatchers {
 				if exceptionCapMatcher(string(scCap)) {
@@ -116,6 +141,7 @@ func checkCapabilityAddList(
 								addListWithAllDiagMsgFmt,
 								containerName,
 								scCap),
+							Path: addPath(i),
 						})
 			}
 		}
@@ -125,7 +151,7 @@ func checkCapabilityAddList(
 
 	// Any capability from scCaps should not match with any from paramCaps
 	for _, paramCapMatcher := range paramCapMatchers {
-		for _, scCap := range scCaps.Add {
+		for i, scCap := range scCaps.Add {
 			// User can specify to add "all" under containers as well.
 			if paramCapMatcher(string(scCap)) || literalReservedCapabilitiesAllMatcher(string(scCap)) {
 				// A capability from ADD list matched with a cap from forbidden capabilities list.
@@ -137,6 +163,7 @@ func checkCapabilityAddList(
 								addListDiagMsgFmt,
 								containerName,
 								scCap),
+							Path: addPath(i),
 						})
 			}
 		}
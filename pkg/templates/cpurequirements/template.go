@@ -14,10 +14,18 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+const templateKey = "cpu-requirements"
+
 func process(results *[]diagnostic.Diagnostic, containerName, requirementsType string, quantity *resource.Quantity, lowerBound int, upperBound *int) {
 	if util.ValueInRange(int(quantity.MilliValue()), lowerBound, upperBound) {
 		*results = append(*results, diagnostic.Diagnostic{
 			Message: fmt.Sprintf("container %q has cpu %s %s", containerName, requirementsType, quantity),
+			Path:    fmt.Sprintf("resources.%ss.cpu", requirementsType),
+			Code:    templateKey + "/" + requirementsType,
+			Value:   quantity.String(),
+			Expected: util.DescribeOutsideRange(lowerBound, upperBound, func(millis int) string {
+				return resource.NewMilliQuantity(int64(millis), resource.DecimalSI).String()
+			}),
 		})
 	}
 
@@ -26,7 +34,7 @@ func process(results *[]diagnostic.Diagnostic, containerName, requirementsType s
 func init() {
 	templates.Register(check.Template{
 		HumanName:   "CPU Requirements",
-		Key:         "cpu-requirements",
+		Key:         templateKey,
 		Description: "Flag containers with CPU requirements in the given range",
 		SupportedObjectKinds: config.ObjectKindsDesc{
 			ObjectKinds: []string{objectkinds.DeploymentLike},
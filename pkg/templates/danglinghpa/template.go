@@ -70,6 +70,7 @@ func init() {
 		SupportedObjectKinds: config.ObjectKindsDesc{
 			ObjectKinds: []string{objectkinds.HorizontalPodAutoscaler},
 		},
+		NeedsOtherObjects:      true,
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
@@ -90,7 +91,7 @@ func init() {
 						return nil
 					}
 				}
-				return []diagnostic.Diagnostic{{Message: fmt.Sprintf("no resources found matching HorizontalPodAutoscaler scaleTargetRef (%v)", *target)}}
+				return []diagnostic.Diagnostic{{Message: fmt.Sprintf("no resources found matching HorizontalPodAutoscaler scaleTargetRef (%v)", *target), Path: "spec.scaleTargetRef"}}
 			}, nil
 		}),
 	})
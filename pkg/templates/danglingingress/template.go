@@ -43,26 +43,30 @@ func getSelectorsFromIngressBackend(b *networkingV1.IngressBackend) (serviceDesc
 	}, true
 }
 
-func getSelectorsFromIngress(ingress *networkingV1.Ingress) map[serviceDescriptor]struct{} {
-	selectors := map[serviceDescriptor]struct{}{}
+// getSelectorsFromIngress returns the services that the backends of the ingress refer to, along with the field path
+// of the first backend that refers to each.
+func getSelectorsFromIngress(ingress *networkingV1.Ingress) map[serviceDescriptor]string {
+	selectors := map[serviceDescriptor]string{}
+	addSelector := func(b *networkingV1.IngressBackend, path string) {
+		if s, found := getSelectorsFromIngressBackend(b); found {
+			if _, seen := selectors[s]; !seen {
+				selectors[s] = path
+			}
+		}
+	}
 
 	if defaultBack := ingress.Spec.DefaultBackend; defaultBack != nil {
-		if s, found := getSelectorsFromIngressBackend(defaultBack); found {
-			selectors[s] = struct{}{}
-		}
+		addSelector(defaultBack, "spec.defaultBackend.service")
 	}
 
-	for _, r := range ingress.Spec.Rules {
+	for i, r := range ingress.Spec.Rules {
 		spec := r.HTTP
 		if spec == nil {
 			continue
 		}
 
-		for _, p := range spec.Paths {
-			p := p
-			if s, found := getSelectorsFromIngressBackend(&p.Backend); found {
-				selectors[s] = struct{}{}
-			}
+		for j := range spec.Paths {
+			addSelector(&spec.Paths[j].Backend, fmt.Sprintf("spec.rules[%d].http.paths[%d].backend.service", i, j))
 		}
 	}
 
@@ -77,6 +81,7 @@ func init() {
 		SupportedObjectKinds: config.ObjectKindsDesc{
 			ObjectKinds: []string{objectkinds.Ingress},
 		},
+		NeedsOtherObjects:      true,
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
@@ -128,9 +133,10 @@ func init() {
 
 				var dig []diagnostic.Diagnostic
 
-				for k := range selectors {
+				for k, path := range selectors {
 					dig = append(dig, diagnostic.Diagnostic{
 						Message: fmt.Sprintf("no service found matching ingress label (%v), port %s", k.name, k.port.String()),
+						Path:    path,
 					})
 				}
 
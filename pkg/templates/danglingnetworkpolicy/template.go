@@ -29,6 +29,7 @@ func init() {
 		SupportedObjectKinds: config.ObjectKindsDesc{
 			ObjectKinds: []string{objectkinds.DeploymentLike},
 		},
+		NeedsOtherObjects:      true,
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
@@ -43,6 +44,7 @@ func init() {
 				if err != nil {
 					return []diagnostic.Diagnostic{{
 						Message: fmt.Sprintf("networkpolicy has invalid podSelector: %v", err),
+						Path:    "spec.podSelector",
 					}}
 				}
 				for _, obj := range lintCtx.Objects() {
@@ -58,7 +60,7 @@ func init() {
 						return nil
 					}
 				}
-				return []diagnostic.Diagnostic{{Message: fmt.Sprintf("no pods found matching networkpolicy's podSelector labels (%v) ", podselector)}}
+				return []diagnostic.Diagnostic{{Message: fmt.Sprintf("no pods found matching networkpolicy's podSelector labels (%v) ", podselector), Path: "spec.podSelector"}}
 			}, nil
 		}),
 	})
@@ -29,6 +29,7 @@ func init() {
 		SupportedObjectKinds: config.ObjectKindsDesc{
 			ObjectKinds: []string{objectkinds.DeploymentLike},
 		},
+		NeedsOtherObjects:      true,
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
@@ -39,20 +40,24 @@ func init() {
 					return nil
 				}
 				ingressRules := networkpolicy.Spec.Ingress
-				for _, inrule := range ingressRules {
-					for _, peer := range inrule.From {
+				for i, inrule := range ingressRules {
+					for j, peer := range inrule.From {
 						res := getAndCheckifPodSelectorMatchesPods(peer, lintCtx, networkpolicy.Namespace)
 						if res != nil {
-							results = append(results, res[len(res)-1])
+							d := res[len(res)-1]
+							d.Path = fmt.Sprintf("spec.ingress[%d].from[%d].podSelector", i, j)
+							results = append(results, d)
 						}
 					}
 				}
 				egressRules := networkpolicy.Spec.Egress
-				for _, torule := range egressRules {
-					for _, peer := range torule.To {
+				for i, torule := range egressRules {
+					for j, peer := range torule.To {
 						res := getAndCheckifPodSelectorMatchesPods(peer, lintCtx, networkpolicy.Namespace)
 						if res != nil {
-							results = append(results, res[len(res)-1])
+							d := res[len(res)-1]
+							d.Path = fmt.Sprintf("spec.egress[%d].to[%d].podSelector", i, j)
+							results = append(results, d)
 						}
 					}
 				}
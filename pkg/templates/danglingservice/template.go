@@ -26,6 +26,7 @@ func init() {
 		SupportedObjectKinds: config.ObjectKindsDesc{
 			ObjectKinds: []string{objectkinds.DeploymentLike},
 		},
+		NeedsOtherObjects:      true,
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
@@ -42,6 +43,7 @@ func init() {
 				if len(selector) == 0 {
 					return []diagnostic.Diagnostic{{
 						Message: "service has no selector specified",
+						Path:    "spec.selector",
 					}}
 				}
 
@@ -53,6 +55,7 @@ func init() {
 				if err != nil {
 					return []diagnostic.Diagnostic{{
 						Message: fmt.Sprintf("service has invalid label selector: %v", err),
+						Path:    "spec.selector",
 					}}
 				}
 				for _, obj := range lintCtx.Objects() {
@@ -68,7 +71,7 @@ func init() {
 						return nil
 					}
 				}
-				return []diagnostic.Diagnostic{{Message: fmt.Sprintf("no pods found matching service labels (%v)", selector)}}
+				return []diagnostic.Diagnostic{{Message: fmt.Sprintf("no pods found matching service labels (%v)", selector), Path: "spec.selector"}}
 			}, nil
 		}),
 	})
@@ -24,6 +24,7 @@ func init() {
 		SupportedObjectKinds: config.ObjectKindsDesc{
 			ObjectKinds: []string{objectkinds.ServiceMonitor},
 		},
+		NeedsOtherObjects:      true,
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
@@ -40,12 +41,14 @@ func init() {
 				if !labelSelectorSet && !nsSelectorSet {
 					return []diagnostic.Diagnostic{{
 						Message: "service monitor has no selector specified",
+						Path:    "spec.selector",
 					}}
 				}
 				labelSelector, err := metaV1.LabelSelectorAsSelector(&metaV1.LabelSelector{MatchLabels: serviceMonitor.Spec.Selector.MatchLabels})
 				if err != nil {
 					return []diagnostic.Diagnostic{{
 						Message: fmt.Sprintf("service monitor has invalid label selector: %v", err),
+						Path:    "spec.selector",
 					}}
 				}
 				for _, obj := range lintCtx.Objects() {
@@ -69,7 +72,7 @@ func init() {
 					}
 
 				}
-				return []diagnostic.Diagnostic{{Message: fmt.Sprintf("no services found matching the service monitor's label selector (%s) and namespace selector (%s)", labelSelector, nsSelector.MatchNames)}}
+				return []diagnostic.Diagnostic{{Message: fmt.Sprintf("no services found matching the service monitor's label selector (%s) and namespace selector (%s)", labelSelector, nsSelector.MatchNames), Path: "spec.selector"}}
 			}, nil
 		}),
 	})
@@ -11,6 +11,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/deprecatedserviceaccount/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
 func init() {
@@ -29,17 +30,28 @@ func init() {
 				if !found {
 					return nil
 				}
+				podSpecPath, _ := extract.PodSpecPath(object.K8sObject)
+				path := util.JoinPath(podSpecPath, "serviceAccount")
 
 				sa := podSpec.DeprecatedServiceAccount
 				san := podSpec.ServiceAccountName
 				if sa != "" && sa != san {
 					if san == "" { // only serviceAccount is specified
-						return []diagnostic.Diagnostic{{Message: fmt.Sprintf(
-							"serviceAccount is specified (%s), but this field is deprecated; use serviceAccountName instead", sa)}}
+						return []diagnostic.Diagnostic{{
+							Message: fmt.Sprintf(
+								"serviceAccount is specified (%s), but this field is deprecated; use serviceAccountName instead", sa),
+							Path:  path,
+							Value: sa,
+						}}
 					}
 					// serviceAccount and serviceAccountName both specified but do not match
-					return []diagnostic.Diagnostic{{Message: fmt.Sprintf(
-						"serviceAccount (%s) and serviceAccountName (%s) are both specified with non-matching values. serviceAccount is deprecated; unspecify serviceAccount or make values match.", sa, san)}}
+					return []diagnostic.Diagnostic{{
+						Message: fmt.Sprintf(
+							"serviceAccount (%s) and serviceAccountName (%s) are both specified with non-matching values. serviceAccount is deprecated; unspecify serviceAccount or make values match.", sa, san),
+						Path:     path,
+						Value:    sa,
+						Expected: san,
+					}}
 				}
 				return nil
 			}, nil
@@ -40,7 +40,7 @@ func init() {
 			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
 				gvk := extract.GVK(object.K8sObject)
 				if groupMatcher(gvk.Group) && versionMatcher(gvk.Version) && kindMatcher(gvk.Kind) {
-					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("disallowed API object found: %s", gvk)}}
+					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("disallowed API object found: %s", gvk), Value: gvk.String()}}
 				}
 				return nil
 			}, nil
@@ -11,6 +11,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/dnsconfigoptions/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
 func init() {
@@ -29,11 +30,13 @@ func init() {
 				if !found {
 					return nil
 				}
+				podSpecPath, _ := extract.PodSpecPath(object.K8sObject)
 				if podTemplateSpec.Spec.DNSConfig == nil {
-					return []diagnostic.Diagnostic{{Message: "Object does not define any DNSConfig rules."}}
+					return []diagnostic.Diagnostic{{Message: "Object does not define any DNSConfig rules.", Path: util.JoinPath(podSpecPath, "dnsConfig")}}
 				}
+				optionsPath := util.JoinPath(podSpecPath, "dnsConfig.options")
 				if podTemplateSpec.Spec.DNSConfig.Options == nil {
-					return []diagnostic.Diagnostic{{Message: "Object does not define any DNSConfig Options."}}
+					return []diagnostic.Diagnostic{{Message: "Object does not define any DNSConfig Options.", Path: optionsPath}}
 				}
 
 				for _, option := range podTemplateSpec.Spec.DNSConfig.Options {
@@ -44,6 +47,7 @@ func init() {
 				}
 				return []diagnostic.Diagnostic{{
 					Message: fmt.Sprintf("DNSConfig Options \"%s:%s\" not found.", p.Key, p.Value),
+					Path:    optionsPath,
 				}}
 			}, nil
 		}),
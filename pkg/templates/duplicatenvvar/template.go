@@ -30,7 +30,7 @@ func init() {
 				var results []diagnostic.Diagnostic
 				envVarNames := map[string]int{}
 
-				for _, envVar := range container.Env {
+				for i, envVar := range container.Env {
 					// Ensure we only report on error per env var
 					envVarNames[envVar.Name]++
 					if num, ok := envVarNames[envVar.Name]; !ok || num != 2 {
@@ -42,6 +42,9 @@ func init() {
 							envVar.Name,
 							container.Name,
 						),
+						Path:      fmt.Sprintf("env[%d]", i),
+						Container: container.Name,
+						Value:     envVar.Name,
 					})
 				}
 				return results
@@ -49,6 +49,7 @@ func init() {
 		SupportedObjectKinds: config.ObjectKindsDesc{
 			ObjectKinds: []string{objectkinds.DeploymentLike},
 		},
+		NeedsOtherObjects:      true,
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
@@ -83,9 +84,10 @@ func lintForEachContainer(lintCtx lintcontext.LintContext, object lintcontext.Ob
 		var envRefs []struct {
 			info resourceInfo
 			typ  resourceType
+			path string
 		}
 
-		for _, envVar := range container.Env {
+		for i, envVar := range container.Env {
 			valueFrom := envVar.ValueFrom
 			if valueFrom == nil {
 				continue
@@ -95,13 +97,15 @@ func lintForEachContainer(lintCtx lintcontext.LintContext, object lintcontext.Ob
 				envRefs = append(envRefs, struct {
 					info resourceInfo
 					typ  resourceType
+					path string
 				}{
 					info: resourceInfo{
 						name:     secretRef.Name,
 						key:      secretRef.Key,
 						optional: secretRef.Optional,
 					},
-					typ: resourceTypeSecret,
+					typ:  resourceTypeSecret,
+					path: fmt.Sprintf("env[%d].valueFrom.secretKeyRef", i),
 				})
 			}
 
@@ -109,13 +113,15 @@ func lintForEachContainer(lintCtx lintcontext.LintContext, object lintcontext.Ob
 				envRefs = append(envRefs, struct {
 					info resourceInfo
 					typ  resourceType
+					path string
 				}{
 					info: resourceInfo{
 						name:     configMapRef.Name,
 						key:      configMapRef.Key,
 						optional: configMapRef.Optional,
 					},
-					typ: resourceTypeConfigMap,
+					typ:  resourceTypeConfigMap,
+					path: fmt.Sprintf("env[%d].valueFrom.configMapKeyRef", i),
 				})
 			}
 		}
@@ -150,7 +156,7 @@ func lintForEachContainer(lintCtx lintcontext.LintContext, object lintcontext.Ob
 			}
 
 			if msg := checkResourceReference(container.Name, envRef.info, checker); msg != "" {
-				results = append(results, diagnostic.Diagnostic{Message: msg})
+				results = append(results, diagnostic.Diagnostic{Message: msg, Path: envRef.path})
 			}
 		}
 		return results
@@ -0,0 +1,285 @@
+// Package external provides templates that are implemented by external programs, and declared in the config
+// rather than registered at compile time.
+//
+// The program of a template is run once per object. It is given a JSON request on its standard input:
+//
+//	{"params": {...}, "object": {...}, "objects": [...]}
+//
+// where params are the params of the check, object is the object to check, and objects, which is only set if the
+// template includes objects, are all the objects of the lint context. It must write a JSON response to its standard
+// output, and exit with a zero status:
+//
+//	{"diagnostics": [{"message": "...", "path": "spec.template.spec"}]}
+//
+// where path, which identifies the field at fault, is optional.
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os/exec"
+	"strings"
+
+	"golang.stackrox.io/kube-linter/internal/errorhelpers"
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
+// maxStderrLength is the length of the standard error of a failed program that is included in diagnostics.
+const maxStderrLength = 1024
+
+var parameterTypes = map[string]check.ParameterType{
+	string(check.StringType):  check.StringType,
+	string(check.IntegerType): check.IntegerType,
+	string(check.BooleanType): check.BooleanType,
+	string(check.NumberType):  check.NumberType,
+	string(check.ObjectType):  check.ObjectType,
+	string(check.ArrayType):   check.ArrayType,
+}
+
+type request struct {
+	Params  map[string]interface{}   `json:"params"`
+	Object  map[string]interface{}   `json:"object"`
+	Objects []map[string]interface{} `json:"objects,omitempty"`
+}
+
+type response struct {
+	Diagnostics []struct {
+		Message   string `json:"message"`
+		Path      string `json:"path"`
+		Code      string `json:"code"`
+		Container string `json:"container"`
+		Value     string `json:"value"`
+		Expected  string `json:"expected"`
+	} `json:"diagnostics"`
+}
+
+// New returns the template that the config declares.
+func New(cfg config.TemplateConfig) (check.Template, error) {
+	errorList := errorhelpers.NewErrorList(fmt.Sprintf("validating template %q", cfg.Key))
+	if cfg.Key == "" {
+		errorList.AddString("no key specified")
+	}
+	if cfg.Exec.Command == "" {
+		errorList.AddString("no exec command specified")
+	}
+	if _, err := objectkinds.ConstructMatcher(cfg.SupportedObjectKinds.ObjectKinds...); err != nil {
+		errorList.AddError(err)
+	}
+	paramDescs := make([]check.ParameterDesc, 0, len(cfg.Parameters))
+	paramNames := set.NewStringSet()
+	for _, param := range cfg.Parameters {
+		desc, err := parameterDesc(param)
+		if err != nil {
+			errorList.AddError(err)
+			continue
+		}
+		if !paramNames.Add(param.Name) {
+			errorList.AddStringf("duplicate parameter %s", param.Name)
+		}
+		paramDescs = append(paramDescs, desc)
+	}
+	if err := errorList.ToError(); err != nil {
+		return check.Template{}, err
+	}
+
+	humanName := cfg.Name
+	if humanName == "" {
+		humanName = cfg.Key
+	}
+	return check.Template{
+		HumanName:            humanName,
+		Key:                  cfg.Key,
+		Description:          cfg.Description,
+		SupportedObjectKinds: cfg.SupportedObjectKinds,
+		Parameters:           paramDescs,
+		ParseAndValidateParams: func(params map[string]interface{}) (interface{}, error) {
+			if err := validateParams(paramDescs, params); err != nil {
+				return nil, err
+			}
+			return params, nil
+		},
+		Instantiate: func(params interface{}) (check.Func, error) {
+			return instantiate(cfg, params.(map[string]interface{})).WithoutContext(), nil
+		},
+		InstantiateWithContext: func(params interface{}) (check.ContextFunc, error) {
+			return instantiate(cfg, params.(map[string]interface{})), nil
+		},
+	}, nil
+}
+
+func parameterDesc(param config.TemplateParameterConfig) (check.ParameterDesc, error) {
+	if param.Name == "" {
+		return check.ParameterDesc{}, errors.New("parameter with no name")
+	}
+	paramType, ok := parameterTypes[param.Type]
+	if !ok {
+		return check.ParameterDesc{}, fmt.Errorf("parameter %s has invalid type %q", param.Name, param.Type)
+	}
+	desc := check.ParameterDesc{
+		Name:        param.Name,
+		Type:        paramType,
+		Description: param.Description,
+		Examples:    param.Examples,
+		Enum:        param.Enum,
+		Required:    param.Required,
+		// The program gets the params as they are, so it is up to it to support regexes or negation.
+		NoRegex:      true,
+		NotNegatable: true,
+	}
+	if param.ArrayElemType != "" {
+		elemType, ok := parameterTypes[param.ArrayElemType]
+		if !ok || paramType != check.ArrayType {
+			return check.ParameterDesc{}, fmt.Errorf("parameter %s has invalid array element type %q", param.Name, param.ArrayElemType)
+		}
+		desc.ArrayElemType = elemType
+	}
+	return desc, nil
+}
+
+// validateParams checks that the params are declared, have the declared types, and that the required params are set.
+func validateParams(paramDescs []check.ParameterDesc, params map[string]interface{}) error {
+	var validationErrors []string
+	declared := set.NewStringSet()
+	for _, desc := range paramDescs {
+		declared.Add(desc.Name)
+		value, found := params[desc.Name]
+		if !found {
+			if desc.Required {
+				validationErrors = append(validationErrors, fmt.Sprintf("required param %s not found", desc.Name))
+			}
+			continue
+		}
+		if !hasType(value, desc.Type) {
+			validationErrors = append(validationErrors, fmt.Sprintf("param %s must be of type %s", desc.Name, desc.Type))
+			continue
+		}
+		// Enums are declared as strings, whatever the type of the param.
+		if valueStr := fmt.Sprint(value); len(desc.Enum) > 0 && !set.NewStringSet(desc.Enum...).Contains(valueStr) {
+			validationErrors = append(validationErrors, fmt.Sprintf("param %s has invalid value %q, must be one of %v", desc.Name, valueStr, desc.Enum))
+		}
+		if desc.ArrayElemType != "" {
+			for _, elem := range value.([]interface{}) {
+				if !hasType(elem, desc.ArrayElemType) {
+					validationErrors = append(validationErrors, fmt.Sprintf("elements of param %s must be of type %s", desc.Name, desc.ArrayElemType))
+					break
+				}
+			}
+		}
+	}
+	for name := range params {
+		if !declared.Contains(name) {
+			validationErrors = append(validationErrors, fmt.Sprintf("unknown param %s", name))
+		}
+	}
+	if len(validationErrors) > 0 {
+		return fmt.Errorf("invalid parameters: %s", strings.Join(validationErrors, ", "))
+	}
+	return nil
+}
+
+func hasType(value interface{}, paramType check.ParameterType) bool {
+	switch value := value.(type) {
+	case string:
+		return paramType == check.StringType
+	case bool:
+		return paramType == check.BooleanType
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return paramType == check.IntegerType || paramType == check.NumberType
+	case float32:
+		return isNumber(float64(value), paramType)
+	case float64:
+		return isNumber(value, paramType)
+	case map[string]interface{}:
+		return paramType == check.ObjectType
+	case []interface{}:
+		return paramType == check.ArrayType
+	}
+	return false
+}
+
+// isNumber returns whether a number decoded from the config has the given type, since integers may be decoded as
+// floats.
+func isNumber(value float64, paramType check.ParameterType) bool {
+	return paramType == check.NumberType || (paramType == check.IntegerType && value == math.Trunc(value))
+}
+
+func instantiate(cfg config.TemplateConfig, params map[string]interface{}) check.ContextFunc {
+	var contextObjects *util.ContextCache
+	if cfg.Exec.IncludeObjects {
+		contextObjects = util.NewContextCache(func(lintCtx lintcontext.LintContext) (interface{}, error) {
+			return util.ObjectMaps(lintCtx)
+		})
+	}
+	return func(ctx context.Context, lintCtx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+		diagnostics, err := run(ctx, cfg.Exec, contextObjects, params, lintCtx, object)
+		if err != nil {
+			return []diagnostic.Diagnostic{{Message: fmt.Sprintf("error running template %s: %v", cfg.Key, err)}}
+		}
+		return diagnostics
+	}
+}
+
+func run(ctx context.Context, execCfg config.ExecTemplateConfig, contextObjects *util.ContextCache, params map[string]interface{}, lintCtx lintcontext.LintContext, object lintcontext.Object) ([]diagnostic.Diagnostic, error) {
+	req := request{Params: params}
+	var err error
+	if req.Object, err = util.ToMap(object.K8sObject); err != nil {
+		return nil, fmt.Errorf("failed to convert object to map: %w", err)
+	}
+	if contextObjects != nil {
+		objects, err := contextObjects.Get(lintCtx)
+		if err != nil {
+			return nil, err
+		}
+		req.Objects = objects.([]map[string]interface{})
+	}
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, execCfg.Command, execCfg.Args...) // #nosec G204 -- The command is declared in the config
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		msg := strings.TrimSpace(stderr.String())
+		if len(msg) > maxStderrLength {
+			msg = msg[:maxStderrLength] + "..."
+		}
+		if msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+
+	var resp response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	diagnostics := make([]diagnostic.Diagnostic, 0, len(resp.Diagnostics))
+	for _, d := range resp.Diagnostics {
+		diagnostics = append(diagnostics, diagnostic.Diagnostic{
+			Message:   d.Message,
+			Path:      d.Path,
+			Code:      d.Code,
+			Container: d.Container,
+			Value:     d.Value,
+			Expected:  d.Expected,
+		})
+	}
+	return diagnostics, nil
+}
@@ -0,0 +1,193 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	appsV1 "k8s.io/api/apps/v1"
+)
+
+// programEnv makes the test binary act as the program of a template, which reports the objects that do not have
+// the label of the "label" param.
+const programEnv = "KUBE_LINTER_TEST_EXTERNAL_TEMPLATE"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(programEnv) != "" {
+		os.Exit(runProgram())
+	}
+	os.Exit(m.Run())
+}
+
+func runProgram() int {
+	var req struct {
+		Params  map[string]interface{}
+		Object  map[string]interface{}
+		Objects []interface{}
+	}
+	if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	label, _ := req.Params["label"].(string)
+	if label == "" {
+		fmt.Fprintln(os.Stderr, "no label given")
+		return 2
+	}
+	metadata, _ := req.Object["metadata"].(map[string]interface{})
+	labels, _ := metadata["labels"].(map[string]interface{})
+	var diagnostics []map[string]string
+	if _, found := labels[label]; !found {
+		diagnostics = append(diagnostics, map[string]string{
+			"message":  fmt.Sprintf("%s has no %s label (%d objects)", metadata["name"], label, len(req.Objects)),
+			"path":     "metadata.labels",
+			"code":     "label/missing",
+			"expected": label,
+		})
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(map[string]interface{}{"diagnostics": diagnostics}); err != nil {
+		return 1
+	}
+	return 0
+}
+
+func labelTemplateConfig(includeObjects bool) config.TemplateConfig {
+	return config.TemplateConfig{
+		Key:                  "label",
+		SupportedObjectKinds: config.ObjectKindsDesc{ObjectKinds: []string{"DeploymentLike"}},
+		Parameters: []config.TemplateParameterConfig{
+			{Name: "label", Type: "string"},
+			{Name: "ports", Type: "array", ArrayElemType: "integer"},
+			{Name: "mode", Type: "string", Enum: []string{"strict", "lenient"}},
+		},
+		Exec: config.ExecTemplateConfig{Command: os.Args[0], IncludeObjects: includeObjects},
+	}
+}
+
+func TestNew(t *testing.T) {
+	template, err := New(labelTemplateConfig(false))
+	require.NoError(t, err)
+	assert.Equal(t, "label", template.HumanName)
+	require.Len(t, template.Parameters, 3)
+	assert.True(t, template.Parameters[0].NoRegex)
+
+	_, err = New(config.TemplateConfig{
+		SupportedObjectKinds: config.ObjectKindsDesc{ObjectKinds: []string{"NotAKind"}},
+		Parameters: []config.TemplateParameterConfig{
+			{Name: "label", Type: "text"},
+			{Name: "ports", Type: "string", ArrayElemType: "integer"},
+			{Name: "mode", Type: "string"},
+			{Name: "mode", Type: "string"},
+		},
+	})
+	require.Error(t, err)
+	for _, msg := range []string{
+		"no key specified",
+		"no exec command specified",
+		"NotAKind",
+		`parameter label has invalid type "text"`,
+		`parameter ports has invalid array element type "integer"`,
+		"duplicate parameter mode",
+	} {
+		assert.ErrorContains(t, err, msg)
+	}
+}
+
+func TestParseAndValidateParams(t *testing.T) {
+	template, err := New(labelTemplateConfig(false))
+	require.NoError(t, err)
+
+	params, err := template.ParseAndValidateParams(map[string]interface{}{
+		"label": "team",
+		"ports": []interface{}{80, 443.0},
+		"mode":  "strict",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "team", params.(map[string]interface{})["label"])
+
+	for _, tc := range []struct {
+		params map[string]interface{}
+		err    string
+	}{
+		{params: map[string]interface{}{"label": 1}, err: "param label must be of type string"},
+		{params: map[string]interface{}{"ports": []interface{}{80.5}}, err: "elements of param ports must be of type integer"},
+		{params: map[string]interface{}{"mode": "loose"}, err: `param mode has invalid value "loose"`},
+		{params: map[string]interface{}{"color": "red"}, err: "unknown param color"},
+	} {
+		_, err := template.ParseAndValidateParams(tc.params)
+		assert.ErrorContains(t, err, tc.err)
+	}
+
+	cfg := labelTemplateConfig(false)
+	cfg.Parameters[0].Required = true
+	template, err = New(cfg)
+	require.NoError(t, err)
+	_, err = template.ParseAndValidateParams(nil)
+	assert.ErrorContains(t, err, "required param label not found")
+
+	// Enums of params that are not strings are matched against the string form of their values.
+	cfg = labelTemplateConfig(false)
+	cfg.Parameters = []config.TemplateParameterConfig{{Name: "replicas", Type: "integer", Enum: []string{"1", "2"}}}
+	template, err = New(cfg)
+	require.NoError(t, err)
+	_, err = template.ParseAndValidateParams(map[string]interface{}{"replicas": 2})
+	assert.NoError(t, err)
+	_, err = template.ParseAndValidateParams(map[string]interface{}{"replicas": 3})
+	assert.ErrorContains(t, err, `param replicas has invalid value "3", must be one of [1 2]`)
+}
+
+func TestRun(t *testing.T) {
+	t.Setenv(programEnv, "1")
+
+	lintCtx := mocks.NewMockContext()
+	lintCtx.AddMockDeployment(t, "labeled")
+	lintCtx.ModifyDeployment(t, "labeled", func(deployment *appsV1.Deployment) {
+		deployment.Labels = map[string]string{"team": "payments"}
+	})
+	lintCtx.AddMockDeployment(t, "unlabeled")
+
+	for _, tc := range []struct {
+		includeObjects bool
+		expected       string
+	}{
+		{includeObjects: false, expected: "unlabeled has no team label (0 objects)"},
+		{includeObjects: true, expected: "unlabeled has no team label (2 objects)"},
+	} {
+		template, err := New(labelTemplateConfig(tc.includeObjects))
+		require.NoError(t, err)
+		checkFunc, err := template.InstantiateWithContext(map[string]interface{}{"label": "team"})
+		require.NoError(t, err)
+
+		var reported []diagnostic.Diagnostic
+		for _, obj := range lintCtx.Objects() {
+			reported = append(reported, checkFunc(context.Background(), lintCtx, obj)...)
+		}
+		assert.Equal(t, []diagnostic.Diagnostic{{
+			Message:  tc.expected,
+			Path:     "metadata.labels",
+			Code:     "label/missing",
+			Expected: "team",
+		}}, reported)
+	}
+
+	template, err := New(labelTemplateConfig(false))
+	require.NoError(t, err)
+	checkFunc, err := template.InstantiateWithContext(map[string]interface{}{})
+	require.NoError(t, err)
+	reported := checkFunc(context.Background(), lintCtx, lintCtx.Objects()[0])
+	require.Len(t, reported, 1)
+	assert.Equal(t, "error running template label: exit status 2: no label given", reported[0].Message)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	reported = checkFunc(ctx, lintCtx, lintCtx.Objects()[0])
+	require.Len(t, reported, 1)
+	assert.Equal(t, "error running template label: context canceled", reported[0].Message)
+}
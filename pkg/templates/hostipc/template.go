@@ -9,6 +9,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/hostipc/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
 func init() {
@@ -28,7 +29,8 @@ func init() {
 					return nil
 				}
 				if podSpec.HostIPC {
-					return []diagnostic.Diagnostic{{Message: "resource shares host's IPC namespace (via hostIPC=true)."}}
+					podSpecPath, _ := extract.PodSpecPath(object.K8sObject)
+					return []diagnostic.Diagnostic{{Message: "resource shares host's IPC namespace (via hostIPC=true).", Path: util.JoinPath(podSpecPath, "hostIPC")}}
 				}
 				return nil
 			}, nil
@@ -12,6 +12,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/hostmounts/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
 const (
@@ -42,8 +43,10 @@ func init() {
 				if !found {
 					return nil
 				}
+				podSpecPath, _ := extract.PodSpecPath(object.K8sObject)
 				var results []diagnostic.Diagnostic
 				containers := podSpec.AllContainers()
+				containerPaths := podSpec.AllContainerPaths()
 				for _, v := range podSpec.Volumes {
 					if v.HostPath == nil {
 						continue
@@ -52,11 +55,15 @@ func init() {
 						if !regex.MatchString(v.HostPath.Path) {
 							continue
 						}
-						for _, container := range containers {
-							for _, mount := range container.VolumeMounts {
+						for i, container := range containers {
+							for j, mount := range container.VolumeMounts {
 								if mount.Name == v.Name {
 									results = append(results, diagnostic.Diagnostic{
-										Message: fmt.Sprintf("host system directory %q is mounted on container %q", v.HostPath.Path, container.Name)})
+										Message:   fmt.Sprintf("host system directory %q is mounted on container %q", v.HostPath.Path, container.Name),
+										Path:      util.JoinPath(podSpecPath, containerPaths[i], fmt.Sprintf("volumeMounts[%d]", j)),
+										Container: container.Name,
+										Value:     v.HostPath.Path,
+									})
 								}
 							}
 						}
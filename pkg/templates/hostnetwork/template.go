@@ -9,6 +9,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/hostnetwork/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
 func init() {
@@ -28,7 +29,8 @@ func init() {
 					return nil
 				}
 				if podSpec.HostNetwork {
-					return []diagnostic.Diagnostic{{Message: "resource shares host's network namespace (via hostNetwork=true)."}}
+					podSpecPath, _ := extract.PodSpecPath(object.K8sObject)
+					return []diagnostic.Diagnostic{{Message: "resource shares host's network namespace (via hostNetwork=true).", Path: util.JoinPath(podSpecPath, "hostNetwork")}}
 				}
 				return nil
 			}, nil
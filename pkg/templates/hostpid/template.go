@@ -9,6 +9,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/hostpid/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
 func init() {
@@ -28,7 +29,8 @@ func init() {
 					return nil
 				}
 				if podSpec.HostPID {
-					return []diagnostic.Diagnostic{{Message: "object shares the host's process namespace (via hostPID=true)."}}
+					podSpecPath, _ := extract.PodSpecPath(object.K8sObject)
+					return []diagnostic.Diagnostic{{Message: "object shares the host's process namespace (via hostPID=true).", Path: util.JoinPath(podSpecPath, "hostPID")}}
 				}
 				return nil
 			}, nil
@@ -6,6 +6,7 @@ import (
 	"golang.stackrox.io/kube-linter/internal/stringutils"
 	"golang.stackrox.io/kube-linter/pkg/check"
 	"golang.stackrox.io/kube-linter/pkg/config"
+	kedaV1Alpha1 "golang.stackrox.io/kube-linter/pkg/crds/keda/v1alpha1"
 	"golang.stackrox.io/kube-linter/pkg/diagnostic"
 	"golang.stackrox.io/kube-linter/pkg/extract"
 	"golang.stackrox.io/kube-linter/pkg/lintcontext"
@@ -18,6 +19,14 @@ const (
 	templateKey = "hpa-minimum-replicas"
 )
 
+// minReplicasPath returns the path of the minimum number of replicas of the given object.
+func minReplicasPath(object lintcontext.Object) string {
+	if _, ok := object.K8sObject.(*kedaV1Alpha1.ScaledObject); ok {
+		return "spec.minReplicaCount"
+	}
+	return "spec.minReplicas"
+}
+
 func init() {
 	templates.Register(check.Template{
 		HumanName:   "HorizontalPodAutoscaler Minimum replicas",
@@ -40,7 +49,11 @@ func init() {
 				return []diagnostic.Diagnostic{
 					{Message: fmt.Sprintf("object has %d %s but minimum required replicas is %d",
 						replicas, stringutils.Ternary(replicas > 1, "replicas", "replica"),
-						p.MinReplicas)},
+						p.MinReplicas),
+						Path:     minReplicasPath(object),
+						Value:    fmt.Sprint(replicas),
+						Expected: fmt.Sprintf("at least %d", p.MinReplicas),
+					},
 				}
 			}, nil
 		}),
@@ -2,6 +2,7 @@ package imagepullpolicy
 
 import (
 	"fmt"
+	"strings"
 
 	"golang.stackrox.io/kube-linter/internal/set"
 	"golang.stackrox.io/kube-linter/pkg/check"
@@ -30,9 +31,27 @@ func init() {
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
 			forbiddenPolicies := set.NewStringSet(p.ForbiddenPolicies...)
+			// Fixes set the policy to the first allowed one of these.
+			var fixPolicy v1.PullPolicy
+			for _, policy := range []v1.PullPolicy{v1.PullAlways, v1.PullIfNotPresent} {
+				if !forbiddenPolicies.Contains(string(policy)) {
+					fixPolicy = policy
+					break
+				}
+			}
 			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
 				if forbiddenPolicies.Contains(string(container.ImagePullPolicy)) {
-					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q has imagePullPolicy set to %s", container.Name, container.ImagePullPolicy)}}
+					var fix *diagnostic.Fix
+					if fixPolicy != "" {
+						fix = diagnostic.SetFix(fmt.Sprintf("Set imagePullPolicy to %s", fixPolicy), "imagePullPolicy", string(fixPolicy))
+					}
+					return []diagnostic.Diagnostic{{
+						Message:  fmt.Sprintf("container %q has imagePullPolicy set to %s", container.Name, container.ImagePullPolicy),
+						Path:     "imagePullPolicy",
+						Value:    string(container.ImagePullPolicy),
+						Expected: fmt.Sprintf("an imagePullPolicy other than %s", strings.Join(p.ForbiddenPolicies, ", ")),
+						Fix:      fix,
+					}}
 				}
 				return nil
 			}), nil
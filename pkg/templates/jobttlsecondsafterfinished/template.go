@@ -32,11 +32,12 @@ func init() {
 				switch kind {
 				case "Job":
 					if jobSpec.TTLSecondsAfterFinished == nil {
-						return []diagnostic.Diagnostic{{Message: "Standalone Job does not specify ttlSecondsAfterFinished"}}
+						return []diagnostic.Diagnostic{{Message: "Standalone Job does not specify ttlSecondsAfterFinished", Path: "spec.ttlSecondsAfterFinished"}}
 					}
 				case "CronJob":
 					if jobSpec.TTLSecondsAfterFinished != nil {
-						return []diagnostic.Diagnostic{{Message: "Managed Job specifies ttlSecondsAfterFinished which might conflict with successfulJobsHistoryLimit and failedJobsHistoryLimit from CronJob that have default values. Final behaviour is determined by the strictest parameter, and therefore, setting ttlSecondsAfterFinished at the job level can result with unexpected behaviour with regard to finished jobs removal"}}
+						return []diagnostic.Diagnostic{{Message: "Managed Job specifies ttlSecondsAfterFinished which might conflict with successfulJobsHistoryLimit and failedJobsHistoryLimit from CronJob that have default values. Final behaviour is determined by the strictest parameter, and therefore, setting ttlSecondsAfterFinished at the job level can result with unexpected behaviour with regard to finished jobs removal",
+							Path: "spec.jobTemplate.spec.ttlSecondsAfterFinished"}}
 					}
 				}
 				return nil
@@ -1,6 +1,7 @@
 package kubeconform
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -29,11 +30,20 @@ func init() {
 		},
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
-		Instantiate:            params.WrapInstantiateFunc(validate),
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			checkFunc, err := validate(p)
+			if err != nil {
+				return nil, err
+			}
+			return checkFunc.WithoutContext(), nil
+		}),
+		InstantiateWithContext: func(paramsInt interface{}) (check.ContextFunc, error) {
+			return validate(paramsInt.(params.Params))
+		},
 	})
 }
 
-func validate(p params.Params) (check.Func, error) {
+func validate(p params.Params) (check.ContextFunc, error) {
 	// Create cache directory if it doesn't exist
 	if p.Cache != "" {
 		if err := os.MkdirAll(p.Cache, 0o750); err != nil {
@@ -52,11 +62,19 @@ func validate(p params.Params) (check.Func, error) {
 	if err != nil {
 		return nil, fmt.Errorf("creating kubeconform validator: %w", err)
 	}
-	return func(ctx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+	return func(ctx context.Context, _ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+		// The validator cannot be interrupted while it downloads a schema (its ValidateWithContext reads streams of
+		// documents, with a large buffer for each), so the context is only checked before and after validating.
+		if ctx.Err() != nil {
+			return nil
+		}
 		res := v.ValidateResource(resource.Resource{
 			Path:  object.Metadata.FilePath,
 			Bytes: object.Metadata.Raw,
 		})
+		if ctx.Err() != nil {
+			return nil
+		}
 		if res.Status == validator.Invalid {
 			return []diagnostic.Diagnostic{
 				{Message: fmt.Sprintf("resource is not valid: %v", res.Err)},
@@ -1,6 +1,7 @@
 package kubeconform
 
 import (
+	"context"
 	_ "embed"
 	"testing"
 
@@ -76,9 +77,14 @@ func TestValidateWithEmptyParams(t *testing.T) {
 	assert.NotNil(t, checkFunc)
 
 	// Run the validation (LintContext not used by kubeconform validator)
-	var ctx lintcontext.LintContext
-	diagnostics := checkFunc(ctx, object)
+	var lintCtx lintcontext.LintContext
+	diagnostics := checkFunc(context.Background(), lintCtx, object)
 
 	require.Len(t, diagnostics, 1)
 	assert.Contains(t, diagnostics[0].Message, `- at '/spec/selector/matchLabels/k8s-app': got boolean, want null or string - at '/spec': additional properties 'replicas' not allowed`)
+
+	// Nothing is reported once the context is done.
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Empty(t, checkFunc(canceledCtx, lintCtx, object))
 }
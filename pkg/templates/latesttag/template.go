@@ -56,9 +56,21 @@ func init() {
 
 			return util.PerContainerCheck(func(container *v1.Container) (results []diagnostic.Diagnostic) {
 				if len(blockedRegexes) > 0 && isInList(blockedRegexes, container.Image) {
-					results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("The container %q is using an invalid container image, %q. Please use images that are not blocked by the `BlockList` criteria : %q", container.Name, container.Image, blockedRegexes)})
+					results = append(results, diagnostic.Diagnostic{
+						Message:  fmt.Sprintf("The container %q is using an invalid container image, %q. Please use images that are not blocked by the `BlockList` criteria : %q", container.Name, container.Image, blockedRegexes),
+						Path:     "image",
+						Code:     templateKey + "/blocked-image",
+						Value:    container.Image,
+						Expected: fmt.Sprintf("an image that matches none of %q", blockedRegexes),
+					})
 				} else if len(allowedRegexes) > 0 && !isInList(allowedRegexes, container.Image) {
-					results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("The container %q is using an invalid container image, %q. Please use images that satisfies the `AllowList` criteria : %q", container.Name, container.Image, allowedRegexes)})
+					results = append(results, diagnostic.Diagnostic{
+						Message:  fmt.Sprintf("The container %q is using an invalid container image, %q. Please use images that satisfies the `AllowList` criteria : %q", container.Name, container.Image, allowedRegexes),
+						Path:     "image",
+						Code:     templateKey + "/image-not-allowed",
+						Value:    container.Image,
+						Expected: fmt.Sprintf("an image that matches one of %q", allowedRegexes),
+					})
 				}
 				return results
 			}), nil
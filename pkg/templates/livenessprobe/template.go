@@ -27,7 +27,7 @@ func init() {
 		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
 			return util.PerNonInitContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
 				if container.LivenessProbe == nil {
-					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q does not specify a liveness probe", container.Name)}}
+					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q does not specify a liveness probe", container.Name), Path: "livenessProbe"}}
 				}
 				return nil
 			}), nil
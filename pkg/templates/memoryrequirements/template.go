@@ -16,13 +16,20 @@ import (
 )
 
 const (
-	bytesInMB = 1024 * 1024
+	templateKey = "memory-requirements"
+	bytesInMB   = 1024 * 1024
 )
 
 func process(results *[]diagnostic.Diagnostic, containerName, requirementsType string, quantity *resource.Quantity, lowerBoundBytes int, upperBoundBytes *int) {
 	if util.ValueInRange(int(quantity.Value()), lowerBoundBytes, upperBoundBytes) {
 		*results = append(*results, diagnostic.Diagnostic{
 			Message: fmt.Sprintf("container %q has memory %s %s", containerName, requirementsType, quantity),
+			Path:    fmt.Sprintf("resources.%ss.memory", requirementsType),
+			Code:    templateKey + "/" + requirementsType,
+			Value:   quantity.String(),
+			Expected: util.DescribeOutsideRange(lowerBoundBytes, upperBoundBytes, func(bytes int) string {
+				return resource.NewQuantity(int64(bytes), resource.BinarySI).String()
+			}),
 		})
 	}
 }
@@ -30,7 +37,7 @@ func process(results *[]diagnostic.Diagnostic, containerName, requirementsType s
 func init() {
 	templates.Register(check.Template{
 		HumanName:   "Memory Requirements",
-		Key:         "memory-requirements",
+		Key:         templateKey,
 		Description: "Flag containers with memory requirements in the given range",
 		SupportedObjectKinds: config.ObjectKindsDesc{
 			ObjectKinds: []string{objectkinds.DeploymentLike},
@@ -11,12 +11,22 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/mismatchingselector/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 	v1 "k8s.io/api/batch/v1"
 	"k8s.io/api/batch/v1beta1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 )
 
+// selectorPath returns the path of the selector of the given object.
+func selectorPath(object lintcontext.Object) string {
+	switch object.K8sObject.(type) {
+	case *v1beta1.CronJob, *v1.CronJob:
+		return "spec.jobTemplate.spec.selector"
+	}
+	return "spec.selector"
+}
+
 func init() {
 	templates.Register(check.Template{
 		HumanName:   "Mismatching Selector",
@@ -41,6 +51,7 @@ func init() {
 					}
 					return []diagnostic.Diagnostic{{
 						Message: "object has no selector specified",
+						Path:    selectorPath(object),
 					}}
 				}
 
@@ -52,12 +63,17 @@ func init() {
 				if err != nil {
 					return []diagnostic.Diagnostic{{
 						Message: fmt.Sprintf("object has invalid label selector: %v", err),
+						Path:    selectorPath(object),
 					}}
 				}
 				if labelSelector.Matches(labels.Set(podTemplateSpec.Labels)) {
 					return nil
 				}
-				return []diagnostic.Diagnostic{{Message: fmt.Sprintf("labels in pod spec (%v) do not match labels in selector (%v)", podTemplateSpec.Labels, selector)}}
+				podMetadataPath, _ := extract.PodMetadataPath(object.K8sObject)
+				return []diagnostic.Diagnostic{{
+					Message: fmt.Sprintf("labels in pod spec (%v) do not match labels in selector (%v)", podTemplateSpec.Labels, selector),
+					Path:    util.JoinPath(podMetadataPath, "labels"),
+				}}
 			}, nil
 		}),
 	})
@@ -28,7 +28,7 @@ func init() {
 				namespace := object.K8sObject.GetNamespace()
 				ns := stringutils.OrDefault(namespace, "default")
 				if strings.EqualFold(ns, "default") {
-					return []diagnostic.Diagnostic{{Message: "object in default namespace"}}
+					return []diagnostic.Diagnostic{{Message: "object in default namespace", Path: "metadata.namespace", Value: ns, Expected: "a namespace other than default"}}
 				}
 				return nil
 			}, nil
@@ -9,6 +9,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/nodeaffinity/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
 func init() {
@@ -27,11 +28,9 @@ func init() {
 				if !found {
 					return nil
 				}
-				if podTemplateSpec.Spec.Affinity == nil {
-					return []diagnostic.Diagnostic{{Message: "object does not define any node affinity rules."}}
-				}
-				if podTemplateSpec.Spec.Affinity.NodeAffinity == nil {
-					return []diagnostic.Diagnostic{{Message: "object does not define any node affinity rules."}}
+				podSpecPath, _ := extract.PodSpecPath(object.K8sObject)
+				if podTemplateSpec.Spec.Affinity == nil || podTemplateSpec.Spec.Affinity.NodeAffinity == nil {
+					return []diagnostic.Diagnostic{{Message: "object does not define any node affinity rules.", Path: util.JoinPath(podSpecPath, "affinity.nodeAffinity")}}
 				}
 				return nil
 			}, nil
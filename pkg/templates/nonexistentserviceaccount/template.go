@@ -13,6 +13,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/nonexistentserviceaccount/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -28,6 +29,7 @@ func init() {
 		SupportedObjectKinds: config.ObjectKindsDesc{
 			ObjectKinds: []string{objectkinds.DeploymentLike},
 		},
+		NeedsOtherObjects:      true,
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
@@ -41,6 +43,7 @@ func init() {
 				if sa == "" || sa == "default" {
 					return nil
 				}
+				podSpecPath, _ := extract.PodSpecPath(object.K8sObject)
 				ns := object.K8sObject.GetNamespace()
 				serviceAccountsInCtx := set.NewStringSet()
 				for _, otherObj := range lintCtx.Objects() {
@@ -50,8 +53,11 @@ func init() {
 					}
 				}
 				if !serviceAccountsInCtx.Contains(sa) {
-					return []diagnostic.Diagnostic{{Message: fmt.Sprintf(
-						"serviceAccount %q not found", sa)}}
+					return []diagnostic.Diagnostic{{
+						Message: fmt.Sprintf("serviceAccount %q not found", sa),
+						Path:    util.JoinPath(podSpecPath, stringutils.Ternary(podSpec.ServiceAccountName != "", "serviceAccountName", "serviceAccount")),
+						Value:   sa,
+					}}
 				}
 				return nil
 			}, nil
@@ -11,6 +11,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/nonisolatedpod/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 	networkingV1 "k8s.io/api/networking/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -28,6 +29,7 @@ func init() {
 		SupportedObjectKinds: config.ObjectKindsDesc{
 			ObjectKinds: []string{objectkinds.NetworkPolicy},
 		},
+		NeedsOtherObjects:      true,
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
@@ -57,8 +59,10 @@ func init() {
 						return nil
 					}
 				}
+				podMetadataPath, _ := extract.PodMetadataPath(object.K8sObject)
 				return []diagnostic.Diagnostic{{
 					Message: "pods created by this object are non-isolated",
+					Path:    util.JoinPath(podMetadataPath, "labels"),
 				}}
 			}, nil
 		}),
@@ -45,12 +45,14 @@ func init() {
 				if err != nil {
 					return []diagnostic.Diagnostic{{
 						Message: fmt.Sprintf("maxUnavailable has invalid value [%s]", pdb.Spec.MaxUnavailable),
+						Path:    "spec.maxUnavailable",
 					}}
 				}
 
 				if maxUnavailable == 0 {
 					return []diagnostic.Diagnostic{{
 						Message: "MaxUnavailable is set to 0",
+						Path:    "spec.maxUnavailable",
 					}}
 				}
 
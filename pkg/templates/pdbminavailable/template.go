@@ -33,6 +33,7 @@ func init() {
 			ObjectKinds: []string{
 				objectkinds.PodDisruptionBudget},
 		},
+		NeedsOtherObjects:      true,
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
@@ -41,6 +42,11 @@ func init() {
 	})
 }
 
+const (
+	minAvailablePath = "spec.minAvailable"
+	selectorPath     = "spec.selector"
+)
+
 func minAvailableCheck(lintCtx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
 
 	var results []diagnostic.Diagnostic
@@ -62,6 +68,7 @@ func minAvailableCheck(lintCtx lintcontext.LintContext, object lintcontext.Objec
 		return []diagnostic.Diagnostic{
 			{
 				Message: fmt.Sprintf("PDB has invalid MinAvailable value: %v", err),
+				Path:    minAvailablePath,
 			},
 		}
 	}
@@ -72,6 +79,7 @@ func minAvailableCheck(lintCtx lintcontext.LintContext, object lintcontext.Objec
 		return []diagnostic.Diagnostic{
 			{
 				Message: "PDB has minimum available replicas set to 100 percent of replicas",
+				Path:    minAvailablePath,
 			},
 		}
 	}
@@ -81,6 +89,7 @@ func minAvailableCheck(lintCtx lintcontext.LintContext, object lintcontext.Objec
 		return []diagnostic.Diagnostic{
 			{
 				Message: "PDB is missing required selector field: https://kubernetes.io/docs/tasks/run-application/configure-pdb/#specifying-a-poddisruptionbudget",
+				Path:    selectorPath,
 			},
 		}
 	}
@@ -95,6 +104,7 @@ func minAvailableCheck(lintCtx lintcontext.LintContext, object lintcontext.Objec
 		return []diagnostic.Diagnostic{
 			{
 				Message: fmt.Sprintf("PDB has invalid label selector: %s", err),
+				Path:    selectorPath,
 			},
 		}
 	}
@@ -108,6 +118,7 @@ func minAvailableCheck(lintCtx lintcontext.LintContext, object lintcontext.Objec
 		return []diagnostic.Diagnostic{
 			{
 				Message: fmt.Sprintf("Failed to retrieve deployments matching the PDB's label selector within namespace %s: %s", pdb.Namespace, err),
+				Path:    selectorPath,
 			},
 		}
 	}
@@ -127,6 +138,7 @@ func minAvailableCheck(lintCtx lintcontext.LintContext, object lintcontext.Objec
 		if replicas <= int32(pdbMinAvailable) {
 			results = append(results, diagnostic.Diagnostic{
 				Message: fmt.Sprintf("The current number of replicas for deployment %s is equal to or lower than the minimum number of replicas specified by its PDB.", dl.GetName()),
+				Path:    minAvailablePath,
 			})
 		}
 	}
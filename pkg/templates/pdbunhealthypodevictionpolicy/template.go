@@ -33,7 +33,7 @@ func init() {
 					return nil
 				}
 				if pdb.Spec.UnhealthyPodEvictionPolicy == nil {
-					return []diagnostic.Diagnostic{{Message: "unhealthyPodEvictionPolicy is not explicitly set"}}
+					return []diagnostic.Diagnostic{{Message: "unhealthyPodEvictionPolicy is not explicitly set", Path: "spec.unhealthyPodEvictionPolicy"}}
 				}
 				return nil
 			}, nil
@@ -32,7 +32,7 @@ func init() {
 			}
 			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
 				var results []diagnostic.Diagnostic
-				for _, port := range container.Ports {
+				for i, port := range container.Ports {
 					// The k8s protocol defaults to TCP even if not set in the YAML.
 					protocol := string(port.Protocol)
 					if protocol == "" {
@@ -42,6 +42,8 @@ func init() {
 						results = append(results, diagnostic.Diagnostic{
 							Message: fmt.Sprintf("port %d and protocol %s in container %q found",
 								port.ContainerPort, protocol, container.Name),
+							Path:  fmt.Sprintf("ports[%d]", i),
+							Value: fmt.Sprintf("%d/%s", port.ContainerPort, protocol),
 						})
 					}
 				}
@@ -14,6 +14,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/priorityclassname/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
 const (
@@ -38,8 +39,14 @@ func init() {
 				if !found || isEmpty || isAccepted {
 					return nil
 				}
+				podSpecPath, _ := extract.PodSpecPath(object.K8sObject)
 				return []diagnostic.Diagnostic{
-					{Message: fmt.Sprintf("object has a priority class name defined with '%s' but the only accepted priority class names are '%s'", spec.PriorityClassName, p.AcceptedPriorityClassNames)},
+					{
+						Message:  fmt.Sprintf("object has a priority class name defined with '%s' but the only accepted priority class names are '%s'", spec.PriorityClassName, p.AcceptedPriorityClassNames),
+						Path:     util.JoinPath(podSpecPath, "priorityClassName"),
+						Value:    spec.PriorityClassName,
+						Expected: fmt.Sprintf("one of %s", strings.Join(p.AcceptedPriorityClassNames, ", ")),
+					},
 				}
 			}, nil
 		}),
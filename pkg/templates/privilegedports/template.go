@@ -26,10 +26,13 @@ func init() {
 		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
 			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
 				var results []diagnostic.Diagnostic
-				for _, port := range container.Ports {
+				for i, port := range container.Ports {
 					if int(port.ContainerPort) > 0 && int(port.ContainerPort) < 1024 {
 						results = append(results, diagnostic.Diagnostic{
-							Message: fmt.Sprintf("port %d is mapped in container %q.", port.ContainerPort, container.Name),
+							Message:  fmt.Sprintf("port %d is mapped in container %q.", port.ContainerPort, container.Name),
+							Path:     fmt.Sprintf("ports[%d].containerPort", i),
+							Value:    fmt.Sprint(port.ContainerPort),
+							Expected: "a port of at least 1024",
 						})
 					}
 				}
@@ -14,13 +14,14 @@ import (
 )
 
 const (
+	templateKey        = "privilege-escalation-container"
 	sysAdminCapability = "SYS_ADMIN"
 )
 
 func init() {
 	templates.Register(check.Template{
 		HumanName:   "Privilege Escalation on Containers",
-		Key:         "privilege-escalation-container",
+		Key:         templateKey,
 		Description: "Flag containers of allowing privilege escalation",
 		SupportedObjectKinds: config.ObjectKindsDesc{
 			ObjectKinds: []string{objectkinds.DeploymentLike},
@@ -34,15 +35,30 @@ func init() {
 					return nil
 				}
 				if securityContext.AllowPrivilegeEscalation != nil && *securityContext.AllowPrivilegeEscalation {
-					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q has AllowPrivilegeEscalation set to true.", container.Name)}}
+					return []diagnostic.Diagnostic{{
+						Message: fmt.Sprintf("container %q has AllowPrivilegeEscalation set to true.", container.Name),
+						Path:    "securityContext.allowPrivilegeEscalation",
+						Code:    templateKey + "/allow-privilege-escalation",
+						Value:   "true",
+					}}
 				}
 				if securityContext.Privileged != nil && *securityContext.Privileged {
-					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q is Privileged hence allows privilege escalation.", container.Name)}}
+					return []diagnostic.Diagnostic{{
+						Message: fmt.Sprintf("container %q is Privileged hence allows privilege escalation.", container.Name),
+						Path:    "securityContext.privileged",
+						Code:    templateKey + "/privileged",
+						Value:   "true",
+					}}
 				}
 				if securityContext.Capabilities != nil {
 					for _, capability := range securityContext.Capabilities.Add {
 						if capability == sysAdminCapability {
-							return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q has SYS_ADMIN capability hence allows privilege escalation.", container.Name)}}
+							return []diagnostic.Diagnostic{{
+								Message: fmt.Sprintf("container %q has SYS_ADMIN capability hence allows privilege escalation.", container.Name),
+								Path:    "securityContext.capabilities.add",
+								Code:    templateKey + "/sys-admin-capability",
+								Value:   string(capability),
+							}}
 						}
 					}
 				}
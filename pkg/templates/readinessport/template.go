@@ -25,7 +25,7 @@ func init() {
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
 			return util.PerNonInitContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
-				return util.CheckProbePort(container, container.ReadinessProbe)
+				return util.CheckProbePort(container, container.ReadinessProbe, "readinessProbe")
 			}), nil
 		}),
 	})
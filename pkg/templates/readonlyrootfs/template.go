@@ -27,7 +27,12 @@ func init() {
 			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
 				sc := container.SecurityContext
 				if sc == nil || sc.ReadOnlyRootFilesystem == nil || !*sc.ReadOnlyRootFilesystem {
-					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q does not have a read-only root file system", container.Name)}}
+					return []diagnostic.Diagnostic{{
+						Message:  fmt.Sprintf("container %q does not have a read-only root file system", container.Name),
+						Path:     "securityContext.readOnlyRootFilesystem",
+						Expected: "true",
+						Fix:      diagnostic.SetFix("Set readOnlyRootFilesystem to true", "securityContext.readOnlyRootFilesystem", true),
+					}}
 				}
 				return nil
 			}), nil
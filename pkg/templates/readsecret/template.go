@@ -26,10 +26,12 @@ func init() {
 		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
 			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
 				var results []diagnostic.Diagnostic
-				for _, envVar := range container.Env {
+				for i, envVar := range container.Env {
 					if envVar.ValueFrom != nil && envVar.ValueFrom.SecretKeyRef != nil {
 						results = append(results, diagnostic.Diagnostic{
 							Message: fmt.Sprintf("environment variable %q in container %q uses SecretKeyRef", envVar.Name, container.Name),
+							Path:    fmt.Sprintf("env[%d].valueFrom.secretKeyRef", i),
+							Value:   envVar.Name,
 						})
 					}
 				}
@@ -0,0 +1,102 @@
+// Code generated by kube-linter template codegen. DO NOT EDIT.
+//go:build !templatecodegen
+// +build !templatecodegen
+
+package params
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
+var (
+	// Use some imports in case they don't get used otherwise.
+	_ = util.MustParseParameterDesc
+
+	moduleParamDesc = util.MustParseParameterDesc(`{
+	"Name": "module",
+	"Type": "string",
+	"Description": "Module is a Rego module whose deny and violation rules report problems with the object. The object is available as input.object (and as input.review.object, as in Gatekeeper), and all the objects being linted as data.objects.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": false,
+	"NoRegex": true,
+	"NotNegatable": true,
+	"XXXStructFieldName": "Module",
+	"XXXIsPointer": false
+}
+`)
+
+	moduleFileParamDesc = util.MustParseParameterDesc(`{
+	"Name": "moduleFile",
+	"Type": "string",
+	"Description": "ModuleFile is the path to a file that contains the Rego module. Exactly one of module and moduleFile must be set.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": false,
+	"NoRegex": true,
+	"NotNegatable": true,
+	"XXXStructFieldName": "ModuleFile",
+	"XXXIsPointer": false
+}
+`)
+
+	regoVersionParamDesc = util.MustParseParameterDesc(`{
+	"Name": "regoVersion",
+	"Type": "string",
+	"Description": "RegoVersion is the version of the Rego syntax that the module is written in, either v1 or v0. Defaults to v1.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": false,
+	"NoRegex": true,
+	"NotNegatable": true,
+	"XXXStructFieldName": "RegoVersion",
+	"XXXIsPointer": false
+}
+`)
+
+	ParamDescs = []check.ParameterDesc{
+		moduleParamDesc,
+		moduleFileParamDesc,
+		regoVersionParamDesc,
+	}
+)
+
+func (p *Params) Validate() error {
+	var validationErrors []string
+	if len(validationErrors) > 0 {
+		return fmt.Errorf("invalid parameters: %s", strings.Join(validationErrors, ", "))
+    }
+	return nil
+}
+
+// ParseAndValidate instantiates a Params object out of the passed map[string]interface{},
+// validates it, and returns it.
+// The return type is interface{} to satisfy the type in the Template struct.
+func ParseAndValidate(m map[string]interface{}) (interface{}, error) {
+	var p Params
+	if err := util.DecodeMapStructure(m, &p); err != nil {
+		return nil, err
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// WrapInstantiateFunc is a convenience wrapper that wraps an untyped instantiate function
+// into a typed one.
+func WrapInstantiateFunc(f func(p Params) (check.Func, error)) func(interface{}) (check.Func, error) {
+	return func(paramsInt interface{}) (check.Func, error) {
+		return f(paramsInt.(Params))
+	}
+}
@@ -0,0 +1,17 @@
+package params
+
+// Params defines the configuration parameters for this template.
+type Params struct {
+	// Module is a Rego module whose deny and violation rules report problems with the object. The object is available as input.object (and as input.review.object, as in Gatekeeper), and all the objects being linted as data.objects.
+	// +noregex
+	// +notnegatable
+	Module string
+	// ModuleFile is the path to a file that contains the Rego module. Exactly one of module and moduleFile must be set.
+	// +noregex
+	// +notnegatable
+	ModuleFile string
+	// RegoVersion is the version of the Rego syntax that the module is written in, either v1 or v0. Defaults to v1.
+	// +noregex
+	// +notnegatable
+	RegoVersion string
+}
@@ -0,0 +1,184 @@
+package rego
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/rego"
+	"github.com/open-policy-agent/opa/v1/storage/inmem"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/rego/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
+const (
+	templateKey = "rego"
+)
+
+// resultRules are the rules of the module whose entries are reported as diagnostics.
+var resultRules = []string{"deny", "violation"}
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Rego",
+		Key:         templateKey,
+		Description: "Flag objects with a Rego policy, for example a Gatekeeper or Conftest policy",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.Any},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			checkFunc, err := instantiate(p)
+			if err != nil {
+				return nil, err
+			}
+			return checkFunc.WithoutContext(), nil
+		}),
+		InstantiateWithContext: func(paramsInt interface{}) (check.ContextFunc, error) {
+			return instantiate(paramsInt.(params.Params))
+		},
+	})
+}
+
+func instantiate(p params.Params) (check.ContextFunc, error) {
+	module, err := parseModule(p)
+	if err != nil {
+		return nil, err
+	}
+	query := make([]string, 0, len(resultRules))
+	for _, rule := range resultRules {
+		// Comprehensions are empty, rather than undefined, if the module does not define the rule.
+		query = append(query, fmt.Sprintf("%s := [entry | entry := %s.%s[_]]", rule, module.Package.Path, rule))
+	}
+
+	// The objects of each context are loaded into the data document once, and the query is prepared against them.
+	queries := util.NewContextCache(func(lintCtx lintcontext.LintContext) (interface{}, error) {
+		objects, err := util.ObjectMaps(lintCtx)
+		if err != nil {
+			return nil, err
+		}
+		objectsData := make([]interface{}, len(objects))
+		for i, object := range objects {
+			objectsData[i] = object
+		}
+		return rego.New(
+			rego.Query(strings.Join(query, "; ")),
+			rego.ParsedModule(module.Copy()),
+			rego.SetRegoVersion(module.RegoVersion()),
+			rego.Store(inmem.NewFromObject(map[string]interface{}{"objects": objectsData})),
+		).PrepareForEval(context.Background())
+	})
+
+	return func(ctx context.Context, lintCtx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+		messages, err := evaluate(ctx, queries, lintCtx, object)
+		if err != nil {
+			return []diagnostic.Diagnostic{{Message: fmt.Sprintf("error evaluating Rego policy: %v", err)}}
+		}
+		diagnostics := make([]diagnostic.Diagnostic, 0, len(messages))
+		for _, msg := range messages {
+			diagnostics = append(diagnostics, diagnostic.Diagnostic{Message: msg})
+		}
+		return diagnostics
+	}, nil
+}
+
+// parseModule parses the module of the params, and checks that it defines a rule to report.
+func parseModule(p params.Params) (*ast.Module, error) {
+	source, filename := p.Module, "module.rego"
+	switch {
+	case p.Module != "" && p.ModuleFile != "":
+		return nil, errors.New("only one of module and moduleFile can be specified")
+	case p.Module == "" && p.ModuleFile == "":
+		return nil, errors.New("one of module and moduleFile must be specified")
+	case p.ModuleFile != "":
+		contents, err := os.ReadFile(filepath.Clean(p.ModuleFile))
+		if err != nil {
+			return nil, fmt.Errorf("reading Rego module: %w", err)
+		}
+		source, filename = string(contents), p.ModuleFile
+	}
+
+	var regoVersion ast.RegoVersion
+	switch p.RegoVersion {
+	case "", "v1":
+		regoVersion = ast.RegoV1
+	case "v0":
+		regoVersion = ast.RegoV0
+	default:
+		return nil, fmt.Errorf("invalid Rego version %q, must be v1 or v0", p.RegoVersion)
+	}
+	module, err := ast.ParseModuleWithOpts(filename, source, ast.ParserOptions{RegoVersion: regoVersion})
+	if err != nil {
+		return nil, fmt.Errorf("parsing Rego module: %w", err)
+	}
+	for _, rule := range module.Rules {
+		for _, name := range resultRules {
+			if rule.Head.Name.String() == name {
+				return module, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("the Rego module must define a %s rule", strings.Join(resultRules, " or "))
+}
+
+// evaluate returns the messages of the entries that the module reports for the object.
+func evaluate(ctx context.Context, queries *util.ContextCache, lintCtx lintcontext.LintContext, object lintcontext.Object) ([]string, error) {
+	query, err := queries.Get(lintCtx)
+	if err != nil {
+		return nil, err
+	}
+	objectMap, err := util.ToMap(object.K8sObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert object to map: %w", err)
+	}
+	results, err := query.(rego.PreparedEvalQuery).Eval(ctx, rego.EvalInput(map[string]interface{}{
+		"object": objectMap,
+		"review": map[string]interface{}{"object": objectMap},
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []string
+	for _, result := range results {
+		for _, rule := range resultRules {
+			entries, _ := result.Bindings[rule].([]interface{})
+			for _, entry := range entries {
+				messages = append(messages, message(entry))
+			}
+		}
+	}
+	sort.Strings(messages)
+	return messages, nil
+}
+
+// message returns the message of an entry of a deny or violation rule, which is either a string, or an object
+// with a msg field as in Gatekeeper.
+func message(entry interface{}) string {
+	switch entry := entry.(type) {
+	case string:
+		return entry
+	case map[string]interface{}:
+		if msg, ok := entry["msg"].(string); ok {
+			return msg
+		}
+	}
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Sprint(entry)
+	}
+	return string(encoded)
+}
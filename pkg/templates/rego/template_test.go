@@ -0,0 +1,103 @@
+package rego
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/rego/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+)
+
+const (
+	teamLabelModule = `package kubelinter.team
+
+deny contains msg if {
+	input.object.kind == "Deployment"
+	not input.object.metadata.labels.team
+	msg := sprintf("deployment %s has no team label", [input.object.metadata.name])
+}
+`
+
+	// A Gatekeeper style policy, in Rego v0 syntax, that relates the object to the other objects.
+	uniqueLabelsModule = `package k8suniquelabels
+
+violation[{"msg": msg, "details": {}}] {
+	obj := input.review.object
+	other := data.objects[_]
+	other.metadata.name != obj.metadata.name
+	other.metadata.labels.app == obj.metadata.labels.app
+	msg := sprintf("app label %s is also used by %s", [obj.metadata.labels.app, other.metadata.name])
+}
+`
+)
+
+func TestRego(t *testing.T) {
+	suite.Run(t, new(RegoTestSuite))
+}
+
+type RegoTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *RegoTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *RegoTestSuite) addDeployment(name string, labels map[string]string) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		deployment.Labels = labels
+	})
+}
+
+func (s *RegoTestSuite) TestDeny() {
+	s.addDeployment("labeled", map[string]string{"team": "payments"})
+	s.addDeployment("unlabeled", nil)
+
+	modulePath := filepath.Join(s.T().TempDir(), "team.rego")
+	s.Require().NoError(os.WriteFile(modulePath, []byte(teamLabelModule), 0o600))
+
+	expected := map[string][]diagnostic.Diagnostic{
+		"unlabeled": {{Message: "deployment unlabeled has no team label"}},
+	}
+	s.Validate(s.ctx, []templates.TestCase{
+		{Param: params.Params{Module: teamLabelModule}, Diagnostics: expected},
+		{Param: params.Params{ModuleFile: modulePath}, Diagnostics: expected},
+	})
+}
+
+func (s *RegoTestSuite) TestViolationWithObjects() {
+	s.addDeployment("first", map[string]string{"app": "shop"})
+	s.addDeployment("second", map[string]string{"app": "shop"})
+	s.addDeployment("third", map[string]string{"app": "blog"})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{Module: uniqueLabelsModule, RegoVersion: "v0"},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"first":  {{Message: "app label shop is also used by second"}},
+				"second": {{Message: "app label shop is also used by first"}},
+			},
+		},
+	})
+}
+
+func (s *RegoTestSuite) TestInvalidModules() {
+	s.Validate(s.ctx, []templates.TestCase{
+		{Param: params.Params{}, ExpectInstantiationError: true},
+		{Param: params.Params{Module: teamLabelModule, ModuleFile: "team.rego"}, ExpectInstantiationError: true},
+		{Param: params.Params{ModuleFile: "does-not-exist.rego"}, ExpectInstantiationError: true},
+		{Param: params.Params{Module: "package empty\n\nallow := true\n"}, ExpectInstantiationError: true},
+		{Param: params.Params{Module: teamLabelModule, RegoVersion: "v2"}, ExpectInstantiationError: true},
+		// v0 syntax is rejected unless the version is set.
+		{Param: params.Params{Module: uniqueLabelsModule}, ExpectInstantiationError: true},
+	})
+}
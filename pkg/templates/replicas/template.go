@@ -40,7 +40,11 @@ func init() {
 				return []diagnostic.Diagnostic{
 					{Message: fmt.Sprintf("object has %d %s but minimum required replicas is %d",
 						replicas, stringutils.Ternary(replicas > 1, "replicas", "replica"),
-						p.MinReplicas)},
+						p.MinReplicas),
+						Path:     "spec.replicas",
+						Value:    fmt.Sprint(replicas),
+						Expected: fmt.Sprintf("at least %d", p.MinReplicas),
+					},
 				}
 			}, nil
 		}),
@@ -11,6 +11,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/restartpolicy/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 	coreV1 "k8s.io/api/core/v1"
 )
 
@@ -18,7 +19,10 @@ const (
 	templateKey = "restart-policy"
 )
 
-var acceptedRestartPolicies = []coreV1.RestartPolicy{coreV1.RestartPolicyAlways, coreV1.RestartPolicyOnFailure}
+var (
+	acceptedRestartPolicies     = []coreV1.RestartPolicy{coreV1.RestartPolicyAlways, coreV1.RestartPolicyOnFailure}
+	acceptedRestartPoliciesDesc = fmt.Sprintf("%s, %s", coreV1.RestartPolicyAlways, coreV1.RestartPolicyOnFailure)
+)
 
 func init() {
 	templates.Register(check.Template{
@@ -41,8 +45,14 @@ func init() {
 						return nil
 					}
 				}
+				podSpecPath, _ := extract.PodSpecPath(object.K8sObject)
 				return []diagnostic.Diagnostic{
-					{Message: fmt.Sprintf("object has a restart policy defined with '%s' but the only accepted restart policies are '%s'", spec.RestartPolicy, acceptedRestartPolicies)},
+					{
+						Message:  fmt.Sprintf("object has a restart policy defined with '%s' but the only accepted restart policies are '%s'", spec.RestartPolicy, acceptedRestartPolicies),
+						Path:     util.JoinPath(podSpecPath, "restartPolicy"),
+						Value:    string(spec.RestartPolicy),
+						Expected: "one of " + acceptedRestartPoliciesDesc,
+					},
 				}
 			}, nil
 		}),
@@ -11,6 +11,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/runasnonroot/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -46,6 +47,15 @@ func effectiveRunAsGroup(podSC *v1.PodSecurityContext, containerSC *v1.SecurityC
 	return nil
 }
 
+// securityContextPath returns the path of the given field of the security context that sets its effective value: the
+// one of the container if it sets the field, and the one of the pod otherwise.
+func securityContextPath(podSpecPath, containerPath string, setByContainer bool, field string) string {
+	if setByContainer {
+		return util.JoinPath(containerPath, "securityContext", field)
+	}
+	return util.JoinPath(podSpecPath, "securityContext", field)
+}
+
 func init() {
 	templates.Register(check.Template{
 		HumanName:   "Run as non-root user",
@@ -62,31 +72,59 @@ func init() {
 				if !found {
 					return nil
 				}
+				podSpecPath, _ := extract.PodSpecPath(object.K8sObject)
+				containerPaths := podSpec.AllContainerPaths()
 				var results []diagnostic.Diagnostic
-				for _, container := range podSpec.AllContainers() {
-					runAsGroup := effectiveRunAsGroup(podSpec.SecurityContext, container.SecurityContext)
+				for i, container := range podSpec.AllContainers() {
+					containerPath := util.JoinPath(podSpecPath, containerPaths[i])
+					containerSC := container.SecurityContext
+					runAsGroup := effectiveRunAsGroup(podSpec.SecurityContext, containerSC)
 					if runAsGroup != nil && *runAsGroup == 0 {
 						results = append(results, diagnostic.Diagnostic{
-							Message: fmt.Sprintf("container %q is set to runAsGroup 0", container.Name),
+							Message:   fmt.Sprintf("container %q is set to runAsGroup 0", container.Name),
+							Path:      securityContextPath(podSpecPath, containerPath, containerSC != nil && containerSC.RunAsGroup != nil, "runAsGroup"),
+							Code:      templateKey + "/run-as-group-zero",
+							Container: container.Name,
+							Value:     "0",
+							Expected:  "a non-zero runAsGroup",
 						})
 					}
 
-					runAsUser := effectiveRunAsUser(podSpec.SecurityContext, container.SecurityContext)
+					runAsUser := effectiveRunAsUser(podSpec.SecurityContext, containerSC)
 					// runAsUser explicitly set to non-root. All good.
 					if runAsUser != nil && *runAsUser > 0 {
 						continue
 					}
-					runAsNonRoot := effectiveRunAsNonRoot(podSpec.SecurityContext, container.SecurityContext)
+					runAsNonRoot := effectiveRunAsNonRoot(podSpec.SecurityContext, containerSC)
 					if runAsNonRoot {
 						// runAsNonRoot set, but runAsUser set to 0. This will result in a runtime failure.
 						if runAsUser != nil && *runAsUser == 0 {
 							results = append(results, diagnostic.Diagnostic{
-								Message: fmt.Sprintf("container %q is set to runAsNonRoot, but runAsUser set to %d", container.Name, *runAsUser),
+								Message:   fmt.Sprintf("container %q is set to runAsNonRoot, but runAsUser set to %d", container.Name, *runAsUser),
+								Path:      securityContextPath(podSpecPath, containerPath, containerSC != nil && containerSC.RunAsUser != nil, "runAsUser"),
+								Code:      templateKey + "/run-as-user-zero",
+								Container: container.Name,
+								Value:     "0",
+								Expected:  "a non-zero runAsUser",
 							})
 						}
 						continue
 					}
-					results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("container %q is not set to runAsNonRoot", container.Name)})
+					// Point at runAsNonRoot if it is set to false, and at the container if it is not set at all.
+					path := containerPath
+					switch {
+					case containerSC != nil && containerSC.RunAsNonRoot != nil:
+						path = securityContextPath(podSpecPath, containerPath, true, "runAsNonRoot")
+					case podSpec.SecurityContext != nil && podSpec.SecurityContext.RunAsNonRoot != nil:
+						path = securityContextPath(podSpecPath, containerPath, false, "runAsNonRoot")
+					}
+					results = append(results, diagnostic.Diagnostic{
+						Message:   fmt.Sprintf("container %q is not set to runAsNonRoot", container.Name),
+						Path:      path,
+						Code:      templateKey + "/not-run-as-non-root",
+						Container: container.Name,
+						Expected:  "runAsNonRoot set to true, or a non-zero runAsUser",
+					})
 				}
 				return results
 			}, nil
@@ -117,6 +117,53 @@ func (s *RunAsNonRootTestSuite) TestMissingRunAsGroupAllowedWhenRunAsUserNonRoot
 	})
 }
 
+func (s *RunAsNonRootTestSuite) TestPaths() {
+	const deploymentName = "paths"
+
+	s.ctx.AddMockDeployment(s.T(), deploymentName)
+	s.ctx.AddSecurityContextToDeployment(s.T(), deploymentName, &v1.PodSecurityContext{
+		RunAsGroup:   int64Ptr(0),
+		RunAsNonRoot: boolPtr(false),
+	})
+	s.ctx.AddContainerToDeployment(s.T(), deploymentName, v1.Container{Name: "pod-settings"})
+	s.ctx.AddContainerToDeployment(s.T(), deploymentName, v1.Container{
+		Name: "own-settings",
+		SecurityContext: &v1.SecurityContext{
+			RunAsGroup:   int64Ptr(0),
+			RunAsUser:    int64Ptr(0),
+			RunAsNonRoot: boolPtr(true),
+		},
+	})
+
+	checkFunc, err := s.Template.Instantiate(params.Params{})
+	s.Require().NoError(err)
+	var paths []string
+	for _, d := range checkFunc(s.ctx, s.ctx.Objects()[0]) {
+		paths = append(paths, d.Code+" "+d.Path)
+	}
+	// Findings point at the security context that sets the effective value, or at the container if none does.
+	s.Equal([]string{
+		"run-as-non-root/run-as-group-zero spec.template.spec.securityContext.runAsGroup",
+		"run-as-non-root/not-run-as-non-root spec.template.spec.securityContext.runAsNonRoot",
+		"run-as-non-root/run-as-group-zero spec.template.spec.containers[1].securityContext.runAsGroup",
+		"run-as-non-root/run-as-user-zero spec.template.spec.containers[1].securityContext.runAsUser",
+	}, paths)
+
+	s.ctx.AddMockDeployment(s.T(), "unset")
+	s.ctx.AddContainerToDeployment(s.T(), "unset", v1.Container{Name: "app"})
+	for _, obj := range s.ctx.Objects() {
+		if obj.K8sObject.GetName() == "unset" {
+			diagnostics := checkFunc(s.ctx, obj)
+			s.Require().Len(diagnostics, 1)
+			s.Equal("spec.template.spec.containers[0]", diagnostics[0].Path)
+		}
+	}
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
 func int64Ptr(v int64) *int64 {
 	return &v
 }
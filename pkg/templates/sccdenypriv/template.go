@@ -32,7 +32,7 @@ func init() {
 				state, found := extract.SCCallowPrivilegedContainer(object.K8sObject)
 				if found && state == p.AllowPrivilegedContainer {
 					return []diagnostic.Diagnostic{
-						{Message: fmt.Sprintf("SCC has allowPrivilegedContainer set to %v", state)},
+						{Message: fmt.Sprintf("SCC has allowPrivilegedContainer set to %v", state), Path: "allowPrivilegedContainer", Value: fmt.Sprint(state)},
 					}
 				}
 				return nil
@@ -13,6 +13,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/serviceaccount/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
 const (
@@ -44,7 +45,12 @@ func init() {
 				}
 				sa := stringutils.OrDefault(podSpec.ServiceAccountName, podSpec.DeprecatedServiceAccount)
 				if saMatcher(sa) {
-					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("found matching serviceAccount (%q)", sa)}}
+					podSpecPath, _ := extract.PodSpecPath(object.K8sObject)
+					return []diagnostic.Diagnostic{{
+						Message: fmt.Sprintf("found matching serviceAccount (%q)", sa),
+						Path:    util.JoinPath(podSpecPath, stringutils.Ternary(podSpec.ServiceAccountName != "", "serviceAccountName", "serviceAccount")),
+						Value:   sa,
+					}}
 				}
 				return nil
 			}, nil
@@ -34,7 +34,12 @@ func init() {
 				var results []diagnostic.Diagnostic
 				for _, servicetype := range p.ForbiddenServiceTypes {
 					if strings.EqualFold(string(service.Spec.Type), servicetype) {
-						results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("%q service type is forbidden.", servicetype)})
+						results = append(results, diagnostic.Diagnostic{
+							Message:  fmt.Sprintf("%q service type is forbidden.", servicetype),
+							Path:     "spec.type",
+							Value:    string(service.Spec.Type),
+							Expected: fmt.Sprintf("a service type other than %s", strings.Join(p.ForbiddenServiceTypes, ", ")),
+						})
 					}
 				}
 				return results
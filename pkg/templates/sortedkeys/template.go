@@ -97,6 +97,11 @@ func checkNode(node ast.Node, path string, recursive bool) []diagnostic.Diagnost
 							strings.Join(sortedKeys, ", "),
 							strings.Join(keys, ", "),
 						),
+						Path: path,
+						Fix: &diagnostic.Fix{
+							Description: "Sort the keys",
+							Edits:       []diagnostic.Edit{{Kind: diagnostic.EditSortKeys, Path: path}},
+						},
 					})
 					// Only report once per level
 					break
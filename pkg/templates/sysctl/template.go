@@ -12,6 +12,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/sysctl/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
 func init() {
@@ -32,10 +33,15 @@ func init() {
 				}
 				var results []diagnostic.Diagnostic
 				if podSpec.SecurityContext != nil && podSpec.SecurityContext.Sysctls != nil {
-					for _, ctl := range podSpec.SecurityContext.Sysctls {
+					podSpecPath, _ := extract.PodSpecPath(object.K8sObject)
+					for i, ctl := range podSpec.SecurityContext.Sysctls {
 						for _, unsafeCtl := range p.UnsafeSysCtls {
 							if strings.HasPrefix(ctl.Name, unsafeCtl) {
-								results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("resource specifies unsafe sysctl %q.", ctl.Name)})
+								results = append(results, diagnostic.Diagnostic{
+									Message: fmt.Sprintf("resource specifies unsafe sysctl %q.", ctl.Name),
+									Path:    util.JoinPath(podSpecPath, fmt.Sprintf("securityContext.sysctls[%d]", i)),
+									Value:   ctl.Name,
+								})
 							}
 						}
 					}
@@ -12,6 +12,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/targetport/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	k8sValidation "k8s.io/apimachinery/pkg/util/validation"
@@ -35,7 +36,8 @@ func init() {
 			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
 				podSpec, foundPodSpec := extract.PodSpec(object.K8sObject)
 				if foundPodSpec {
-					return findPodPorts(&podSpec)
+					podSpecPath, _ := extract.PodSpecPath(object.K8sObject)
+					return findPodPorts(&podSpec, podSpecPath)
 				}
 
 				service, foundService := object.K8sObject.(*coreV1.Service)
@@ -50,12 +52,13 @@ func init() {
 	})
 }
 
-func findPodPorts(podSpec *customtypes.PodSpec) []diagnostic.Diagnostic {
+func findPodPorts(podSpec *customtypes.PodSpec, podSpecPath string) []diagnostic.Diagnostic {
 	var results []diagnostic.Diagnostic
 
 	containers := podSpec.AllContainers()
-	for _, container := range containers {
-		for _, port := range container.Ports {
+	containerPaths := podSpec.AllContainerPaths()
+	for i, container := range containers {
+		for j, port := range container.Ports {
 			if port.Name == "" {
 				continue
 			}
@@ -65,6 +68,8 @@ func findPodPorts(podSpec *customtypes.PodSpec) []diagnostic.Diagnostic {
 				results = append(results, diagnostic.Diagnostic{
 					Message: fmt.Sprintf("port name %q in container %q %s",
 						port.Name, container.Name, violation),
+					Path:      util.JoinPath(podSpecPath, containerPaths[i], fmt.Sprintf("ports[%d].name", j)),
+					Container: container.Name,
 				})
 			}
 		}
@@ -76,7 +81,7 @@ func findPodPorts(podSpec *customtypes.PodSpec) []diagnostic.Diagnostic {
 func findServicePorts(service *coreV1.Service) []diagnostic.Diagnostic {
 	var results []diagnostic.Diagnostic
 
-	for _, port := range service.Spec.Ports {
+	for i, port := range service.Spec.Ports {
 		targetPort := port.TargetPort
 		if targetPort.Type == intstr.Int && targetPort.IntVal == 0 {
 			continue
@@ -88,6 +93,7 @@ func findServicePorts(service *coreV1.Service) []diagnostic.Diagnostic {
 				results = append(results, diagnostic.Diagnostic{
 					Message: fmt.Sprintf("port targetPort %q in service %q %s",
 						targetPort.String(), service.Name, violation),
+					Path: fmt.Sprintf("spec.ports[%d].targetPort", i),
 				})
 			}
 		}
@@ -98,6 +104,7 @@ func findServicePorts(service *coreV1.Service) []diagnostic.Diagnostic {
 				results = append(results, diagnostic.Diagnostic{
 					Message: fmt.Sprintf("port targetPort %q in service %q %s",
 						targetPort.String(), service.Name, violation),
+					Path: fmt.Sprintf("spec.ports[%d].targetPort", i),
 				})
 			}
 		}
@@ -191,14 +191,15 @@ func init() {
 				if !compiledRegex.MatchString(strategyType) {
 					newD := diagnostic.Diagnostic{
 						Message: fmt.Sprintf("object has %s strategy type but must match regex %s",
-							stringutils.Ternary(strategyType != "", strategyType, "no"), p.StrategyTypeRegex)}
+							stringutils.Ternary(strategyType != "", strategyType, "no"), p.StrategyTypeRegex),
+						Path: strategy.Path + ".type"}
 					diagnostics = append(diagnostics, newD)
 				}
 				if !strategy.RollingConfigExists {
 					return nil
 				}
 				if needsRollingUpdateDefinition(p) && !strategy.RollingConfigValid {
-					newD := diagnostic.Diagnostic{Message: "object has no rolling update parameters defined"}
+					newD := diagnostic.Diagnostic{Message: "object has no rolling update parameters defined", Path: strategy.RollingConfigPath}
 					diagnostics = append(diagnostics, newD)
 				}
 				if strategy.MaxUnavailableExists {
@@ -207,7 +208,7 @@ func init() {
 						maxStr := fmt.Sprintf("no more than %s", p.MaxPodsUnavailable)
 						msg := fmt.Sprintf("object has a max unavailable of %s but %s is required", strategy.MaxUnavailable.String(),
 							conditional(p.MinPodsUnavailable != "", minStr, p.MaxPodsUnavailable != "", maxStr, " and "))
-						newD := diagnostic.Diagnostic{Message: msg}
+						newD := diagnostic.Diagnostic{Message: msg, Path: strategy.RollingConfigPath + ".maxUnavailable"}
 						diagnostics = append(diagnostics, newD)
 					}
 				}
@@ -217,7 +218,7 @@ func init() {
 						maxStr := fmt.Sprintf("no more than %s", p.MaxSurge)
 						msg := fmt.Sprintf("object has a max surge of %s but %s is required", strategy.MaxSurge.String(),
 							conditional(p.MinSurge != "", minStr, p.MaxSurge != "", maxStr, " and "))
-						newD := diagnostic.Diagnostic{Message: msg}
+						newD := diagnostic.Diagnostic{Message: msg, Path: strategy.RollingConfigPath + ".maxSurge"}
 						diagnostics = append(diagnostics, newD)
 					}
 				}
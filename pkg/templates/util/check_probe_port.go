@@ -10,7 +10,9 @@ import (
 
 var sentinel = struct{}{}
 
-func CheckProbePort(container *v1.Container, probe *v1.Probe) []diagnostic.Diagnostic {
+// CheckProbePort checks that the port of the given probe of the container is exposed by the container. probeField is the
+// name of the field of the probe in the container, e.g. livenessProbe, which the paths of the diagnostics start with.
+func CheckProbePort(container *v1.Container, probe *v1.Probe, probeField string) []diagnostic.Diagnostic {
 	if probe == nil {
 		return nil
 	}
@@ -24,10 +26,14 @@ func CheckProbePort(container *v1.Container, probe *v1.Probe) []diagnostic.Diagn
 		ports[intstr.FromString(port.Name)] = sentinel
 	}
 
+	const exposedPorts = "a TCP port of the container"
 	if httpProbe := probe.HTTPGet; httpProbe != nil {
 		if _, ok := ports[httpProbe.Port]; !ok {
 			return []diagnostic.Diagnostic{{
-				Message: fmt.Sprintf("container %q does not expose port %s for the HTTPGet", container.Name, httpProbe.Port.String()),
+				Message:  fmt.Sprintf("container %q does not expose port %s for the HTTPGet", container.Name, httpProbe.Port.String()),
+				Path:     JoinPath(probeField, "httpGet.port"),
+				Value:    httpProbe.Port.String(),
+				Expected: exposedPorts,
 			}}
 		}
 	}
@@ -35,7 +41,10 @@ func CheckProbePort(container *v1.Container, probe *v1.Probe) []diagnostic.Diagn
 	if tcpProbe := probe.TCPSocket; tcpProbe != nil {
 		if _, ok := ports[tcpProbe.Port]; !ok {
 			return []diagnostic.Diagnostic{{
-				Message: fmt.Sprintf("container %q does not expose port %s for the TCPSocket", container.Name, tcpProbe.Port.String()),
+				Message:  fmt.Sprintf("container %q does not expose port %s for the TCPSocket", container.Name, tcpProbe.Port.String()),
+				Path:     JoinPath(probeField, "tcpSocket.port"),
+				Value:    tcpProbe.Port.String(),
+				Expected: exposedPorts,
 			}}
 		}
 	}
@@ -43,7 +52,10 @@ func CheckProbePort(container *v1.Container, probe *v1.Probe) []diagnostic.Diagn
 	if grpcProbe := probe.GRPC; grpcProbe != nil {
 		if _, ok := ports[intstr.FromInt32(grpcProbe.Port)]; !ok {
 			return []diagnostic.Diagnostic{{
-				Message: fmt.Sprintf("container %q does not expose port %d for the GRPC check", container.Name, grpcProbe.Port),
+				Message:  fmt.Sprintf("container %q does not expose port %d for the GRPC check", container.Name, grpcProbe.Port),
+				Path:     JoinPath(probeField, "grpc.port"),
+				Value:    fmt.Sprint(grpcProbe.Port),
+				Expected: exposedPorts,
 			}}
 		}
 	}
@@ -0,0 +1,97 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+)
+
+// contextCacheSize is the number of contexts whose values are cached. Objects are checked context by context, so
+// only a few contexts are in use at any time.
+const contextCacheSize = 16
+
+// A ContextCache caches a value that is computed from a lint context, such as the objects of the context converted
+// for a policy engine, so that it is computed once per context rather than once per object. Only the values of the
+// most recently used contexts are kept. It is safe for concurrent use.
+type ContextCache struct {
+	compute func(lintCtx lintcontext.LintContext) (interface{}, error)
+
+	lock    sync.Mutex
+	entries []*contextCacheEntry
+}
+
+type contextCacheEntry struct {
+	// objects identify the context by their backing array, since contexts are not necessarily comparable.
+	// Referencing them ensures that the array is not reused by another context while the entry is cached.
+	objects []lintcontext.Object
+	once    sync.Once
+
+	value interface{}
+	err   error
+}
+
+// NewContextCache returns a ContextCache that computes values with the given function.
+func NewContextCache(compute func(lintCtx lintcontext.LintContext) (interface{}, error)) *ContextCache {
+	return &ContextCache{compute: compute}
+}
+
+// Get returns the value for the given context, computing it if it is not cached.
+func (c *ContextCache) Get(lintCtx lintcontext.LintContext) (interface{}, error) {
+	objects := lintCtx.Objects()
+	if len(objects) == 0 {
+		return c.compute(lintCtx)
+	}
+	entry := c.entry(objects)
+	entry.once.Do(func() {
+		entry.value, entry.err = c.compute(lintCtx)
+	})
+	return entry.value, entry.err
+}
+
+func (c *ContextCache) entry(objects []lintcontext.Object) *contextCacheEntry {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for i, entry := range c.entries {
+		if &entry.objects[0] == &objects[0] && len(entry.objects) == len(objects) {
+			// Move the entry to the front, so that the least recently used entries are evicted first.
+			copy(c.entries[1:i+1], c.entries[:i])
+			c.entries[0] = entry
+			return entry
+		}
+	}
+	entry := &contextCacheEntry{objects: objects}
+	c.entries = append([]*contextCacheEntry{entry}, c.entries...)
+	if len(c.entries) > contextCacheSize {
+		c.entries = c.entries[:contextCacheSize]
+	}
+	return entry
+}
+
+// ToMap converts the Kubernetes object to a map, as it is encoded in JSON.
+func ToMap(obj interface{}) (map[string]interface{}, error) {
+	bytes, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal object: %w", err)
+	}
+	var output map[string]interface{}
+	if err := json.Unmarshal(bytes, &output); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal object: %w", err)
+	}
+	return output, nil
+}
+
+// ObjectMaps converts all the objects of the context with ToMap.
+func ObjectMaps(lintCtx lintcontext.LintContext) ([]map[string]interface{}, error) {
+	objects := lintCtx.Objects()
+	maps := make([]map[string]interface{}, len(objects))
+	for i, obj := range objects {
+		objMap, err := ToMap(obj.K8sObject)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert object %s to map: %w", obj.GetK8sObjectName().String(), err)
+		}
+		maps[i] = objMap
+	}
+	return maps, nil
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"fmt"
+	"sort"
 
 	"golang.stackrox.io/kube-linter/internal/stringutils"
 	"golang.stackrox.io/kube-linter/pkg/check"
@@ -35,10 +36,18 @@ func ConstructForbiddenMapMatcher(key, value, fieldType string) (check.Func, err
 
 	return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
 		fields := extractFunc(object.K8sObject)
-		for k, v := range fields {
-			if keyMatcher(k) && valueMatcher(v) {
+		// Go through the keys in order, so that the reported value does not depend on map iteration order.
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if v := fields[k]; keyMatcher(k) && valueMatcher(v) {
 				return []diagnostic.Diagnostic{{
 					Message: fmt.Sprintf("%s matching \"%s=%s\" found", fieldType, key, stringutils.OrDefault(value, "<any>")),
+					Path:    fmt.Sprintf("metadata.%ss[%q]", fieldType, k),
+					Value:   fmt.Sprintf("%s=%s", k, v),
 				}}
 			}
 		}
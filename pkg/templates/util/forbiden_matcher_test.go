@@ -35,34 +35,34 @@ func TestConstructForbiddenMapMatcher(t *testing.T) {
 		expected  []diagnostic.Diagnostic
 	}{{
 		key: "a", value: "1", fieldType: "annotation",
-		expected: []diagnostic.Diagnostic{{Message: `annotation matching "a=1" found`}},
+		expected: []diagnostic.Diagnostic{{Message: `annotation matching "a=1" found`, Path: `metadata.annotations["a"]`, Value: "a=1"}},
 	}, {
 		key: "a", value: "3", fieldType: "label",
-		expected: []diagnostic.Diagnostic{{Message: `label matching "a=3" found`}},
+		expected: []diagnostic.Diagnostic{{Message: `label matching "a=3" found`, Path: `metadata.labels["a"]`, Value: "a=3"}},
 	}, {
 		key: "e", value: "f", fieldType: "annotation",
 	}, {
 		key: "x", value: "y", fieldType: "label",
 	}, {
 		key: "a", value: "", fieldType: "label",
-		expected: []diagnostic.Diagnostic{{Message: `label matching "a=<any>" found`}},
+		expected: []diagnostic.Diagnostic{{Message: `label matching "a=<any>" found`, Path: `metadata.labels["a"]`, Value: "a=3"}},
 	}, {
 		key: "a", value: ".*", fieldType: "label",
-		expected: []diagnostic.Diagnostic{{Message: `label matching "a=.*" found`}},
+		expected: []diagnostic.Diagnostic{{Message: `label matching "a=.*" found`, Path: `metadata.labels["a"]`, Value: "a=3"}},
 	}, {
 		key: "a", value: "[0-2]", fieldType: "annotation",
-		expected: []diagnostic.Diagnostic{{Message: `annotation matching "a=[0-2]" found`}},
+		expected: []diagnostic.Diagnostic{{Message: `annotation matching "a=[0-2]" found`, Path: `metadata.annotations["a"]`, Value: "a=1"}},
 	}, {
 		key: "a", value: "[0-2]", fieldType: "label",
 	}, {
 		key: "a", value: "!2", fieldType: "label",
-		expected: []diagnostic.Diagnostic{{Message: `label matching "a=!2" found`}},
+		expected: []diagnostic.Diagnostic{{Message: `label matching "a=!2" found`, Path: `metadata.labels["a"]`, Value: "a=3"}},
 	}, {
 		key: "!x", value: "", fieldType: "label",
-		expected: []diagnostic.Diagnostic{{Message: `label matching "!x=<any>" found`}},
+		expected: []diagnostic.Diagnostic{{Message: `label matching "!x=<any>" found`, Path: `metadata.labels["a"]`, Value: "a=3"}},
 	}, {
 		key: "!x", value: "", fieldType: "annotation",
-		expected: []diagnostic.Diagnostic{{Message: `annotation matching "!x=<any>" found`}},
+		expected: []diagnostic.Diagnostic{{Message: `annotation matching "!x=<any>" found`, Path: `metadata.annotations["a"]`, Value: "a=1"}},
 	}}
 	for _, tt := range tests {
 		t.Run(fmt.Sprintf("%s=%s %s", tt.key, tt.value, tt.fieldType), func(t *testing.T) {
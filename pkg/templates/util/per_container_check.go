@@ -11,16 +11,21 @@ import (
 // PerContainerCheck returns a check that abstracts away some of the boilerplate of writing a check
 // that applies to containers. The given function is passed each container, and is allowed to return
 // diagnostics if an error is found.
+// The Path of returned diagnostics is interpreted relative to the container (e.g. securityContext.privileged),
+// and is rewritten to be relative to the object. Diagnostics without a Path point at the container itself.
+// The Container of returned diagnostics defaults to the name of the container.
 func PerContainerCheck(matchFunc func(container *v1.Container) []diagnostic.Diagnostic) check.Func {
 	return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
 		podSpec, found := extract.PodSpec(object.K8sObject)
 		if !found {
 			return nil
 		}
+		podSpecPath, _ := extract.PodSpecPath(object.K8sObject)
 		var results []diagnostic.Diagnostic
 		containers := podSpec.AllContainers()
+		containerPaths := podSpec.AllContainerPaths()
 		for i := range containers {
-			results = append(results, matchFunc(&containers[i])...)
+			results = append(results, inContainer(JoinPath(podSpecPath, containerPaths[i]), containers[i].Name, matchFunc(&containers[i]))...)
 		}
 		return results
 	}
@@ -29,17 +34,50 @@ func PerContainerCheck(matchFunc func(container *v1.Container) []diagnostic.Diag
 // PerNonInitContainerCheck returns a check that abstracts away some of the boilerplate of writing a check
 // that applies to all non-init containers. The given function is passed each non-init container,
 // and is allowed to return diagnostics if an error is found.
+// Paths and containers of returned diagnostics are handled the same way as in PerContainerCheck.
 func PerNonInitContainerCheck(matchFunc func(container *v1.Container) []diagnostic.Diagnostic) check.Func {
 	return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
 		podSpec, found := extract.PodSpec(object.K8sObject)
 		if !found {
 			return nil
 		}
+		podSpecPath, _ := extract.PodSpecPath(object.K8sObject)
 		var results []diagnostic.Diagnostic
 		containers := podSpec.NonInitContainers()
+		containerPaths := podSpec.NonInitContainerPaths()
 		for i := range containers {
-			results = append(results, matchFunc(&containers[i])...)
+			results = append(results, inContainer(JoinPath(podSpecPath, containerPaths[i]), containers[i].Name, matchFunc(&containers[i]))...)
 		}
 		return results
 	}
 }
+
+// JoinPath joins field path elements with dots, skipping empty elements.
+func JoinPath(elems ...string) string {
+	var path string
+	for _, elem := range elems {
+		if elem == "" {
+			continue
+		}
+		if path != "" && elem[0] != '[' {
+			path += "."
+		}
+		path += elem
+	}
+	return path
+}
+
+// inContainer makes the paths of the diagnostics of a container relative to the object, given the path of the
+// container, and sets their container.
+func inContainer(prefix, containerName string, diagnostics []diagnostic.Diagnostic) []diagnostic.Diagnostic {
+	for i := range diagnostics {
+		if diagnostics[i].Container == "" {
+			diagnostics[i].Container = containerName
+		}
+		diagnostics[i].Path = JoinPath(prefix, diagnostics[i].Path)
+		diagnostics[i].Fix.PrefixPaths(func(path string) string {
+			return JoinPath(prefix, path)
+		})
+	}
+	return diagnostics
+}
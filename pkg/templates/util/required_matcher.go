@@ -41,7 +41,9 @@ func ConstructRequiredMapMatcher(key, value, fieldType string) (check.Func, erro
 			}
 		}
 		return []diagnostic.Diagnostic{{
-			Message: fmt.Sprintf("no %s matching \"%s=%s\" found", fieldType, key, stringutils.OrDefault(value, "<any>")),
+			Message:  fmt.Sprintf("no %s matching \"%s=%s\" found", fieldType, key, stringutils.OrDefault(value, "<any>")),
+			Path:     fmt.Sprintf("metadata.%ss", fieldType),
+			Expected: fmt.Sprintf("%s=%s", key, stringutils.OrDefault(value, "<any>")),
 		}}
 	}, nil
 }
@@ -39,10 +39,10 @@ func TestConstructRequiredMapMatcher(t *testing.T) {
 		key: "a", value: "3", fieldType: "label",
 	}, {
 		key: "e", value: "f", fieldType: "annotation",
-		expected: []diagnostic.Diagnostic{{Message: `no annotation matching "e=f" found`}},
+		expected: []diagnostic.Diagnostic{{Message: `no annotation matching "e=f" found`, Path: "metadata.annotations", Expected: "e=f"}},
 	}, {
 		key: "x", value: "y", fieldType: "label",
-		expected: []diagnostic.Diagnostic{{Message: `no label matching "x=y" found`}},
+		expected: []diagnostic.Diagnostic{{Message: `no label matching "x=y" found`, Path: "metadata.labels", Expected: "x=y"}},
 	}, {
 		key: "a", value: "", fieldType: "label",
 	}, {
@@ -51,7 +51,7 @@ func TestConstructRequiredMapMatcher(t *testing.T) {
 		key: "a", value: "[0-2]", fieldType: "annotation",
 	}, {
 		key: "a", value: "[0-2]", fieldType: "label",
-		expected: []diagnostic.Diagnostic{{Message: `no label matching "a=[0-2]" found`}},
+		expected: []diagnostic.Diagnostic{{Message: `no label matching "a=[0-2]" found`, Path: "metadata.labels", Expected: "a=[0-2]"}},
 	}, {
 		key: "a", value: "!2", fieldType: "label",
 	}, {
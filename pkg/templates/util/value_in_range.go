@@ -1,5 +1,7 @@
 package util
 
+import "fmt"
+
 // ValueInRange returns whether the given quantity is in the range between the lowerBound and the upperBound (inclusive).
 // A nil upper bound is interpreted as infinity.
 func ValueInRange(value, lowerBound int, upperBound *int) bool {
@@ -11,3 +13,19 @@ func ValueInRange(value, lowerBound int, upperBound *int) bool {
 	}
 	return true
 }
+
+// DescribeOutsideRange describes the values that are outside the range between the lowerBound and the upperBound
+// (inclusive), formatted with the given function, for the Expected field of diagnostics of values in the range.
+// A nil upper bound is interpreted as infinity.
+func DescribeOutsideRange(lowerBound int, upperBound *int, format func(int) string) string {
+	switch {
+	case lowerBound > 0 && upperBound != nil:
+		return fmt.Sprintf("less than %s or more than %s", format(lowerBound), format(*upperBound))
+	case lowerBound > 0:
+		return "less than " + format(lowerBound)
+	case upperBound != nil:
+		return "more than " + format(*upperBound)
+	default:
+		return ""
+	}
+}
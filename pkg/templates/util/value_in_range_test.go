@@ -81,3 +81,22 @@ func TestValueInRange(t *testing.T) {
 		})
 	}
 }
+
+func TestDescribeOutsideRange(t *testing.T) {
+	format := func(value int) string { return fmt.Sprintf("%dm", value) }
+	for _, testCase := range []struct {
+		lowerBound int
+		upperBound *int
+		expected   string
+	}{
+		{upperBound: pointers.Int(0), expected: "more than 0m"},
+		{lowerBound: 100, expected: "less than 100m"},
+		{lowerBound: 100, upperBound: pointers.Int(200), expected: "less than 100m or more than 200m"},
+		{},
+	} {
+		c := testCase
+		t.Run(fmt.Sprintf("%+v", c), func(t *testing.T) {
+			assert.Equal(t, c.expected, DescribeOutsideRange(c.lowerBound, c.upperBound, format))
+		})
+	}
+}
@@ -34,10 +34,11 @@ func init() {
 					return nil
 				}
 				var diagnostics []diagnostic.Diagnostic
-				for _, vct := range sts.VolumeClaimTemplates {
+				for i, vct := range sts.VolumeClaimTemplates {
 					if vct.Annotations == nil || vct.Annotations[p.Annotation] == "" {
 						diagnostics = append(diagnostics, diagnostic.Diagnostic{
 							Message: fmt.Sprintf("StatefulSet's VolumeClaimTemplate is missing required annotation: %s", p.Annotation),
+							Path:    fmt.Sprintf("spec.volumeClaimTemplates[%d].metadata.annotations", i),
 						})
 					}
 				}
@@ -13,10 +13,12 @@ import (
 	rbacV1 "k8s.io/api/rbac/v1"
 )
 
+const templateKey = "wildcard-in-rules"
+
 func init() {
 	templates.Register(check.Template{
 		HumanName:   "Wildcard Use in Role and ClusterRole Rules",
-		Key:         "wildcard-in-rules",
+		Key:         templateKey,
 		Description: "Flag Roles and ClusterRoles that use wildcard * in rules",
 		SupportedObjectKinds: config.ObjectKindsDesc{
 			ObjectKinds: []string{
@@ -45,15 +47,25 @@ func init() {
 // find wildcards used in rules
 func findWildCard(rules []rbacV1.PolicyRule) []diagnostic.Diagnostic {
 	results := []diagnostic.Diagnostic{}
-	for _, rule := range rules {
-		for _, item := range rule.Resources {
+	for i, rule := range rules {
+		for j, item := range rule.Resources {
 			if item == "*" {
-				results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("wildcard %q in resource specification", item)})
+				results = append(results, diagnostic.Diagnostic{
+					Message: fmt.Sprintf("wildcard %q in resource specification", item),
+					Path:    fmt.Sprintf("rules[%d].resources[%d]", i, j),
+					Code:    templateKey + "/resources",
+					Value:   item,
+				})
 			}
 		}
-		for _, item := range rule.Verbs {
+		for j, item := range rule.Verbs {
 			if item == "*" {
-				results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("wildcard %q in verb specification", item)})
+				results = append(results, diagnostic.Diagnostic{
+					Message: fmt.Sprintf("wildcard %q in verb specification", item),
+					Path:    fmt.Sprintf("rules[%d].verbs[%d]", i, j),
+					Code:    templateKey + "/verbs",
+					Value:   item,
+				})
 			}
 		}
 	}
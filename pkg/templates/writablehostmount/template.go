@@ -10,6 +10,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/lintcontext"
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 	"golang.stackrox.io/kube-linter/pkg/templates/writablehostmount/internal/params"
 )
 
@@ -38,14 +39,22 @@ func init() {
 				if len(hostPaths) == 0 {
 					return nil
 				}
+				podSpecPath, _ := extract.PodSpecPath(object.K8sObject)
+				containerPaths := podSpec.AllContainerPaths()
 				var results []diagnostic.Diagnostic
-				for _, container := range podSpec.AllContainers() {
-					for _, mount := range container.VolumeMounts {
+				for i, container := range podSpec.AllContainers() {
+					for j, mount := range container.VolumeMounts {
 						if mount.ReadOnly {
 							continue
 						}
 						if hostPath, exists := hostPaths[mount.Name]; exists {
-							results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("container %s mounts path %s on the host as writable", container.Name, hostPath)})
+							results = append(results, diagnostic.Diagnostic{
+								Message:   fmt.Sprintf("container %s mounts path %s on the host as writable", container.Name, hostPath),
+								Path:      util.JoinPath(podSpecPath, containerPaths[i], fmt.Sprintf("volumeMounts[%d]", j)),
+								Container: container.Name,
+								Value:     hostPath,
+								Expected:  "a read-only mount",
+							})
 						}
 					}
 				}